@@ -1,18 +1,27 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"davet.link/configs/csrfconfig"
 	"davet.link/configs/databaseconfig"
+	"davet.link/configs/envconfig"
 	"davet.link/configs/fileconfig"
 	"davet.link/configs/logconfig"
 	"davet.link/configs/sessionconfig"
+	"davet.link/middlewares"
 	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/scheduler"
+	"davet.link/pkg/slugpolicy"
 	"davet.link/pkg/templatehelpers"
 	"davet.link/routes"
+	"davet.link/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/template/html/v2"
@@ -38,7 +47,12 @@ func main() {
 	fileconfig.InitFileConfig()
 
 	fileconfig.Config.SetAllowedExtensions("cards", []string{"jpg", "png", "webp"})
+	fileconfig.Config.SetAllowedExtensions("organizations", []string{"jpg", "png", "webp"})
 	fileconfig.Config.SetAllowedExtensions("invitations", []string{"jpeg", "png"})
+	fileconfig.Config.SetAllowedExtensions("imports", []string{"csv", "xlsx"})
+	fileconfig.Config.SetAllowedExtensions("guestbook", []string{"jpg", "jpeg", "png", "webp"})
+	fileconfig.Config.SetAllowedExtensions("gallery", []string{"jpg", "jpeg", "png", "webp"})
+
 
 	engine := html.New("./views", ".html")
 	engine.AddFunc("getFlashMessages", flashmessages.GetFlashMessages)
@@ -46,6 +60,11 @@ func main() {
 
 	app := fiber.New(fiber.Config{
 		Views: engine,
+		// Gövdeler akış olarak okunur ve sınır BodyLimitMiddleware ile gövde okunmadan uygulanır.
+		// Böylece yalnızca galeri yükleme rotaları varsayılan 4 MB'tan büyük gövde kabul eder;
+		// yüklenen dosyalar belleğe değil geçici dosyalara yazılır.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -69,10 +88,77 @@ func main() {
 
 	app.Static("/", "./public")
 	app.Static("/uploads", "./uploads")
+	app.Use(middlewares.BodyLimitMiddleware(
+		fiber.DefaultBodyLimit,
+		envconfig.GetEnvAsInt("GALLERY_UPLOAD_LIMIT_MB", 64)*1024*1024,
+		routes.IsGalleryUploadRequest,
+	))
 	app.Use(csrfconfig.SetupCSRF())
 	routes.SetupRoutes(app)
+	configureSlugPolicy(app)
+
+	schedulerCtx, stopSchedulers := context.WithCancel(context.Background())
+	defer stopSchedulers()
+	startSchedulers(schedulerCtx)
+
+	analyticsService := services.NewAnalyticsService()
+	go analyticsService.Run(schedulerCtx)
 
 	startServer(app)
+
+	// Tampondaki görüntülenmeler veritabanı bağlantısı kapanmadan önce yazılır.
+	stopSchedulers()
+	select {
+	case <-analyticsService.Done():
+	case <-time.After(10 * time.Second):
+		logconfig.Log.Warn("Görüntülenme tamponu zamanında boşaltılamadı")
+	}
+}
+
+func startSchedulers(ctx context.Context) {
+	notificationService := services.NewNotificationService()
+	go scheduler.Every(ctx, "notification-digest", 15*time.Minute, func(ctx context.Context) error {
+		return notificationService.SendDailyDigests(ctx, time.Now())
+	})
+
+	reminderService := services.NewReminderService()
+	go scheduler.Every(ctx, "invitation-reminders", 10*time.Minute, func(ctx context.Context) error {
+		_, err := reminderService.QueueDueReminders(ctx, time.Now())
+		return err
+	})
+
+	messagingService := services.NewMessagingService()
+	go scheduler.Every(ctx, "message-dispatch", time.Minute, func(ctx context.Context) error {
+		_, err := messagingService.DispatchPending(ctx)
+		return err
+	})
+
+	analyticsService := services.NewAnalyticsService()
+	go scheduler.Every(ctx, "analytics-salt-cleanup", time.Hour, func(ctx context.Context) error {
+		return analyticsService.DeleteExpiredSalts(ctx, time.Now())
+	})
+}
+
+// configureSlugPolicy, kart kullanıcı adı politikasını ortam değişkenleriyle yapılandırır ve
+// kayıtlı rotaların ilk parçalarını ile public dizinindeki adları ayrılmış kelimelere ekler.
+func configureSlugPolicy(app *fiber.App) {
+	slugpolicy.Default.SetMinLength(envconfig.GetEnvAsInt("SLUG_MIN_LENGTH", 3))
+	if blocklist := envconfig.GetEnvWithDefault("SLUG_BLOCKLIST", ""); blocklist != "" {
+		slugpolicy.Default.Block(strings.Split(blocklist, ",")...)
+	}
+
+	var paths []string
+	for _, route := range app.GetRoutes(true) {
+		paths = append(paths, route.Path)
+	}
+	slugpolicy.Default.Reserve(slugpolicy.ReservedFromPaths(paths)...)
+
+	if entries, err := os.ReadDir("./public"); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			slugpolicy.Default.Reserve(strings.TrimSuffix(name, filepath.Ext(name)))
+		}
+	}
 }
 
 func startServer(app *fiber.App) {
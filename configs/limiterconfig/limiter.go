@@ -1,6 +1,9 @@
 package limiterconfig
 
 import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
@@ -10,3 +13,51 @@ func GetLimiterConfig() limiter.Config {
 		Expiration: 60,
 	}
 }
+
+// GetGuestbookLimiterConfig, anı defterine aynı IP adresinden aynı davetiyeye gönderilebilecek
+// mesaj sayısını sınırlar.
+func GetGuestbookLimiterConfig() limiter.Config {
+	return limiter.Config{
+		Max:        3,
+		Expiration: 10 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + "|" + c.Path()
+		},
+	}
+}
+
+// GetGalleryPINLimiterConfig, galeri PIN'inin tahmin edilmesini önlemek için aynı IP adresinden
+// yapılabilecek PIN denemelerini sınırlar.
+func GetGalleryPINLimiterConfig() limiter.Config {
+	return limiter.Config{
+		Max:        5,
+		Expiration: 15 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + "|" + c.Path()
+		},
+	}
+}
+
+// GetGalleryUploadLimiterConfig, misafirlerin aynı IP adresinden galeriye yapabileceği yükleme
+// isteklerini sınırlar.
+func GetGalleryUploadLimiterConfig() limiter.Config {
+	return limiter.Config{
+		Max:        20,
+		Expiration: time.Hour,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + "|" + c.Path()
+		},
+	}
+}
+
+// GetGiftReservationLimiterConfig, bir ziyaretçinin hediye listesindeki ürünleri toplu olarak
+// ayırıp listeyi kilitlemesini önlemek için aynı IP adresinden yapılan ayırmaları sınırlar.
+func GetGiftReservationLimiterConfig() limiter.Config {
+	return limiter.Config{
+		Max:        10,
+		Expiration: time.Hour,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP() + "|gifts"
+		},
+	}
+}
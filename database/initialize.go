@@ -64,6 +64,12 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateUsersTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateProvincesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateDistrictsTable(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateInvitationCategoriesTable(db); err != nil {
 		return err
 	}
@@ -73,9 +79,75 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateInvitationDetailsTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateInvitationTablesTable(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateInvitationParticipantsTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateInvitationGuestsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationQuestionsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationAnswersTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationEventsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationEventResponsesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationCoHostsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationCoHostLogsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationCheckInsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationGuestbookEntriesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationAlbumsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationPhotosTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationGiftItemsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationGiftReservationsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateNotificationsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateNotificationPreferencesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateMessageBroadcastsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateOutboundMessagesTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigratePageViewsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateAnalyticsSaltsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateOrganizationsTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateOrganizationMembersTable(db); err != nil {
+		return err
+	}
 	if err := migrations.MigrateCardsTable(db); err != nil {
 		return err
 	}
@@ -91,6 +163,18 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	if err := migrations.MigrateCardSocialMediaTable(db); err != nil {
 		return err
 	}
+	if err := migrations.MigrateOrganizationBanksTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateInvitationBanksTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigrateOrganizationSocialMediaTable(db); err != nil {
+		return err
+	}
+	if err := migrations.MigratePagesTable(db); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -132,11 +216,23 @@ func CheckAndRunSeeders(db *gorm.DB) error {
 		return err
 	}
 
+	// Provinces Seeder
+	if err := seeders.SeedProvinces(db); err != nil {
+		logconfig.Log.Error("İl ve ilçeler seed edilemedi", zap.Error(err))
+		return err
+	}
+
 	// Invitation Categories Seeder
 	if err := seeders.SeedInvitationCategories(db); err != nil {
 		logconfig.Log.Error("Davetiye kategorileri seed edilemedi", zap.Error(err))
 		return err
 	}
 
+	// Pages Seeder
+	if err := seeders.SeedPages(db); err != nil {
+		logconfig.Log.Error("Sayfalar seed edilemedi", zap.Error(err))
+		return err
+	}
+
 	return nil
 }
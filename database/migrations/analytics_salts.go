@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateAnalyticsSaltsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("AnalyticsSalt tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.AnalyticsSalt{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("AnalyticsSalt tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -11,6 +11,10 @@ func MigrateCardBanksTable(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.CardBank{}); err != nil {
 		return err
 	}
+	// IBAN'lar artık boşluksuz ve büyük harfle saklanıyor; eski kayıtları da aynı biçime getir.
+	if err := db.Exec(`UPDATE card_banks SET iban = UPPER(REGEXP_REPLACE(iban, '[[:space:]-]', '', 'g')) WHERE iban ~ '[[:space:]a-z-]'`).Error; err != nil {
+		return err
+	}
 	logconfig.SLog.Info("CardBank tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -8,9 +8,38 @@ import (
 
 func MigrateCardsTable(db *gorm.DB) error {
 	logconfig.SLog.Info("Card tablosu migrate ediliyor...")
+	if err := dropUniqueCardUserIndex(db); err != nil {
+		return err
+	}
 	if err := db.AutoMigrate(&models.Card{}); err != nil {
 		return err
 	}
+	if err := migrateLegacyVenue(db, &models.Card{}, "cards"); err != nil {
+		return err
+	}
 	logconfig.SLog.Info("Card tablosu migrate işlemi tamamlandı.")
 	return nil
 }
+
+// dropUniqueCardUserIndex, kullanıcı başına tek kart kısıtlamasını kaldırır. Eski şemadaki
+// benzersiz idx_cards_user_id indeksi silinir; AutoMigrate aynı adla benzersiz olmayan indeksi
+// yeniden oluşturur.
+func dropUniqueCardUserIndex(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Card{}) {
+		return nil
+	}
+	indexes, err := db.Migrator().GetIndexes(&models.Card{})
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index.Name() != "idx_cards_user_id" {
+			continue
+		}
+		if unique, ok := index.Unique(); ok && unique {
+			logconfig.SLog.Info("Kartlardaki kullanıcı başına tek kart kısıtlaması kaldırılıyor...")
+			return db.Migrator().DropIndex(&models.Card{}, index.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateDistrictsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("District tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.District{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("District tablosu migrate işlemi tamamlandı.")
+	return nil
+}
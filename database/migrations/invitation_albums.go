@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationAlbumsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationAlbum tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationAlbum{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationAlbum tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationAnswersTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationAnswer tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationAnswer{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationAnswer tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationBanksTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationBank tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationBank{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationBank tablosu migrate işlemi tamamlandı.")
+	return nil
+}
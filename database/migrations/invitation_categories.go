@@ -3,6 +3,7 @@ package migrations
 import (
 	"davet.link/configs/logconfig"
 	"davet.link/models"
+	"davet.link/pkg/invitationtheme"
 	"gorm.io/gorm"
 )
 
@@ -11,6 +12,15 @@ func MigrateInvitationCategoriesTable(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.InvitationCategory{}); err != nil {
 		return err
 	}
+	// Alan şeması tanımlanmamış kategoriler, türlerinin varsayılan alanlarıyla doldurulur;
+	// böylece yönetim panelinde düzenlenebilir hale gelirler.
+	for _, kind := range invitationtheme.Kinds() {
+		if err := db.Model(&models.InvitationCategory{}).
+			Where("template = ? AND field_schema IS NULL", kind.Name).
+			Update("field_schema", kind.Schema).Error; err != nil {
+			return err
+		}
+	}
 	logconfig.SLog.Info("InvitationCategory tablosu migrate işlemi tamamlandı.")
 	return nil
 }
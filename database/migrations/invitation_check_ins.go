@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationCheckInsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationCheckIn tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationCheckIn{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationCheckIn tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationCoHostLogsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationCoHostLog tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationCoHostLog{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationCoHostLog tablosu migrate işlemi tamamlandı.")
+	return nil
+}
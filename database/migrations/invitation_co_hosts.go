@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationCoHostsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationCoHost tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationCoHost{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationCoHost tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -11,6 +11,42 @@ func MigrateInvitationDetailsTable(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.InvitationDetail{}); err != nil {
 		return err
 	}
+	// Detaylar artık kategori şemasına göre fields sütununda saklanıyor. Eski sütunlardaki
+	// başlık, kişi, aile ve gelin/damat bilgileri varsayılan şemaların anahtarlarıyla taşınır;
+	// "yaşıyor" işaretleri merhum işaretine çevrilir. Eski sütunlar silinmez.
+	if db.Migrator().HasColumn(&models.InvitationDetail{}, "bride_name") {
+		if err := db.Exec(legacyInvitationDetailsSQL).Error; err != nil {
+			return err
+		}
+	}
 	logconfig.SLog.Info("InvitationDetail tablosu migrate işlemi tamamlandı.")
 	return nil
 }
+
+var legacyInvitationDetailsSQL = `
+UPDATE invitation_details SET fields = jsonb_strip_nulls(jsonb_build_object(
+	'title', NULLIF(title, ''),
+	'person', CASE WHEN COALESCE(person, '') <> '' THEN jsonb_build_object('name', person) END,
+	'mother', ` + legacyPersonSQL("mother_name", "mother_surname") + `,
+	'mother_deceased', CASE WHEN NOT is_mother_live THEN true END,
+	'father', ` + legacyPersonSQL("father_name", "father_surname") + `,
+	'father_deceased', CASE WHEN NOT is_father_live THEN true END,
+	'bride', ` + legacyPersonSQL("bride_name", "bride_surname") + `,
+	'bride_mother', ` + legacyPersonSQL("bride_mother_name", "bride_mother_surname") + `,
+	'bride_mother_deceased', CASE WHEN NOT is_bride_mother_live THEN true END,
+	'bride_father', ` + legacyPersonSQL("bride_father_name", "bride_father_surname") + `,
+	'bride_father_deceased', CASE WHEN NOT is_bride_father_live THEN true END,
+	'groom', ` + legacyPersonSQL("groom_name", "groom_surname") + `,
+	'groom_mother', ` + legacyPersonSQL("groom_mother_name", "groom_mother_surname") + `,
+	'groom_mother_deceased', CASE WHEN NOT is_groom_mother_live THEN true END,
+	'groom_father', ` + legacyPersonSQL("groom_father_name", "groom_father_surname") + `,
+	'groom_father_deceased', CASE WHEN NOT is_groom_father_live THEN true END
+))
+WHERE fields IS NULL`
+
+// legacyPersonSQL, eski ad ve soyad sütunlarından {name, surname} nesnesi üretir; ikisi de
+// boşsa NULL döner ve anahtar jsonb_strip_nulls ile atılır.
+func legacyPersonSQL(name, surname string) string {
+	return `CASE WHEN COALESCE(` + name + `, '') <> '' OR COALESCE(` + surname + `, '') <> '' ` +
+		`THEN jsonb_build_object('name', NULLIF(` + name + `, ''), 'surname', NULLIF(` + surname + `, '')) END`
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationEventResponsesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationEventResponse tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationEventResponse{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationEventResponse tablosu migrate işlemi tamamlandı.")
+	return nil
+}
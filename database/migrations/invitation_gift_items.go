@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGiftItemsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGiftItem tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGiftItem{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGiftItem tablosu migrate işlemi tamamlandı.")
+	return nil
+}
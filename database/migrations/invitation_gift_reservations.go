@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGiftReservationsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGiftReservation tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGiftReservation{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGiftReservation tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGuestbookEntriesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGuestbookEntry tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGuestbookEntry{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGuestbookEntry tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationGuestsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationGuest tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationGuest{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationGuest tablosu migrate işlemi tamamlandı.")
+	return nil
+}
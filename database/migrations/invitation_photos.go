@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationPhotosTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationPhoto tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationPhoto{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationPhoto tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationQuestionsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationQuestion tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationQuestion{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationQuestion tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateInvitationTablesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("InvitationTable tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.InvitationTable{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("InvitationTable tablosu migrate işlemi tamamlandı.")
+	return nil
+}
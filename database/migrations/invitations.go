@@ -11,6 +11,9 @@ func MigrateInvitationsTable(db *gorm.DB) error {
 	if err := db.AutoMigrate(&models.Invitation{}); err != nil {
 		return err
 	}
+	if err := migrateLegacyVenue(db, &models.Invitation{}, "invitations"); err != nil {
+		return err
+	}
 	logconfig.SLog.Info("Invitation tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateMessageBroadcastsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("MessageBroadcast tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.MessageBroadcast{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("MessageBroadcast tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateNotificationPreferencesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("NotificationPreference tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.NotificationPreference{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("NotificationPreference tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateNotificationsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("Notification tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Notification{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("Notification tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateOrganizationBanksTable(db *gorm.DB) error {
+	logconfig.SLog.Info("OrganizationBank tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.OrganizationBank{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("OrganizationBank tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateOrganizationMembersTable(db *gorm.DB) error {
+	logconfig.SLog.Info("OrganizationMember tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.OrganizationMember{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("OrganizationMember tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateOrganizationSocialMediaTable(db *gorm.DB) error {
+	logconfig.SLog.Info("OrganizationSocialMedia tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.OrganizationSocialMedia{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("OrganizationSocialMedia tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateOrganizationsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("Organization tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Organization{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("Organization tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateOutboundMessagesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("OutboundMessage tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.OutboundMessage{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("OutboundMessage tablosu migrate işlemi tamamlandı.")
+	return nil
+}
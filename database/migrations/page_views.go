@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigratePageViewsTable(db *gorm.DB) error {
+	logconfig.SLog.Info("PageView tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.PageView{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("PageView tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigratePagesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("Page tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Page{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("Page tablosu migrate işlemi tamamlandı.")
+	return nil
+}
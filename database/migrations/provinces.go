@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+func MigrateProvincesTable(db *gorm.DB) error {
+	logconfig.SLog.Info("Province tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Province{}); err != nil {
+		return err
+	}
+	logconfig.SLog.Info("Province tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"strings"
+
+	"davet.link/pkg/maplink"
+	"gorm.io/gorm"
+)
+
+// legacyVenueColumns, eski serbest metin sütunlarının yapılandırılmış mekân sütunlarındaki
+// karşılıklarıdır.
+var legacyVenueColumns = []struct{ Legacy, Column string }{
+	{"venue", "venue_name"},
+	{"address", "venue_address_line1"},
+	{"location", "venue_map_url"},
+}
+
+// migrateLegacyVenue, eski venue, address ve location sütunlarındaki değerleri mekân bilgisi
+// girilmemiş satırlara taşır ve harita bağlantılarından koordinat çıkarır. Eski sütunlar silinmez.
+func migrateLegacyVenue(db *gorm.DB, model interface{}, table string) error {
+	var assignments []string
+	for _, c := range legacyVenueColumns {
+		if db.Migrator().HasColumn(model, c.Legacy) {
+			assignments = append(assignments, c.Column+" = "+c.Legacy)
+		}
+	}
+	if len(assignments) > 0 {
+		err := db.Exec("UPDATE " + table + " SET " + strings.Join(assignments, ", ") +
+			" WHERE COALESCE(venue_name, '') = '' AND COALESCE(venue_address_line1, '') = '' AND COALESCE(venue_map_url, '') = ''").Error
+		if err != nil {
+			return err
+		}
+	}
+
+	var rows []struct {
+		ID          uint
+		VenueMapURL string
+	}
+	if err := db.Table(table).Select("id, venue_map_url").
+		Where("COALESCE(venue_map_url, '') <> '' AND venue_latitude IS NULL").Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		coordinates, ok := maplink.Parse(row.VenueMapURL)
+		if !ok {
+			continue
+		}
+		if err := db.Table(table).Where("id = ?", row.ID).Updates(map[string]interface{}{
+			"venue_latitude":  coordinates.Latitude,
+			"venue_longitude": coordinates.Longitude,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
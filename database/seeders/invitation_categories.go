@@ -3,9 +3,36 @@ package seeders
 import (
 	"davet.link/configs/logconfig"
 	"davet.link/models"
+	"davet.link/pkg/detailfields"
+	"davet.link/pkg/invitationtheme"
 	"gorm.io/gorm"
 )
 
+// Türün varsayılan alanları yerine kendi alanlarını kullanan kategorilerin şemaları
+var (
+	titleField    = detailfields.Field{Key: "title", Label: "Başlık", Type: detailfields.TypeText, Required: true}
+	sponsorsField = detailfields.Field{Key: "sponsors", Label: "Sponsorlar", Type: detailfields.TypeList}
+	speakerSchema = detailfields.Schema{
+		titleField,
+		{Key: "theme", Label: "Tema", Type: detailfields.TypeText},
+		{Key: "speakers", Label: "Konuşmacılar", Type: detailfields.TypeList},
+		sponsorsField,
+	}
+	graduationSchema = detailfields.Schema{
+		titleField,
+		{Key: "school", Label: "Okul", Type: detailfields.TypeText, Required: true},
+		{Key: "class_of", Label: "Mezuniyet Yılı / Dönemi", Type: detailfields.TypeText},
+		{Key: "graduates", Label: "Mezunlar", Type: detailfields.TypeList},
+	}
+	exhibitionSchema = detailfields.Schema{
+		titleField,
+		{Key: "curator", Label: "Küratör", Type: detailfields.TypePerson},
+		{Key: "exhibitors", Label: "Katılımcılar", Type: detailfields.TypeList},
+		{Key: "closing_date", Label: "Kapanış Tarihi", Type: detailfields.TypeDate},
+		sponsorsField,
+	}
+)
+
 func SeedInvitationCategories(db *gorm.DB) error {
 	categories := []models.InvitationCategory{
 		{Name: "Açılış", Template: "title", Icon: "fas fa-door-open", IsActive: true},
@@ -22,20 +49,20 @@ func SeedInvitationCategories(db *gorm.DB) error {
 		{Name: "Düğün", Template: "wedding", Icon: "fas fa-ring", IsActive: true},
 		{Name: "Eğitim", Template: "title", Icon: "fas fa-book-open", IsActive: true},
 		{Name: "Film Galası", Template: "title", Icon: "fas fa-film", IsActive: true},
-		{Name: "Fuar", Template: "title", Icon: "fas fa-building", IsActive: true},
+		{Name: "Fuar", Template: "title", Icon: "fas fa-building", IsActive: true, FieldSchema: exhibitionSchema},
 		{Name: "Gelin Hamamı", Template: "person", Icon: "fas fa-spa", IsActive: true},
 		{Name: "Gezi", Template: "title", Icon: "fas fa-suitcase-rolling", IsActive: true},
 		{Name: "Kına Gecesi", Template: "wedding", Icon: "fas fa-hand-sparkles", IsActive: true},
-		{Name: "Konferans", Template: "title", Icon: "fas fa-microphone-alt", IsActive: true},
-		{Name: "Kongre", Template: "title", Icon: "fas fa-users", IsActive: true},
+		{Name: "Konferans", Template: "title", Icon: "fas fa-microphone-alt", IsActive: true, FieldSchema: speakerSchema},
+		{Name: "Kongre", Template: "title", Icon: "fas fa-users", IsActive: true, FieldSchema: speakerSchema},
 		{Name: "Konser", Template: "title", Icon: "fas fa-music", IsActive: true},
 		{Name: "Lansman", Template: "title", Icon: "fas fa-laptop", IsActive: true},
-		{Name: "Mezuniyet", Template: "title", Icon: "fas fa-graduation-cap", IsActive: true},
+		{Name: "Mezuniyet", Template: "title", Icon: "fas fa-graduation-cap", IsActive: true, FieldSchema: graduationSchema},
 		{Name: "Nikâh Töreni", Template: "wedding", Icon: "fas fa-ring", IsActive: true},
 		{Name: "Nişan", Template: "wedding", Icon: "fas fa-heart", IsActive: true},
 		{Name: "Online Etkinlik", Template: "online", Icon: "fas fa-link", IsActive: true},
-		{Name: "Seminer", Template: "title", Icon: "fas fa-chalkboard-teacher", IsActive: true},
-		{Name: "Sergi", Template: "title", Icon: "fas fa-palette", IsActive: true},
+		{Name: "Seminer", Template: "title", Icon: "fas fa-chalkboard-teacher", IsActive: true, FieldSchema: speakerSchema},
+		{Name: "Sergi", Template: "title", Icon: "fas fa-palette", IsActive: true, FieldSchema: exhibitionSchema},
 		{Name: "Spor Müsabakası", Template: "title", Icon: "fas fa-futbol", IsActive: true},
 		{Name: "Sünnet Düğünü", Template: "person-family", Icon: "fas fa-child", IsActive: true},
 		{Name: "Tanıtım", Template: "title", Icon: "fas fa-bullhorn", IsActive: true},
@@ -54,6 +81,7 @@ func SeedInvitationCategories(db *gorm.DB) error {
 		var existingCategory models.InvitationCategory
 		if err := db.Where("name = ?", category.Name).First(&existingCategory).Error; err == gorm.ErrRecordNotFound {
 			// Kategori yoksa ekle
+			category.FieldSchema = invitationtheme.SchemaFor(category.Template, category.FieldSchema)
 			if err := db.Create(&category).Error; err != nil {
 				logconfig.SLog.Error("Kategori eklenirken hata: " + category.Name)
 				return err
@@ -0,0 +1,62 @@
+package seeders
+
+import (
+	"errors"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"gorm.io/gorm"
+)
+
+// SeedPages, alt bilgide bağlantısı bulunan yasal sayfaları oluşturur. Sayfalar yalnızca ilk
+// kez eklenir; içerikleri sonradan yönetim panelinden düzenlenir ve seed ile ezilmez.
+func SeedPages(db *gorm.DB) error {
+	pages := []models.Page{
+		{
+			Title:           "Kullanım Şartları",
+			Slug:            "kullanim-sartlari",
+			Body:            "# Kullanım Şartları\n\nBurada platformun kullanım şartları yer alacaktır.",
+			MetaTitle:       "Kullanım Şartları",
+			MetaDescription: "davet.link dijital davetiye ve kartvizit hizmetlerinin kullanım şartları.",
+			IsPublished:     true,
+		},
+		{
+			Title:           "KVKK Aydınlatma Metni",
+			Slug:            "kvkk",
+			Body:            "# KVKK Aydınlatma Metni\n\n6698 sayılı Kişisel Verilerin Korunması Kanunu kapsamındaki aydınlatma metni burada yer alacaktır.",
+			MetaTitle:       "KVKK Aydınlatma Metni",
+			MetaDescription: "davet.link kişisel verilerin korunması kanunu kapsamında aydınlatma metni.",
+			IsPublished:     true,
+		},
+		{
+			Title:           "Gizlilik Politikası",
+			Slug:            "gizlilik-politikasi",
+			Body:            "# Gizlilik Politikası\n\nBurada platformun gizlilik politikası yer alacaktır.",
+			MetaTitle:       "Gizlilik Politikası",
+			MetaDescription: "davet.link gizlilik politikası ve çerez kullanımı.",
+			IsPublished:     true,
+		},
+	}
+
+	logconfig.SLog.Info("Sayfalar yükleniyor...")
+
+	for _, page := range pages {
+		// Silinmiş sayfalar da slug'ı tuttuğu için Unscoped kontrol edilir
+		var existing models.Page
+		err := db.Unscoped().Where("slug = ?", page.Slug).First(&existing).Error
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := db.Create(&page).Error; err != nil {
+			logconfig.SLog.Error("Sayfa eklenirken hata: " + page.Slug)
+			return err
+		}
+		logconfig.SLog.Info("Sayfa eklendi: " + page.Slug)
+	}
+
+	logconfig.SLog.Info("Sayfa yükleme işlemi tamamlandı.")
+	return nil
+}
@@ -9,13 +9,13 @@ import (
 func SeedSocialMedia(db *gorm.DB) error {
 	// Sosyal medya listesi
 	socialMedias := []models.SocialMedia{
-		{Name: "Facebook", Icon: "fa-brands fa-facebook-f", IsActive: true},
-		{Name: "Instagram", Icon: "fa-brands fa-instagram", IsActive: true},
-		{Name: "X (Twitter)", Icon: "fa-brands fa-x", IsActive: true},
-		{Name: "LinkedIn", Icon: "fa-brands fa-linkedin-in", IsActive: true},
-		{Name: "YouTube", Icon: "fa-brands fa-youtube", IsActive: true},
-		{Name: "TikTok", Icon: "fa-brands fa-tiktok", IsActive: true},
-		{Name: "GitHub", Icon: "fa-brands fa-github", IsActive: true},
+		{Name: "Facebook", Icon: "fa-brands fa-facebook-f", BaseURL: "https://www.facebook.com/", HandlePattern: `^[A-Za-z0-9.\-]{1,50}$`, IsActive: true},
+		{Name: "Instagram", Icon: "fa-brands fa-instagram", BaseURL: "https://www.instagram.com/", HandlePattern: `^[A-Za-z0-9._]{1,30}$`, IsActive: true},
+		{Name: "X (Twitter)", Icon: "fa-brands fa-x", BaseURL: "https://x.com/", HandlePattern: `^[A-Za-z0-9_]{1,15}$`, IsActive: true},
+		{Name: "LinkedIn", Icon: "fa-brands fa-linkedin-in", BaseURL: "https://www.linkedin.com/in/", HandlePattern: `^[A-Za-z0-9_%\-]{3,100}$`, IsActive: true},
+		{Name: "YouTube", Icon: "fa-brands fa-youtube", BaseURL: "https://www.youtube.com/@", HandlePattern: `^[A-Za-z0-9._\-]{3,30}$`, IsActive: true},
+		{Name: "TikTok", Icon: "fa-brands fa-tiktok", BaseURL: "https://www.tiktok.com/@", HandlePattern: `^[A-Za-z0-9._]{2,24}$`, IsActive: true},
+		{Name: "GitHub", Icon: "fa-brands fa-github", BaseURL: "https://github.com/", HandlePattern: `^[A-Za-z0-9](?:[A-Za-z0-9\-]{0,38})$`, IsActive: true},
 	}
 
 	logconfig.SLog.Info("Sosyal medya verileri yükleniyor...")
@@ -31,6 +31,15 @@ func SeedSocialMedia(db *gorm.DB) error {
 				return err
 			}
 			logconfig.SLog.Info("Sosyal medya platformu eklendi: " + socialMedia.Name)
+		} else if err == nil && existingSocialMedia.BaseURL == "" {
+			// Önceden eklenmiş platformların profil adresi kurallarını tamamla
+			if err := db.Model(&existingSocialMedia).Updates(map[string]interface{}{
+				"base_url":       socialMedia.BaseURL,
+				"handle_pattern": socialMedia.HandlePattern,
+			}).Error; err != nil {
+				logconfig.SLog.Error("Sosyal medya platformu güncellenirken hata: " + socialMedia.Name)
+				return err
+			}
 		}
 	}
 
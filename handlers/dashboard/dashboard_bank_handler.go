@@ -79,6 +79,7 @@ func (h *DashboardBankHandler) CreateBank(c *fiber.Ctx) error {
 	bank := &models.Bank{
 		Name:     req.Name,
 		IsActive: req.IsActive == "true",
+		Code:     req.Code,
 	}
 	if err := h.bankService.CreateBank(c.UserContext(), bank); err != nil {
 		return renderBankFormError("dashboard/banks/create", "Yeni Banka Ekle", req, "Banka oluşturulamadı: "+err.Error(), c)
@@ -109,6 +110,7 @@ func (h *DashboardBankHandler) UpdateBank(c *fiber.Ctx) error {
 	bank := &models.Bank{
 		Name:     req.Name,
 		IsActive: req.IsActive == "true",
+		Code:     req.Code,
 	}
 	// Get userID from context
 	userID, _ := c.Locals("userID").(uint)
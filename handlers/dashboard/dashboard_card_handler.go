@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"davet.link/pkg/flashmessages"
 	"davet.link/pkg/queryparams"
 	"davet.link/pkg/renderer"
+	"davet.link/pkg/slugpolicy"
 	"davet.link/requests"
 	"davet.link/services"
 
@@ -108,13 +110,35 @@ func (h *DashboardCardHandler) CreateCard(c *fiber.Ctx) error {
 		Title:      req.Title,
 		Telephone:  req.Telephone,
 		Email:      req.Email,
-		Location:   req.Location,
 		WebsiteUrl: req.WebsiteUrl,
 		StoreUrl:   req.StoreUrl,
 		IsActive:   req.IsActive == "true",
 		UserID:     userID,
 	}
 
+	bankService := services.NewBankService()
+	socialMediaService := services.NewSocialMediaService()
+	err := h.cardService.ValidateSlug(req.Slug, 0)
+	if err == nil {
+		err = bankService.ResolveIBANBanks(req.CardBanks)
+	}
+	if err == nil {
+		err = socialMediaService.NormalizeProfileURLs(req.CardSocialMedia)
+	}
+	if err == nil {
+		card.Venue, err = services.NewVenueService().ResolveVenue(c.UserContext(), req.Venue)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart oluşturulamadı: "+err.Error())
+
+		banksResult, _ := bankService.GetAllBanks(queryparams.ListParams{PerPage: 1000})
+		socialMediasResult, _ := socialMediaService.GetAllSocialMedias(queryparams.ListParams{PerPage: 1000})
+
+		return renderer.Render(c, "dashboard/cards/create", "layouts/dashboard", fiber.Map{
+			"Title": "Yeni Kart Oluştur", "Banks": banksResult.Data, "SocialMedias": socialMediasResult.Data, "FormData": req,
+		})
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "photo", "cards")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf yüklenemedi: "+err.Error())
@@ -215,13 +239,8 @@ func (h *DashboardCardHandler) UpdateCard(c *fiber.Ctx) error {
 	userID, _ := c.Locals("userID").(uint)
 
 	if req.Slug != existingCard.Slug {
-		isAvailable, err := h.cardService.IsSlugAvailable(req.Slug, uint(id))
-		if err != nil {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Slug kontrolü sırasında bir hata oluştu.")
-			return c.Redirect(redirectURL, http.StatusSeeOther)
-		}
-		if !isAvailable {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bu kullanıcı adı zaten alınmış.")
+		if err := h.cardService.ValidateSlug(req.Slug, uint(id)); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
 			c.Locals("cardRequest", req)
 			bankService := services.NewBankService()
 			socialMediaService := services.NewSocialMediaService()
@@ -233,6 +252,20 @@ func (h *DashboardCardHandler) UpdateCard(c *fiber.Ctx) error {
 		}
 	}
 
+	if err := services.NewBankService().ResolveIBANBanks(req.CardBanks); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := services.NewSocialMediaService().NormalizeProfileURLs(req.CardSocialMedia); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	venue, err := services.NewVenueService().ResolveVenue(c.UserContext(), req.Venue)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "photo", "cards")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni fotoğraf yüklenemedi: "+err.Error())
@@ -250,7 +283,7 @@ func (h *DashboardCardHandler) UpdateCard(c *fiber.Ctx) error {
 	existingCard.Title = req.Title
 	existingCard.Telephone = req.Telephone
 	existingCard.Email = req.Email
-	existingCard.Location = req.Location
+	existingCard.Venue = venue
 	existingCard.WebsiteUrl = req.WebsiteUrl
 	existingCard.StoreUrl = req.StoreUrl
 	existingCard.IsActive = req.IsActive == "true"
@@ -308,7 +341,7 @@ func (h *DashboardCardHandler) DeleteCard(c *fiber.Ctx) error {
 }
 
 func (h *DashboardCardHandler) SlugCheck(c *fiber.Ctx) error {
-	slug := c.Query("slug")
+	slug := slugpolicy.Normalize(c.Query("slug"))
 	if slug == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"is_available": false,
@@ -325,16 +358,21 @@ func (h *DashboardCardHandler) SlugCheck(c *fiber.Ctx) error {
 		}
 	}
 
-	isAvailable, err := h.cardService.IsSlugAvailable(slug, excludeID)
-	if err != nil {
-		logconfig.Log.Error("Slug kontrolü sırasında veritabanı hatası", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+	if err := h.cardService.ValidateSlug(slug, excludeID); err != nil {
+		response := fiber.Map{
 			"is_available": false,
-			"message":      "Sunucu hatası.",
-		})
+			"slug":         slug,
+			"message":      err.Error(),
+		}
+		// Dolu veya ayrılmış adlar için kullanılabilir alternatifler öner
+		if errors.Is(err, services.ErrSlugTaken) || errors.Is(err, slugpolicy.ErrReserved) {
+			response["suggestions"] = h.cardService.SuggestSlugs(slug, excludeID)
+		}
+		return c.JSON(response)
 	}
 
 	return c.JSON(fiber.Map{
-		"is_available": isAvailable,
+		"is_available": true,
+		"slug":         slug,
 	})
 }
\ No newline at end of file
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"davet.link/configs/logconfig"
+	"davet.link/pkg/flashmessages"
 	"davet.link/pkg/renderer"
+	"davet.link/requests"
 	"davet.link/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +19,7 @@ type DashboardHomeHandler struct {
 	userService services.IUserService
 	cardService  services.ICardService
 	invitationService services.IInvitationService
+	statsService      services.IAdminStatsService
 }
 
 func NewDashboardHomeHandler() *DashboardHomeHandler {
@@ -25,6 +30,7 @@ func NewDashboardHomeHandler() *DashboardHomeHandler {
 		userService:      userSvc,
 		cardService:     cardSvc,
 		invitationService: invitationSvc,
+		statsService:      services.NewAdminStatsService(),
 	}
 }
 
@@ -51,5 +57,59 @@ func (h *DashboardHomeHandler) HomePage(c *fiber.Ctx) error {
 		"CardCount":     cardCount,
 		"InvitationCount": invitationCount,
 	}
+
+	req, err := requests.ValidateDashboardStatsRequest(c)
+	if err != nil {
+		mapData[renderer.FlashErrorKeyView] = err.Error()
+	}
+	from, to := req.Range(time.Now())
+	stats, err := h.statsService.GetDashboardStats(from, to)
+	if err != nil {
+		mapData[renderer.FlashErrorKeyView] = err.Error()
+		stats = &services.DashboardStats{From: from, To: to}
+	}
+	mapData["Stats"] = stats
+
+	signupLabels, signupCounts := []string{}, []int64{}
+	for _, row := range stats.SignupsPerDay {
+		signupLabels = append(signupLabels, row.Period.Format("02.01"))
+		signupCounts = append(signupCounts, row.Count)
+	}
+	rsvpLabels, rsvpCounts := []string{}, []int64{}
+	for _, row := range stats.RSVPsPerWeek {
+		rsvpLabels = append(rsvpLabels, row.Period.Format("02.01"))
+		rsvpCounts = append(rsvpCounts, row.Count)
+	}
+	mapData["ChartSignupLabels"] = signupLabels
+	mapData["ChartSignupCounts"] = signupCounts
+	mapData["ChartRSVPLabels"] = rsvpLabels
+	mapData["ChartRSVPCounts"] = rsvpCounts
+
 	return renderer.Render(c, "dashboard/home/home", "layouts/dashboard", mapData, http.StatusOK)
 }
+
+// ExportStats, seçilen tarih aralığındaki metrikleri CSV olarak indirir.
+func (h *DashboardHomeHandler) ExportStats(c *fiber.Ctx) error {
+	req, err := requests.ValidateDashboardStatsRequest(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/dashboard/home", http.StatusSeeOther)
+	}
+	from, to := req.Range(time.Now())
+	stats, err := h.statsService.GetDashboardStats(from, to)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/dashboard/home", http.StatusSeeOther)
+	}
+
+	c.Attachment(fmt.Sprintf("istatistikler-%s-%s.csv", from.Format("20060102"), to.Format("20060102")))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	if err := h.statsService.ExportDashboardStats(c.Response().BodyWriter(), stats); err != nil {
+		logconfig.Log.Error("İstatistikler dışa aktarılamadı", zap.Error(err))
+		c.Response().ResetBody()
+		c.Response().Header.Del(fiber.HeaderContentDisposition)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İstatistikler dışa aktarılamadı.")
+		return c.Redirect("/dashboard/home", http.StatusSeeOther)
+	}
+	return nil
+}
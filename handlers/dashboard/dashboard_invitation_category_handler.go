@@ -73,10 +73,11 @@ func (h *DashboardInvitationCategoryHandler) CreateCategory(c *fiber.Ctx) error
 	}
 	req := c.Locals("invitationCategoryRequest").(requests.InvitationCategoryRequest)
 	category := &models.InvitationCategory{
-		Name:     req.Name,
-		Icon:     req.Icon,
-		Template: req.Template,
-		IsActive: req.IsActive == "true",
+		Name:        req.Name,
+		Icon:        req.Icon,
+		Template:    req.Template,
+		IsActive:    req.IsActive == "true",
+		FieldSchema: req.Schema(),
 	}
 	if err := h.categoryService.CreateCategory(c.UserContext(), category); err != nil {
 		return renderCategoryFormError("dashboard/invitation-categories/create", "Yeni Kategori Ekle", req, "Kategori oluşturulamadı: "+err.Error(), c)
@@ -105,10 +106,11 @@ func (h *DashboardInvitationCategoryHandler) UpdateCategory(c *fiber.Ctx) error
 	}
 	req := c.Locals("invitationCategoryRequest").(requests.InvitationCategoryRequest)
 	category := &models.InvitationCategory{
-		Name:     req.Name,
-		Icon:     req.Icon,
-		Template: req.Template,
-		IsActive: req.IsActive == "true",
+		Name:        req.Name,
+		Icon:        req.Icon,
+		Template:    req.Template,
+		IsActive:    req.IsActive == "true",
+		FieldSchema: req.Schema(),
 	}
 	userID, _ := c.Locals("userID").(uint)
 	if err := h.categoryService.UpdateCategory(c.UserContext(), uint(id), category, userID); err != nil {
@@ -73,9 +73,11 @@ func (h *DashboardSocialMediaHandler) CreateSocialMedia(c *fiber.Ctx) error {
 	}
 	req := c.Locals("socialMediaRequest").(requests.SocialMediaRequest)
 	socialMedia := &models.SocialMedia{
-		Name:     req.Name,
-		Icon:     req.Icon,
-		IsActive: req.IsActive == "true",
+		Name:          req.Name,
+		Icon:          req.Icon,
+		IsActive:      req.IsActive == "true",
+		BaseURL:       req.BaseURL,
+		HandlePattern: req.HandlePattern,
 	}
 	if err := h.socialMediaService.CreateSocialMedia(c.UserContext(), socialMedia); err != nil {
 		return renderSocialMediaFormError("dashboard/social-media/create", "Yeni Sosyal Medya Ekle", req, "Kayıt oluşturulamadı: "+err.Error(), c)
@@ -104,9 +106,11 @@ func (h *DashboardSocialMediaHandler) UpdateSocialMedia(c *fiber.Ctx) error {
 	}
 	req := c.Locals("socialMediaRequest").(requests.SocialMediaRequest)
 	socialMedia := &models.SocialMedia{
-		Name:     req.Name,
-		Icon:     req.Icon,
-		IsActive: req.IsActive == "true",
+		Name:          req.Name,
+		Icon:          req.Icon,
+		IsActive:      req.IsActive == "true",
+		BaseURL:       req.BaseURL,
+		HandlePattern: req.HandlePattern,
 	}
 	userID, _ := c.Locals("userID").(uint)
 	if err := h.socialMediaService.UpdateSocialMedia(c.UserContext(), uint(id), socialMedia, userID); err != nil {
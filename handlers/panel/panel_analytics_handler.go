@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// analyticsPeriods, istatistik sayfasında seçilebilecek gün aralıklarıdır.
+var analyticsPeriods = []int{7, 30, 90}
+
+type PanelAnalyticsHandler struct {
+	invitationService services.IInvitationService
+	cardService       services.ICardService
+	analyticsService  services.IAnalyticsService
+}
+
+func NewPanelAnalyticsHandler() *PanelAnalyticsHandler {
+	return &PanelAnalyticsHandler{
+		invitationService: services.NewInvitationService(),
+		cardService:       services.NewCardService(),
+		analyticsService:  services.NewAnalyticsService(),
+	}
+}
+
+func (h *PanelAnalyticsHandler) ShowInvitationAnalytics(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	return h.render(c, models.PageViewInvitation, invitation.ID, fiber.Map{
+		"Subject":     invitation.Title,
+		"BackURL":     fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID),
+		"BackLabel":   "Katılımcılara Dön",
+		"ShowRSVP":    true,
+		"CurrentPath": fmt.Sprintf("/panel/invitations/analytics/%d", invitation.ID),
+	})
+}
+
+func (h *PanelAnalyticsHandler) ShowCardAnalytics(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kart ID'si.")
+		return c.Redirect("/panel/cards", http.StatusSeeOther)
+	}
+	card, err := h.cardService.GetCardByID(uint(id))
+	userID, _ := c.Locals("userID").(uint)
+	if err != nil || !h.cardService.CanManageCard(card, userID) {
+		if err == nil {
+			logconfig.Log.Warn("Başka kullanıcıya ait kartın istatistiklerine erişim denemesi",
+				zap.Uint("user_id", userID),
+				zap.Uint("card_id", card.ID),
+			)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart bulunamadı.")
+		return c.Redirect("/panel/cards", http.StatusSeeOther)
+	}
+	return h.render(c, models.PageViewCard, card.ID, fiber.Map{
+		"Subject":     card.Name,
+		"BackURL":     "/panel/cards",
+		"BackLabel":   "Kartlara Dön",
+		"ShowRSVP":    false,
+		"CurrentPath": fmt.Sprintf("/panel/cards/analytics/%d", card.ID),
+	})
+}
+
+func (h *PanelAnalyticsHandler) render(c *fiber.Ctx, subjectType models.PageViewSubject, subjectID uint, renderData fiber.Map) error {
+	days := analyticsPeriods[1]
+	if requested := c.QueryInt("days"); requested > 0 {
+		for _, period := range analyticsPeriods {
+			if period == requested {
+				days = period
+			}
+		}
+	}
+
+	renderData["Title"] = "Görüntülenme İstatistikleri"
+	renderData["Periods"] = analyticsPeriods
+	renderData["Days"] = days
+
+	stats, err := h.analyticsService.GetStats(subjectType, subjectID, days)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		stats = &services.ViewStats{Days: days}
+	}
+	renderData["Stats"] = stats
+
+	labels := make([]string, 0, len(stats.Daily))
+	views := make([]int64, 0, len(stats.Daily))
+	visitors := make([]int64, 0, len(stats.Daily))
+	for _, day := range stats.Daily {
+		labels = append(labels, day.Day.Format("02.01"))
+		views = append(views, day.Views)
+		visitors = append(visitors, day.Visitors)
+	}
+	renderData["ChartLabels"] = labels
+	renderData["ChartViews"] = views
+	renderData["ChartVisitors"] = visitors
+
+	return renderer.Render(c, "panel/analytics/show", "layouts/panel", renderData, http.StatusOK)
+}
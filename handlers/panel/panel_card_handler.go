@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"davet.link/pkg/flashmessages"
 	"davet.link/pkg/queryparams"
 	"davet.link/pkg/renderer"
+	"davet.link/pkg/slugpolicy"
 	"davet.link/requests"
 	"davet.link/services"
 
@@ -47,7 +49,8 @@ func (h *PanelCardHandler) ListCards(c *fiber.Ctx) error {
 		params.OrderBy = queryparams.DefaultOrderBy
 	}
 
-	result, err := h.cardService.GetAllCards(params)
+	userID, _ := c.Locals("userID").(uint)
+	result, err := h.cardService.GetCardsByUserID(userID, params)
 
 	renderData := fiber.Map{
 		"Title":  "Kartlar",
@@ -108,13 +111,35 @@ func (h *PanelCardHandler) CreateCard(c *fiber.Ctx) error {
 		Title:      req.Title,
 		Telephone:  req.Telephone,
 		Email:      req.Email,
-		Location:   req.Location,
 		WebsiteUrl: req.WebsiteUrl,
 		StoreUrl:   req.StoreUrl,
 		IsActive:   req.IsActive == "true",
 		UserID:     userID,
 	}
 
+	bankService := services.NewBankService()
+	socialMediaService := services.NewSocialMediaService()
+	err := h.cardService.ValidateSlug(req.Slug, 0)
+	if err == nil {
+		err = bankService.ResolveIBANBanks(req.CardBanks)
+	}
+	if err == nil {
+		err = socialMediaService.NormalizeProfileURLs(req.CardSocialMedia)
+	}
+	if err == nil {
+		card.Venue, err = services.NewVenueService().ResolveVenue(c.UserContext(), req.Venue)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart oluşturulamadı: "+err.Error())
+
+		banksResult, _ := bankService.GetAllBanks(queryparams.ListParams{PerPage: 1000})
+		socialMediasResult, _ := socialMediaService.GetAllSocialMedias(queryparams.ListParams{PerPage: 1000})
+
+		return renderer.Render(c, "panel/cards/create", "layouts/panel", fiber.Map{
+			"Title": "Yeni Kart Oluştur", "Banks": banksResult.Data, "SocialMedias": socialMediasResult.Data, "FormData": req,
+		})
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "photo", "cards")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf yüklenemedi: "+err.Error())
@@ -163,7 +188,7 @@ func (h *PanelCardHandler) ShowUpdateCard(c *fiber.Ctx) error {
 	}
 
 	card, err := h.cardService.GetCardByID(uint(id))
-	if err != nil {
+	if err != nil || !h.canManageCard(c, card) {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart bulunamadı.")
 		return c.Redirect("/panel/cards", http.StatusSeeOther)
 	}
@@ -191,7 +216,7 @@ func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 	redirectURL := fmt.Sprintf("/panel/cards/update/%d", id)
 
 	existingCard, err := h.cardService.GetCardByID(uint(id))
-	if err != nil {
+	if err != nil || !h.canManageCard(c, existingCard) {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek kart bulunamadı.")
 		return c.Redirect("/panel/cards", http.StatusSeeOther)
 	}
@@ -215,13 +240,8 @@ func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 	userID, _ := c.Locals("userID").(uint)
 
 	if req.Slug != existingCard.Slug {
-		isAvailable, err := h.cardService.IsSlugAvailable(req.Slug, uint(id))
-		if err != nil {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Slug kontrolü sırasında bir hata oluştu.")
-			return c.Redirect(redirectURL, http.StatusSeeOther)
-		}
-		if !isAvailable {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bu kullanıcı adı zaten alınmış.")
+		if err := h.cardService.ValidateSlug(req.Slug, uint(id)); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
 			c.Locals("cardRequest", req)
 			bankService := services.NewBankService()
 			socialMediaService := services.NewSocialMediaService()
@@ -233,6 +253,20 @@ func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 		}
 	}
 
+	if err := services.NewBankService().ResolveIBANBanks(req.CardBanks); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := services.NewSocialMediaService().NormalizeProfileURLs(req.CardSocialMedia); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	venue, err := services.NewVenueService().ResolveVenue(c.UserContext(), req.Venue)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart güncellenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "photo", "cards")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni fotoğraf yüklenemedi: "+err.Error())
@@ -250,7 +284,7 @@ func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 	existingCard.Title = req.Title
 	existingCard.Telephone = req.Telephone
 	existingCard.Email = req.Email
-	existingCard.Location = req.Location
+	existingCard.Venue = venue
 	existingCard.WebsiteUrl = req.WebsiteUrl
 	existingCard.StoreUrl = req.StoreUrl
 	existingCard.IsActive = req.IsActive == "true"
@@ -292,7 +326,16 @@ func (h *PanelCardHandler) UpdateCard(c *fiber.Ctx) error {
 
 func (h *PanelCardHandler) DeleteCard(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
-	if err := h.cardService.DeleteCardWithRelations(c.UserContext(), uint(id)); err != nil {
+	card, err := h.cardService.GetCardByID(uint(id))
+	if err != nil || !h.canManageCard(c, card) {
+		errMsg := "Kart bulunamadı."
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect("/panel/cards", fiber.StatusSeeOther)
+	}
+	if err := h.cardService.DeleteCardWithRelations(c.UserContext(), card.ID); err != nil {
 		errMsg := "Kart silinemedi: " + err.Error()
 		if strings.Contains(c.Get("Accept"), "application/json") {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
@@ -308,7 +351,7 @@ func (h *PanelCardHandler) DeleteCard(c *fiber.Ctx) error {
 }
 
 func (h *PanelCardHandler) SlugCheck(c *fiber.Ctx) error {
-	slug := c.Query("slug")
+	slug := slugpolicy.Normalize(c.Query("slug"))
 	if slug == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"is_available": false,
@@ -325,16 +368,28 @@ func (h *PanelCardHandler) SlugCheck(c *fiber.Ctx) error {
 		}
 	}
 
-	isAvailable, err := h.cardService.IsSlugAvailable(slug, excludeID)
-	if err != nil {
-		logconfig.Log.Error("Slug kontrolü sırasında veritabanı hatası", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+	if err := h.cardService.ValidateSlug(slug, excludeID); err != nil {
+		response := fiber.Map{
 			"is_available": false,
-			"message":      "Sunucu hatası.",
-		})
+			"slug":         slug,
+			"message":      err.Error(),
+		}
+		// Dolu veya ayrılmış adlar için kullanılabilir alternatifler öner
+		if errors.Is(err, services.ErrSlugTaken) || errors.Is(err, slugpolicy.ErrReserved) {
+			response["suggestions"] = h.cardService.SuggestSlugs(slug, excludeID)
+		}
+		return c.JSON(response)
 	}
 
 	return c.JSON(fiber.Map{
-		"is_available": isAvailable,
+		"is_available": true,
+		"slug":         slug,
 	})
-}
\ No newline at end of file
+}
+
+// canManageCard, kartın oturumdaki kullanıcıya ait olduğunu veya kullanıcının kartın bağlı
+// olduğu organizasyonda yönetici olduğunu doğrular.
+func (h *PanelCardHandler) canManageCard(c *fiber.Ctx, card *models.Card) bool {
+	userID, _ := c.Locals("userID").(uint)
+	return h.cardService.CanManageCard(card, userID)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// getAuthorizedInvitation, URL'deki davetiyeyi getirir ve oturumdaki kullanıcının davetiyede
+// istenen işleme yetkili bir rolü (sahip ya da ortak ev sahibi) olduğunu doğrular. Kullanıcının
+// rolü şablonlarda kullanılmak üzere "invitationRole" olarak saklanır.
+func getAuthorizedInvitation(c *fiber.Ctx, invitationService services.IInvitationService, permission models.InvitationPermission) (*models.Invitation, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz davetiye ID'si")
+	}
+	invitation, err := invitationService.GetInvitationByID(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeInvitation(c, invitationService, invitation, permission); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// authorizeInvitation, yüklenmiş davetiye için getAuthorizedInvitation ile aynı yetki kontrolünü yapar.
+func authorizeInvitation(c *fiber.Ctx, invitationService services.IInvitationService, invitation *models.Invitation, permission models.InvitationPermission) error {
+	userID, _ := c.Locals("userID").(uint)
+	role, ok := invitationService.GetInvitationRole(invitation, userID)
+	if !ok || !role.Can(permission) {
+		logconfig.Log.Warn("Yetkisiz davetiye erişim denemesi",
+			zap.Uint("user_id", userID),
+			zap.Uint("invitation_id", invitation.ID),
+			zap.String("permission", string(permission)),
+		)
+		return fmt.Errorf("davetiye bulunamadı")
+	}
+	c.Locals("invitationRole", role)
+	return nil
+}
+
+// invitationRole, authorizeInvitation'ın sakladığı rolü döner.
+func invitationRole(c *fiber.Ctx) models.InvitationRole {
+	role, _ := c.Locals("invitationRole").(models.InvitationRole)
+	return role
+}
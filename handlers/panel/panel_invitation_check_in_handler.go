@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/repositories"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationCheckInHandler struct {
+	invitationService services.IInvitationService
+	checkInService    services.IInvitationCheckInService
+}
+
+func NewPanelInvitationCheckInHandler() *PanelInvitationCheckInHandler {
+	return &PanelInvitationCheckInHandler{
+		invitationService: services.NewInvitationService(),
+		checkInService:    services.NewInvitationCheckInService(),
+	}
+}
+
+// ShowCheckIn, kapıdaki görevlilerin telefondan kullandığı bilet okutma ekranıdır.
+func (h *PanelInvitationCheckInHandler) ShowCheckIn(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Kapı Girişi",
+		"Invitation": invitation,
+	}
+	stats, err := h.checkInService.GetStats(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		stats = &repositories.CheckInStats{}
+	}
+	renderData["Stats"] = stats
+	if checkIns, err := h.checkInService.GetRecentCheckIns(invitation.ID); err == nil {
+		renderData["CheckIns"] = checkIns
+	}
+	return renderer.Render(c, "panel/invitations/check_in", "layouts/panel", renderData, http.StatusOK)
+}
+
+// CheckInStats, birden fazla cihazın aynı sayacı göstermesi için ekranın periyodik olarak
+// sorguladığı güncel giriş sayılarını döner.
+func (h *PanelInvitationCheckInHandler) CheckInStats(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	stats, err := h.checkInService.GetStats(invitation.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(checkInStatsJSON(stats))
+}
+
+// LookupTicket, okutulan bileti giriş yapmadan gösterir; görevli kişi sayısını seçip onaylar.
+func (h *PanelInvitationCheckInHandler) LookupTicket(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	req, err := requests.ValidateInvitationCheckInRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	participant, err := h.checkInService.LookupTicket(invitation.ID, req.Code)
+	if err != nil {
+		return checkInErrorJSON(c, participant, err)
+	}
+	return c.JSON(fiber.Map{"participant": checkInParticipantJSON(participant)})
+}
+
+// CheckIn, bilet sahibinin grubundan istenen sayıda kişiyi içeri alır.
+func (h *PanelInvitationCheckInHandler) CheckIn(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	req, err := requests.ValidateInvitationCheckInRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	participant, err := h.checkInService.CheckIn(ctxWithUser, invitation.ID, req.Code, req.Count, userID)
+	if err != nil {
+		return checkInErrorJSON(c, participant, err)
+	}
+
+	response := fiber.Map{
+		"message":     fmt.Sprintf("%s içeri alındı (%d / %d kişi).", participant.Title, participant.CheckedInCount, participant.GuestCount),
+		"participant": checkInParticipantJSON(participant),
+	}
+	if stats, err := h.checkInService.GetStats(invitation.ID); err == nil {
+		response["stats"] = checkInStatsJSON(stats)
+	}
+	return c.JSON(response)
+}
+
+// SendTickets, katılımı onaylı katılımcılara bilet bağlantılarını mesajla gönderir.
+func (h *PanelInvitationCheckInHandler) SendTickets(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationTicketSendRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationTicketSendRequest").(requests.InvitationTicketSendRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	queued, err := h.checkInService.SendTickets(ctxWithUser, userID, invitation, models.MessageChannel(req.Channel))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Biletler gönderilemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if queued == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Tüm katılımcıların güncel biletleri daha önce gönderildi.")
+		return c.Redirect(listURL, http.StatusFound)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d katılımcının bileti gönderim sırasına alındı.", queued))
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func checkInParticipantJSON(participant *models.InvitationParticipant) fiber.Map {
+	checkedInAt := ""
+	if participant.CheckedInAt != nil {
+		checkedInAt = participant.CheckedInAt.Format("02.01.2006 15:04")
+	}
+	return fiber.Map{
+		"id":               participant.ID,
+		"title":            participant.Title,
+		"status":           participant.Status.Label(),
+		"guest_count":      participant.GuestCount,
+		"checked_in_count": participant.CheckedInCount,
+		"remaining":        participant.RemainingCheckIns(),
+		"checked_in_at":    checkedInAt,
+	}
+}
+
+func checkInStatsJSON(stats *repositories.CheckInStats) fiber.Map {
+	return fiber.Map{
+		"arrived_guests":   stats.ArrivedGuests,
+		"expected_guests":  stats.ExpectedGuests,
+		"arrived_parties":  stats.ArrivedParties,
+		"expected_parties": stats.ExpectedParties,
+	}
+}
+
+// checkInErrorJSON, bilet hatalarını kapı ekranının ayırt edebileceği durum kodlarıyla döner.
+// Katılımcı bulunabildiyse (ör: bilet daha önce kullanılmışsa) bilgileri de eklenir.
+func checkInErrorJSON(c *fiber.Ctx, participant *models.InvitationParticipant, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrTicketInvalid), errors.Is(err, services.ErrTicketWrongInvitation):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrTicketNotAttending):
+		status = fiber.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrTicketFullyUsed), errors.Is(err, services.ErrCheckInCountExceeded):
+		status = fiber.StatusConflict
+	case errors.Is(err, services.ErrTicketsDisabled):
+		status = fiber.StatusServiceUnavailable
+	}
+	response := fiber.Map{"error": err.Error()}
+	if participant != nil {
+		response["participant"] = checkInParticipantJSON(participant)
+	}
+	return c.Status(status).JSON(response)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationCoHostHandler struct {
+	invitationService services.IInvitationService
+	coHostService     services.IInvitationCoHostService
+}
+
+func NewPanelInvitationCoHostHandler() *PanelInvitationCoHostHandler {
+	return &PanelInvitationCoHostHandler{
+		invitationService: services.NewInvitationService(),
+		coHostService:     services.NewInvitationCoHostService(),
+	}
+}
+
+func (h *PanelInvitationCoHostHandler) ListCoHosts(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManage)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Ortak Ev Sahipleri",
+		"Invitation": invitation,
+		"Roles":      models.CoHostRoles,
+		"BaseURL":    c.BaseURL(),
+	}
+	coHosts, err := h.coHostService.GetCoHosts(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		coHosts = []models.InvitationCoHost{}
+	}
+	renderData["CoHosts"] = coHosts
+	if logs, err := h.coHostService.GetLogs(invitation.ID); err == nil {
+		renderData["Logs"] = logs
+	}
+	return renderer.Render(c, "panel/invitations/co_hosts", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationCoHostHandler) CreateCoHost(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManage)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/co-hosts/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationCoHostRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationCoHostRequest").(requests.InvitationCoHostRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	coHost, err := h.coHostService.InviteCoHost(ctxWithUser, invitation, userID, req)
+	if errors.Is(err, services.ErrCoHostMailNotSent) {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davet oluşturuldu ancak e-posta gönderilemedi. Kabul bağlantısını listeden kopyalayıp "+coHost.Email+" adresine iletebilirsiniz.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davet gönderilemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, coHost.Email+" adresine ortak ev sahipliği daveti gönderildi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationCoHostHandler) UpdateCoHostRole(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManage)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/co-hosts/%d", invitation.ID)
+
+	coHostID, err := strconv.Atoi(c.Params("coHostID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ortak ev sahibi ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateInvitationCoHostRoleRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationCoHostRoleRequest").(requests.InvitationCoHostRoleRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.coHostService.UpdateCoHostRole(ctxWithUser, invitation, userID, uint(coHostID), req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Rol güncellenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Rol güncellendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationCoHostHandler) DeleteCoHost(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManage)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/co-hosts/%d", invitation.ID)
+
+	coHostID, err := strconv.Atoi(c.Params("coHostID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ortak ev sahibi ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.coHostService.RemoveCoHost(ctxWithUser, invitation, userID, uint(coHostID)); err != nil {
+		errMsg := "Ortak ev sahibi çıkarılamadı: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Ortak ev sahibi çıkarıldı."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ortak ev sahibi çıkarıldı.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+// ShowAcceptCoHost, e-postadaki bağlantıyla gelen kullanıcıya daveti ve rolü gösterir.
+func (h *PanelInvitationCoHostHandler) ShowAcceptCoHost(c *fiber.Ctx) error {
+	coHost, err := h.coHostService.GetCoHostByToken(c.UserContext(), c.Params("token"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	userEmail, _ := c.Locals("userEmail").(string)
+	return renderer.Render(c, "panel/invitations/co_host_accept", "layouts/panel", fiber.Map{
+		"Title":         "Ortak Ev Sahipliği Daveti",
+		"CoHost":        coHost,
+		"EmailMismatch": !strings.EqualFold(coHost.Email, userEmail),
+	})
+}
+
+func (h *PanelInvitationCoHostHandler) AcceptCoHost(c *fiber.Ctx) error {
+	token := c.Params("token")
+	userID, _ := c.Locals("userID").(uint)
+	userEmail, _ := c.Locals("userEmail").(string)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+
+	coHost, err := h.coHostService.AcceptCoHost(ctxWithUser, token, userID, userEmail)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davet kabul edilemedi: "+err.Error())
+		if errors.Is(err, services.ErrCoHostInviteNotFound) {
+			return c.Redirect("/panel/invitations", http.StatusSeeOther)
+		}
+		return c.Redirect("/panel/co-hosts/accept/"+token, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "\""+coHost.Invitation.Title+"\" davetiyesine "+coHost.Role.Label()+" olarak katıldınız.")
+	return c.Redirect("/panel/invitations", http.StatusFound)
+}
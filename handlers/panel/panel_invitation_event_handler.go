@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationEventHandler struct {
+	invitationService services.IInvitationService
+	eventService      services.IInvitationEventService
+}
+
+func NewPanelInvitationEventHandler() *PanelInvitationEventHandler {
+	return &PanelInvitationEventHandler{
+		invitationService: services.NewInvitationService(),
+		eventService:      services.NewInvitationEventService(),
+	}
+}
+
+func (h *PanelInvitationEventHandler) ListEvents(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Program",
+		"Invitation": invitation,
+	}
+	events, err := h.eventService.GetEventsByInvitationID(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Program getirilirken bir hata oluştu."
+		events = []models.InvitationEvent{}
+	}
+	renderData["Events"] = events
+	return renderer.Render(c, "panel/invitations/events", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationEventHandler) CreateEvent(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/events/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationEventRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationEventRequest").(requests.InvitationEventRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.eventService.CreateEvent(ctxWithUser, invitation.ID, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Program bölümü eklenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Program bölümü eklendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationEventHandler) ShowUpdateEvent(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	event, err := h.getInvitationEvent(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Program bölümü bulunamadı.")
+		return c.Redirect(fmt.Sprintf("/panel/invitations/events/%d", invitation.ID), http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/event_update", "layouts/panel", fiber.Map{
+		"Title":      "Program Bölümünü Düzenle",
+		"Invitation": invitation,
+		"Event":      event,
+	}, http.StatusOK)
+}
+
+func (h *PanelInvitationEventHandler) UpdateEvent(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/events/%d", invitation.ID)
+	event, err := h.getInvitationEvent(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Program bölümü bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	updateURL := fmt.Sprintf("%s/update/%d", listURL, event.ID)
+
+	if err := requests.ValidateInvitationEventRequest(c); err != nil {
+		return c.Redirect(updateURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationEventRequest").(requests.InvitationEventRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.eventService.UpdateEvent(ctxWithUser, event.ID, req, userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Program bölümü güncellenemedi: "+err.Error())
+		return c.Redirect(updateURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Program bölümü güncellendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationEventHandler) DeleteEvent(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/events/%d", invitation.ID)
+
+	event, err := h.getInvitationEvent(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Program bölümü bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.eventService.DeleteEvent(ctxWithUser, event.ID); err != nil {
+		errMsg := "Program bölümü silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Program bölümü başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Program bölümü başarıyla silindi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+// getInvitationEvent, rotadaki bölümü yalnızca verilen davetiyeye aitse döner.
+func (h *PanelInvitationEventHandler) getInvitationEvent(c *fiber.Ctx, invitation *models.Invitation) (*models.InvitationEvent, error) {
+	eventID, err := strconv.Atoi(c.Params("eventID"))
+	if err != nil {
+		return nil, errors.New("geçersiz program bölümü ID'si")
+	}
+	event, err := h.eventService.GetEventByID(uint(eventID))
+	if err != nil {
+		return nil, err
+	}
+	if event.InvitationID != invitation.ID {
+		return nil, errors.New("program bölümü bulunamadı")
+	}
+	return event, nil
+}
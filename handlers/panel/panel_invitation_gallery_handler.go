@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// maxPhotosPerUpload, tek seferde yüklenebilecek fotoğraf sayısıdır.
+const maxPhotosPerUpload = 30
+
+type PanelInvitationGalleryHandler struct {
+	invitationService services.IInvitationService
+	galleryService    services.IInvitationGalleryService
+}
+
+func NewPanelInvitationGalleryHandler() *PanelInvitationGalleryHandler {
+	return &PanelInvitationGalleryHandler{
+		invitationService: services.NewInvitationService(),
+		galleryService:    services.NewInvitationGalleryService(),
+	}
+}
+
+func (h *PanelInvitationGalleryHandler) ShowGallery(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	album, err := h.galleryService.GetOrCreateAlbum(ctxWithUser, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID), http.StatusSeeOther)
+	}
+
+	status := models.PhotoStatus(c.Query("status"))
+	switch status {
+	case "", models.PhotoPending, models.PhotoApproved, models.PhotoHidden:
+	default:
+		status = ""
+	}
+
+	renderData := fiber.Map{
+		"Title":        "Fotoğraf Galerisi",
+		"Invitation":   invitation,
+		"Album":        album,
+		"Status":       string(status),
+		"MaxPhotoSize": h.galleryService.MaxPhotoSize(),
+		"GalleryURL":   services.SiteURL() + "/" + invitation.InvitationKey + "/gallery",
+	}
+	photos, err := h.galleryService.GetPhotos(album.ID, status)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Fotoğraflar getirilirken bir hata oluştu."
+		photos = []models.InvitationPhoto{}
+	}
+	counts := map[string]int64{}
+	if statusCounts, err := h.galleryService.CountByStatus(album.ID); err == nil {
+		for photoStatus, count := range statusCounts {
+			counts[string(photoStatus)] = count
+			counts[""] += count
+		}
+	}
+	renderData["Photos"] = photos
+	renderData["Counts"] = counts
+	return renderer.Render(c, "panel/invitations/gallery", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationGalleryHandler) UpdateAlbum(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	galleryURL := fmt.Sprintf("/panel/invitations/gallery/%d", invitation.ID)
+
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateInvitationAlbumRequest(c); err != nil {
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationAlbumRequest").(requests.InvitationAlbumRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.galleryService.UpdateAlbum(ctxWithUser, album, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Galeri ayarları kaydedildi.")
+	return c.Redirect(galleryURL, http.StatusFound)
+}
+
+// UploadPhotos, davetiye sahibinin seçtiği fotoğrafları albüme ekler. Kota dolduğunda kalan
+// dosyalar yüklenmez; o ana kadar eklenenler korunur.
+func (h *PanelInvitationGalleryHandler) UploadPhotos(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	galleryURL := fmt.Sprintf("/panel/invitations/gallery/%d", invitation.ID)
+
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["photos"]) == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen en az bir fotoğraf seçin.")
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	files := form.File["photos"]
+	if len(files) > maxPhotosPerUpload {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, fmt.Sprintf("Tek seferde en fazla %d fotoğraf yükleyebilirsiniz.", maxPhotosPerUpload))
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	uploaded := 0
+	for _, file := range files {
+		fileName, err := filemanager.SaveFormFile(c, file, services.GalleryContentType, h.galleryService.MaxPhotoSize())
+		if err == nil {
+			err = h.galleryService.AddPhoto(ctxWithUser, album, fileName, file.Filename, file.Size, nil)
+			if err != nil {
+				filemanager.DeleteFile(services.GalleryContentType, fileName)
+			}
+		}
+		if err != nil {
+			msg := fmt.Sprintf("%s yüklenemedi: %s", file.Filename, err.Error())
+			if uploaded > 0 {
+				msg = fmt.Sprintf("%d fotoğraf yüklendi. %s", uploaded, msg)
+			}
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+			return c.Redirect(galleryURL, http.StatusSeeOther)
+		}
+		uploaded++
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d fotoğraf galeriye eklendi.", uploaded))
+	return c.Redirect(galleryURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGalleryHandler) UpdatePhotoStatus(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	galleryURL := fmt.Sprintf("/panel/invitations/gallery/%d", invitation.ID)
+	if status := c.Query("status"); status != "" {
+		galleryURL += "?status=" + status
+	}
+
+	photo, err := h.getInvitationPhoto(c, invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf bulunamadı.")
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidatePhotoStatusRequest(c); err != nil {
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	req := c.Locals("photoStatusRequest").(requests.PhotoStatusRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.galleryService.SetPhotoStatus(ctxWithUser, photo, models.PhotoStatus(req.Status)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf güncellenemedi: "+err.Error())
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+
+	switch models.PhotoStatus(req.Status) {
+	case models.PhotoApproved:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf onaylandı ve galeride yayınlandı.")
+	case models.PhotoHidden:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf gizlendi.")
+	default:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf onay bekleyenlere taşındı.")
+	}
+	return c.Redirect(galleryURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGalleryHandler) DeletePhoto(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	galleryURL := fmt.Sprintf("/panel/invitations/gallery/%d", invitation.ID)
+
+	photo, err := h.getInvitationPhoto(c, invitation.ID)
+	if err != nil {
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Fotoğraf bulunamadı."})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf bulunamadı.")
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.galleryService.DeletePhoto(ctxWithUser, photo); err != nil {
+		errMsg := "Fotoğraf silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(galleryURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Fotoğraf başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Fotoğraf başarıyla silindi.")
+	return c.Redirect(galleryURL, http.StatusFound)
+}
+
+// DownloadPhotos, albümdeki tüm fotoğrafların orijinallerini ZIP olarak indirir. Arşiv yanıt
+// gövdesine akış olarak yazılır; albüm ne kadar büyük olursa olsun bellekte tutulmaz.
+func (h *PanelInvitationGalleryHandler) DownloadPhotos(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(fmt.Sprintf("/panel/invitations/gallery/%d", invitation.ID), http.StatusSeeOther)
+	}
+
+	c.Attachment(fmt.Sprintf("galeri-%s.zip", invitation.InvitationKey))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	// Akış işleyici yanıt gönderilirken çalışır; bu noktada c yeniden kullanılmış olabileceğinden
+	// yalnızca önceden alınan değerler kullanılır.
+	galleryService := h.galleryService
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := galleryService.WriteZIP(w, album); err != nil {
+			logconfig.Log.Error("Galeri arşivi gönderilemedi", zap.Uint("album_id", album.ID), zap.Error(err))
+		}
+		_ = w.Flush()
+	})
+	return nil
+}
+
+func (h *PanelInvitationGalleryHandler) getInvitationPhoto(c *fiber.Ctx, invitationID uint) (*models.InvitationPhoto, error) {
+	photoID, err := strconv.Atoi(c.Params("photoID"))
+	if err != nil {
+		return nil, err
+	}
+	photo, err := h.galleryService.GetPhotoByID(uint(photoID))
+	if err != nil {
+		return nil, err
+	}
+	if photo.InvitationID != invitationID {
+		return nil, services.ErrPhotoNotFound
+	}
+	return photo, nil
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/queryparams"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationGiftHandler struct {
+	invitationService services.IInvitationService
+	giftService       services.IInvitationGiftService
+	bankService       services.IBankService
+}
+
+func NewPanelInvitationGiftHandler() *PanelInvitationGiftHandler {
+	return &PanelInvitationGiftHandler{
+		invitationService: services.NewInvitationService(),
+		giftService:       services.NewInvitationGiftService(),
+		bankService:       services.NewBankService(),
+	}
+}
+
+// ShowGifts, davetiyenin hediye hesaplarını ve hediye listesini ayırmalarıyla birlikte gösterir.
+func (h *PanelInvitationGiftHandler) ShowGifts(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Hediyeler",
+		"Invitation": invitation,
+		"GiftsURL":   services.SiteURL() + "/" + invitation.InvitationKey + "/gifts",
+	}
+	banks, err := h.giftService.GetBanks(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		banks = []models.InvitationBank{}
+	}
+	items, err := h.giftService.GetItemsWithReservations(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		items = []models.InvitationGiftItem{}
+	}
+	bankOptions := []models.Bank{}
+	if banksResult, err := h.bankService.GetAllBanks(queryparams.ListParams{PerPage: 1000}); err == nil {
+		if data, ok := banksResult.Data.([]models.Bank); ok {
+			bankOptions = data
+		}
+	}
+	renderData["InvitationBanks"] = banks
+	renderData["Items"] = items
+	renderData["Banks"] = bankOptions
+	return renderer.Render(c, "panel/invitations/gifts", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationGiftHandler) AddBank(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationBankRequest(c); err != nil {
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationBankRequest").(requests.InvitationBankRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.AddBank(ctxWithUser, invitation, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Banka hesabı eklendi.")
+	return c.Redirect(giftsURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGiftHandler) DeleteBank(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	bankID, err := strconv.Atoi(c.Params("bankID"))
+	if err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusNotFound, services.ErrInvitationBankNotFound.Error(), false)
+	}
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.DeleteBank(ctxWithUser, invitation, uint(bankID)); err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusInternalServerError, "Banka hesabı silinemedi: "+err.Error(), false)
+	}
+	return giftResponse(c, giftsURL, fiber.StatusOK, "Banka hesabı başarıyla silindi.", true)
+}
+
+func (h *PanelInvitationGiftHandler) AddItem(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	if err := requests.ValidateGiftItemRequest(c); err != nil {
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	req := c.Locals("giftItemRequest").(requests.GiftItemRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.AddItem(ctxWithUser, invitation, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ürün hediye listesine eklendi.")
+	return c.Redirect(giftsURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGiftHandler) UpdateItem(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	item, err := h.getInvitationGiftItem(c, invitation)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Ürün bulunamadı.")
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateGiftItemRequest(c); err != nil {
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	req := c.Locals("giftItemRequest").(requests.GiftItemRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.UpdateItem(ctxWithUser, item, req, userID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(giftsURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ürün güncellendi.")
+	return c.Redirect(giftsURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGiftHandler) DeleteItem(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	item, err := h.getInvitationGiftItem(c, invitation)
+	if err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusNotFound, "Ürün bulunamadı.", false)
+	}
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.DeleteItem(ctxWithUser, item); err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusInternalServerError, "Ürün silinemedi: "+err.Error(), false)
+	}
+	return giftResponse(c, giftsURL, fiber.StatusOK, "Ürün ve ayırmaları başarıyla silindi.", true)
+}
+
+// DeleteReservation, bir misafirin ayırmasını kaldırır; örneğin misafir ürünü almaktan
+// vazgeçtiğini bildirdiğinde ürün yeniden ayrılabilir olur.
+func (h *PanelInvitationGiftHandler) DeleteReservation(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	giftsURL := fmt.Sprintf("/panel/invitations/gifts/%d", invitation.ID)
+
+	reservationID, err := strconv.Atoi(c.Params("reservationID"))
+	if err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusNotFound, services.ErrGiftReservationMissing.Error(), false)
+	}
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.giftService.DeleteReservation(ctxWithUser, invitation, uint(reservationID)); err != nil {
+		return giftResponse(c, giftsURL, fiber.StatusInternalServerError, "Ayırma kaldırılamadı: "+err.Error(), false)
+	}
+	return giftResponse(c, giftsURL, fiber.StatusOK, "Ayırma kaldırıldı, ürün yeniden ayrılabilir.", true)
+}
+
+func (h *PanelInvitationGiftHandler) getInvitationGiftItem(c *fiber.Ctx, invitation *models.Invitation) (*models.InvitationGiftItem, error) {
+	itemID, err := strconv.Atoi(c.Params("itemID"))
+	if err != nil {
+		return nil, err
+	}
+	return h.giftService.GetItem(invitation, uint(itemID))
+}
+
+func giftResponse(c *fiber.Ctx, giftsURL string, status int, message string, success bool) error {
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		if !success {
+			return c.Status(status).JSON(fiber.Map{"error": message})
+		}
+		return c.JSON(fiber.Map{"message": message})
+	}
+	key := flashmessages.FlashSuccessKey
+	if !success {
+		key = flashmessages.FlashErrorKey
+	}
+	_ = flashmessages.SetFlashMessage(c, key, message)
+	return c.Redirect(giftsURL, http.StatusSeeOther)
+}
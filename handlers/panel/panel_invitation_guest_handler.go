@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationGuestHandler struct {
+	invitationService services.IInvitationService
+	guestService      services.IInvitationGuestService
+}
+
+func NewPanelInvitationGuestHandler() *PanelInvitationGuestHandler {
+	return &PanelInvitationGuestHandler{
+		invitationService: services.NewInvitationService(),
+		guestService:      services.NewInvitationGuestService(),
+	}
+}
+
+func (h *PanelInvitationGuestHandler) ListGuests(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Misafir Listesi",
+		"Invitation": invitation,
+		"BaseURL":    c.BaseURL(),
+	}
+	board, err := h.guestService.GetGuestBoard(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Misafirler getirilirken bir hata oluştu."
+		board = &services.GuestBoard{Guests: []models.InvitationGuest{}}
+	}
+	renderData["Board"] = board
+	return renderer.Render(c, "panel/invitations/guests", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationGuestHandler) CreateGuest(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/guests/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationGuestRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationGuestRequest").(requests.InvitationGuestRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if _, err := h.guestService.CreateGuest(ctxWithUser, invitation.ID, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Misafir eklenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Misafir eklendi, kişiye özel bağlantısı oluşturuldu.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGuestHandler) DeleteGuest(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/guests/%d", invitation.ID)
+
+	guestID, err := strconv.Atoi(c.Params("guestID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz misafir ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	guest, err := h.guestService.GetGuestByID(uint(guestID))
+	if err != nil || guest.InvitationID != invitation.ID {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Misafir bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.guestService.DeleteGuest(ctxWithUser, guest.ID); err != nil {
+		errMsg := "Misafir silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Misafir başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Misafir başarıyla silindi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PanelInvitationGuestbookHandler struct {
+	invitationService services.IInvitationService
+	guestbookService  services.IInvitationGuestbookService
+}
+
+func NewPanelInvitationGuestbookHandler() *PanelInvitationGuestbookHandler {
+	return &PanelInvitationGuestbookHandler{
+		invitationService: services.NewInvitationService(),
+		guestbookService:  services.NewInvitationGuestbookService(),
+	}
+}
+
+func (h *PanelInvitationGuestbookHandler) ListEntries(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	status := models.GuestbookEntryStatus(c.Query("status"))
+	switch status {
+	case "", models.GuestbookEntryPending, models.GuestbookEntryApproved, models.GuestbookEntryHidden:
+	default:
+		status = ""
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Anı Defteri",
+		"Invitation": invitation,
+		"Status":     string(status),
+	}
+	entries, err := h.guestbookService.GetEntries(invitation.ID, status)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Mesajlar getirilirken bir hata oluştu."
+		entries = []models.InvitationGuestbookEntry{}
+	}
+	counts := map[string]int64{}
+	if statusCounts, err := h.guestbookService.CountByStatus(invitation.ID); err == nil {
+		for entryStatus, count := range statusCounts {
+			counts[string(entryStatus)] = count
+			counts[""] += count
+		}
+	}
+	renderData["Entries"] = entries
+	renderData["Counts"] = counts
+	return renderer.Render(c, "panel/invitations/guestbook", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationGuestbookHandler) UpdateEntryStatus(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/guestbook/%d", invitation.ID)
+	if status := c.Query("status"); status != "" {
+		listURL += "?status=" + status
+	}
+
+	entry, err := h.getInvitationEntry(c, invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesaj bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateGuestbookStatusRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("guestbookStatusRequest").(requests.GuestbookStatusRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.guestbookService.SetStatus(ctxWithUser, entry, models.GuestbookEntryStatus(req.Status)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesaj güncellenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	switch models.GuestbookEntryStatus(req.Status) {
+	case models.GuestbookEntryApproved:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Mesaj onaylandı ve davetiye sayfasında yayınlandı.")
+	case models.GuestbookEntryHidden:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Mesaj gizlendi.")
+	default:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Mesaj onay bekleyenlere taşındı.")
+	}
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationGuestbookHandler) DeleteEntry(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/guestbook/%d", invitation.ID)
+
+	entry, err := h.getInvitationEntry(c, invitation.ID)
+	if err != nil {
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Mesaj bulunamadı."})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mesaj bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.guestbookService.DeleteEntry(ctxWithUser, entry); err != nil {
+		errMsg := "Mesaj silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Mesaj başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Mesaj başarıyla silindi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+// ExportEntries, anı defterini hatıra olarak PDF (yalnızca onaylı mesajlar) veya JSON (tüm
+// mesajlar) biçiminde indirir.
+func (h *PanelInvitationGuestbookHandler) ExportEntries(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/guestbook/%d", invitation.ID)
+
+	format := strings.ToLower(c.Query("format", "pdf"))
+	var export func() error
+	switch format {
+	case "pdf":
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		export = func() error { return h.guestbookService.ExportPDF(c.Response().BodyWriter(), invitation) }
+	case "json":
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		export = func() error { return h.guestbookService.ExportJSON(c.Response().BodyWriter(), invitation) }
+	default:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Desteklenmeyen dışa aktarma biçimi.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	c.Attachment(fmt.Sprintf("ani-defteri-%s.%s", invitation.InvitationKey, format))
+	if err := export(); err != nil {
+		logconfig.Log.Error("Anı defteri dışa aktarılamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		c.Response().ResetBody()
+		c.Response().Header.Del(fiber.HeaderContentDisposition)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Anı defteri dışa aktarılamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	return nil
+}
+
+func (h *PanelInvitationGuestbookHandler) getInvitationEntry(c *fiber.Ctx, invitationID uint) (*models.InvitationGuestbookEntry, error) {
+	entryID, err := strconv.Atoi(c.Params("entryID"))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := h.guestbookService.GetEntryByID(uint(entryID))
+	if err != nil {
+		return nil, err
+	}
+	if entry.InvitationID != invitationID {
+		return nil, services.ErrGuestbookNotFound
+	}
+	return entry, nil
+}
@@ -6,8 +6,10 @@ import (
 	"davet.link/models"
 	"davet.link/pkg/filemanager"
 	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/invitationtheme"
 	"davet.link/pkg/queryparams"
 	"davet.link/pkg/renderer"
+	"davet.link/pkg/seo"
 	"davet.link/requests"
 	"davet.link/services"
 	"fmt"
@@ -21,12 +23,14 @@ import (
 type PanelInvitationHandler struct {
 	invitationService services.IInvitationService
 	categoryService   services.IInvitationCategoryService
+	venueService      services.IVenueService
 }
 
 func NewPanelInvitationHandler() *PanelInvitationHandler {
 	return &PanelInvitationHandler{
 		invitationService: services.NewInvitationService(),
 		categoryService:   services.NewInvitationCategoryService(),
+		venueService:      services.NewVenueService(),
 	}
 }
 
@@ -37,13 +41,17 @@ func (h *PanelInvitationHandler) ShowInvitation(c *fiber.Ctx) error {
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), key)
+	if err == nil {
+		err = authorizeInvitation(c, h.invitationService, invitation, models.InvitationPermissionView)
+	}
 	if err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	return renderer.Render(c, "panel/invitations/show", "layouts/panel", fiber.Map{
-		"Title":      "Davetiye Detayları",
-		"Invitation": invitation,
+		"Title":          "Davetiye Detayları",
+		"Invitation":     invitation,
+		"InvitationRole": invitationRole(c),
 	})
 }
 
@@ -65,11 +73,13 @@ func (h *PanelInvitationHandler) ListInvitations(c *fiber.Ctx) error {
 	if params.OrderBy == "" {
 		params.OrderBy = queryparams.DefaultOrderBy
 	}
-	result, err := h.invitationService.GetAllInvitations(params)
+	userID, _ := c.Locals("userID").(uint)
+	result, err := h.invitationService.GetInvitationsForUser(userID, params)
 	renderData := fiber.Map{
 		"Title":  "Davetiyeler",
 		"Result": result,
 		"Params": params,
+		"UserID": userID,
 	}
 	if err != nil {
 		logconfig.Log.Error("Davetiye listesi DB Hatası", zap.Error(err))
@@ -106,6 +116,15 @@ func (h *PanelInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 	req := c.Locals("invitationRequest").(requests.InvitationRequest)
 	userID, _ := c.Locals("userID").(uint)
 
+	venue, err := h.venueService.ResolveVenue(c.UserContext(), req.Venue)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mekan bilgisi geçersiz: "+err.Error())
+		categories, _ := h.categoryService.GetAllCategories(queryparams.DefaultListParams())
+		return renderer.Render(c, "panel/invitations/create", "layouts/panel", fiber.Map{
+			"Title": "Yeni Davetiye Oluştur", "Categories": categories, "FormData": req,
+		})
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "image", "invitations")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Resim yüklenemedi: "+err.Error())
@@ -117,49 +136,28 @@ func (h *PanelInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 	}
 
 	invitation := &models.Invitation{
-		UserID:        userID,
-		CategoryID:    req.CategoryID,
-		Template:      req.Template,
-		Type:          req.Type,
-		Title:         req.Title,
-		Image:         newFileName,
-		Venue:         req.Venue,
-		Address:       req.Address,
-		Location:      req.Location,
-		Telephone:     req.Telephone,
-		Date:          req.Date,
-		Time:          req.Time,
-		IsConfirmed:   req.IsConfirmed,
-		IsParticipant: req.IsParticipant,
+		UserID:                  userID,
+		CategoryID:              req.CategoryID,
+		Template:                req.Template,
+		ThemeOptions:            invitationtheme.EncodeOptions(req.ThemeOptions),
+		Type:                    req.Type,
+		Title:                   req.Title,
+		Image:                   newFileName,
+		Venue:                   venue,
+		Telephone:               req.Telephone,
+		Date:                    req.Date,
+		Time:                    req.Time,
+		IsConfirmed:             req.IsConfirmed,
+		IsParticipant:           req.IsParticipant,
+		IsPublic:                req.IsPublic,
+		IsGuestbook:             req.IsGuestbook,
+		RSVPDeadline:            req.RSVPDeadlineValue(),
+		MaxGuests:               req.MaxGuests,
+		MaxGuestsPerParticipant: req.MaxGuestsPerParticipant,
+		ReminderOffsets:         req.ReminderOffsetsValue(),
 	}
 
-	detail := &models.InvitationDetail{
-		Title:              req.Detail.Title,
-		BrideName:          req.Detail.BrideName,
-		BrideSurname:       req.Detail.BrideSurname,
-		BrideMotherName:    req.Detail.BrideMotherName,
-		BrideMotherSurname: req.Detail.BrideMotherSurname,
-		BrideFatherName:    req.Detail.BrideFatherName,
-		BrideFatherSurname: req.Detail.BrideFatherSurname,
-		GroomName:          req.Detail.GroomName,
-		GroomSurname:       req.Detail.GroomSurname,
-		GroomMotherName:    req.Detail.GroomMotherName,
-		GroomMotherSurname: req.Detail.GroomMotherSurname,
-		GroomFatherName:    req.Detail.GroomFatherName,
-		GroomFatherSurname: req.Detail.GroomFatherSurname,
-		Person:             req.Detail.Person,
-		MotherName:         req.Detail.MotherName,
-		MotherSurname:      req.Detail.MotherSurname,
-		FatherName:         req.Detail.FatherName,
-		FatherSurname:      req.Detail.FatherSurname,
-		IsMotherLive:       req.Detail.IsMotherLive,
-		IsFatherLive:       req.Detail.IsFatherLive,
-		IsBrideMotherLive:  req.Detail.IsBrideMotherLive,
-		IsBrideFatherLive:  req.Detail.IsBrideFatherLive,
-		IsGroomMotherLive:  req.Detail.IsGroomMotherLive,
-		IsGroomFatherLive:  req.Detail.IsGroomFatherLive,
-	}
-	invitation.InvitationDetail = detail
+	invitation.InvitationDetail = &models.InvitationDetail{Fields: req.Fields}
 
 	if err := h.invitationService.CreateInvitationWithRelations(c.UserContext(), invitation); err != nil {
 		if newFileName != "" {
@@ -179,12 +177,7 @@ func (h *PanelInvitationHandler) CreateInvitation(c *fiber.Ctx) error {
 }
 
 func (h *PanelInvitationHandler) ShowUpdateInvitation(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
-		return c.Redirect("/panel/invitations", http.StatusSeeOther)
-	}
-	invitation, err := h.invitationService.GetInvitationByID(uint(id))
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
 	if err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
@@ -209,6 +202,12 @@ func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
 	}
 	redirectURL := fmt.Sprintf("/panel/invitations/update/%d", id)
 
+	existingInvitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
 	if err := requests.ValidateInvitationRequest(c); err != nil {
 		req, _ := c.Locals("invitationRequest").(requests.InvitationRequest)
 		// DÜZELTME: Fonksiyon doğru parametre ile çağrıldı.
@@ -218,14 +217,15 @@ func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
 		})
 	}
 
-	existingInvitation, err := h.invitationService.GetInvitationByID(uint(id))
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güncellenecek davetiye bulunamadı.")
-		return c.Redirect("/panel/invitations", http.StatusSeeOther)
-	}
 	req := c.Locals("invitationRequest").(requests.InvitationRequest)
 	userID, _ := c.Locals("userID").(uint)
 
+	venue, err := h.venueService.ResolveVenue(c.UserContext(), req.Venue)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Mekan bilgisi geçersiz: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
 	newFileName, err := filemanager.UploadFile(c, "image", "invitations")
 	if err != nil && err != filemanager.ErrFileNotProvided {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni resim yüklenemedi: "+err.Error())
@@ -239,51 +239,34 @@ func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
 
 	existingInvitation.CategoryID = req.CategoryID
 	existingInvitation.Template = req.Template
+	existingInvitation.ThemeOptions = invitationtheme.EncodeOptions(req.ThemeOptions)
 	existingInvitation.Type = req.Type
 	existingInvitation.Title = req.Title
-	existingInvitation.Venue = req.Venue
-	existingInvitation.Address = req.Address
-	existingInvitation.Location = req.Location
+	existingInvitation.Venue = venue
 	existingInvitation.Telephone = req.Telephone
 	existingInvitation.Date = req.Date
 	existingInvitation.Time = req.Time
 	existingInvitation.IsConfirmed = req.IsConfirmed
 	existingInvitation.IsParticipant = req.IsParticipant
+	existingInvitation.IsPublic = req.IsPublic
+	existingInvitation.IsGuestbook = req.IsGuestbook
+	existingInvitation.RSVPDeadline = req.RSVPDeadlineValue()
+	existingInvitation.MaxGuests = req.MaxGuests
+	existingInvitation.MaxGuestsPerParticipant = req.MaxGuestsPerParticipant
+	existingInvitation.ReminderOffsets = req.ReminderOffsetsValue()
 	existingInvitation.UpdatedBy = userID
 
-	if existingInvitation.InvitationDetail != nil {
-		existingInvitation.InvitationDetail.Title = req.Detail.Title
-		existingInvitation.InvitationDetail.BrideName = req.Detail.BrideName
-		existingInvitation.InvitationDetail.BrideSurname = req.Detail.BrideSurname
-		existingInvitation.InvitationDetail.BrideMotherName = req.Detail.BrideMotherName
-		existingInvitation.InvitationDetail.BrideMotherSurname = req.Detail.BrideMotherSurname
-		existingInvitation.InvitationDetail.BrideFatherName = req.Detail.BrideFatherName
-		existingInvitation.InvitationDetail.BrideFatherSurname = req.Detail.BrideFatherSurname
-		existingInvitation.InvitationDetail.GroomName = req.Detail.GroomName
-		existingInvitation.InvitationDetail.GroomSurname = req.Detail.GroomSurname
-		existingInvitation.InvitationDetail.GroomMotherName = req.Detail.GroomMotherName
-		existingInvitation.InvitationDetail.GroomMotherSurname = req.Detail.GroomMotherSurname
-		existingInvitation.InvitationDetail.GroomFatherName = req.Detail.GroomFatherName
-		existingInvitation.InvitationDetail.GroomFatherSurname = req.Detail.GroomFatherSurname
-		existingInvitation.InvitationDetail.Person = req.Detail.Person
-		existingInvitation.InvitationDetail.MotherName = req.Detail.MotherName
-		existingInvitation.InvitationDetail.MotherSurname = req.Detail.MotherSurname
-		existingInvitation.InvitationDetail.FatherName = req.Detail.FatherName
-		existingInvitation.InvitationDetail.FatherSurname = req.Detail.FatherSurname
-		existingInvitation.InvitationDetail.IsMotherLive = req.Detail.IsMotherLive
-		existingInvitation.InvitationDetail.IsFatherLive = req.Detail.IsFatherLive
-		existingInvitation.InvitationDetail.IsBrideMotherLive = req.Detail.IsBrideMotherLive
-		existingInvitation.InvitationDetail.IsBrideFatherLive = req.Detail.IsBrideFatherLive
-		existingInvitation.InvitationDetail.IsGroomMotherLive = req.Detail.IsGroomMotherLive
-		existingInvitation.InvitationDetail.IsGroomFatherLive = req.Detail.IsGroomFatherLive
+	if existingInvitation.InvitationDetail == nil {
+		existingInvitation.InvitationDetail = &models.InvitationDetail{}
 	}
+	existingInvitation.InvitationDetail.Fields = req.Fields
 
 	if err := h.invitationService.UpdateInvitationWithRelations(c.UserContext(), existingInvitation); err != nil {
 		if newFileName != "" {
 			// DÜZELTME: Fonksiyonun dönüş değeri kullanılmadı.
 			filemanager.DeleteFile("invitations", newFileName)
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye güncellenirken bir hata oluştu.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye güncellenemedi: "+err.Error())
 		return c.Redirect(redirectURL, http.StatusSeeOther)
 	}
 	if oldPhotoToDelete != "" {
@@ -296,15 +279,18 @@ func (h *PanelInvitationHandler) UpdateInvitation(c *fiber.Ctx) error {
 }
 
 func (h *PanelInvitationHandler) DeleteInvitation(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManage)
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz davetiye ID'si.")
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	userID, _ := c.Locals("userID").(uint)
 	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
 
-	if err := h.invitationService.DeleteInvitationWithRelations(ctxWithUser, uint(id)); err != nil {
+	if err := h.invitationService.DeleteInvitationWithRelations(ctxWithUser, invitation.ID); err != nil {
 		errMsg := "Davetiye silinemedi: " + err.Error()
 		if strings.Contains(c.Get("Accept"), "application/json") {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
@@ -317,4 +303,28 @@ func (h *PanelInvitationHandler) DeleteInvitation(c *fiber.Ctx) error {
 	}
 	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla silindi.")
 	return c.Redirect("/panel/invitations", http.StatusFound)
-}
\ No newline at end of file
+}
+
+// PreviewInvitation, formdaki bilgileri kaydetmeden seçilen şablonla render eder; form
+// sayfasındaki canlı önizleme çerçevesi bu yanıtı gösterir.
+func (h *PanelInvitationHandler) PreviewInvitation(c *fiber.Ctx) error {
+	req, err := requests.ParseInvitationRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz istek formatı.")
+	}
+	invitation, warnings, err := h.invitationService.PreviewInvitation(req, c.FormValue("preview_image"))
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).SendString("Önizleme oluşturulamadı: " + err.Error())
+	}
+	appearance := services.InvitationAppearance(invitation)
+	return renderer.Render(c, appearance.Theme.View, "layouts/website", fiber.Map{
+		"Invitation":      invitation,
+		"Headline":        services.InvitationHeadline(invitation),
+		"DetailEntries":   services.InvitationDetailEntries(invitation),
+		"Appearance":      appearance,
+		"AnswerValues":    map[uint]string{},
+		"Preview":         true,
+		"PreviewWarnings": warnings,
+		"Meta":            seo.Meta{Title: "Davetiye Önizleme | davet.link", NoIndex: true},
+	}, http.StatusOK)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationMessageHandler struct {
+	invitationService services.IInvitationService
+	messagingService  services.IMessagingService
+}
+
+func NewPanelInvitationMessageHandler() *PanelInvitationMessageHandler {
+	return &PanelInvitationMessageHandler{
+		invitationService: services.NewInvitationService(),
+		messagingService:  services.NewMessagingService(),
+	}
+}
+
+func (h *PanelInvitationMessageHandler) ListBroadcasts(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	userID, _ := c.Locals("userID").(uint)
+
+	renderData := fiber.Map{
+		"Title":      "Duyurular",
+		"Invitation": invitation,
+		"Channels":   h.messagingService.AvailableChannels(),
+	}
+	broadcasts, err := h.messagingService.GetBroadcasts(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		broadcasts = []services.BroadcastSummary{}
+	}
+	renderData["Broadcasts"] = broadcasts
+	if quota, err := h.messagingService.GetQuota(userID); err == nil {
+		renderData["Quota"] = quota
+	}
+	return renderer.Render(c, "panel/invitations/messages", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationMessageHandler) ShowBroadcast(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/messages/%d", invitation.ID)
+
+	broadcastID, err := strconv.Atoi(c.Params("broadcastID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz duyuru ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	broadcast, err := h.messagingService.GetBroadcast(uint(broadcastID))
+	if err != nil || broadcast.InvitationID != invitation.ID {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Duyuru bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/message_detail", "layouts/panel", fiber.Map{
+		"Title":      "Duyuru Teslim Durumu",
+		"Invitation": invitation,
+		"Broadcast":  broadcast,
+	}, http.StatusOK)
+}
+
+func (h *PanelInvitationMessageHandler) CreateBroadcast(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/messages/%d", invitation.ID)
+
+	if err := requests.ValidateMessageBroadcastRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("messageBroadcastRequest").(requests.MessageBroadcastRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	broadcast, err := h.messagingService.CreateBroadcast(ctxWithUser, userID, invitation, req)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Duyuru gönderilemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey,
+		fmt.Sprintf("Duyuru %d alıcı için gönderim sırasına alındı.", broadcast.RecipientCount))
+	return c.Redirect(fmt.Sprintf("%s/%d", listURL, broadcast.ID), http.StatusFound)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/pkg/spreadsheet"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type PanelInvitationParticipantHandler struct {
+	invitationService  services.IInvitationService
+	participantService services.IInvitationParticipantService
+	questionService    services.IInvitationQuestionService
+	eventService       services.IInvitationEventService
+	checkInService     services.IInvitationCheckInService
+	messagingService   services.IMessagingService
+}
+
+func NewPanelInvitationParticipantHandler() *PanelInvitationParticipantHandler {
+	return &PanelInvitationParticipantHandler{
+		invitationService:  services.NewInvitationService(),
+		participantService: services.NewInvitationParticipantService(),
+		questionService:    services.NewInvitationQuestionService(),
+		eventService:       services.NewInvitationEventService(),
+		checkInService:     services.NewInvitationCheckInService(),
+		messagingService:   services.NewMessagingService(),
+	}
+}
+
+func (h *PanelInvitationParticipantHandler) ListParticipants(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Katılımcılar",
+		"Invitation": invitation,
+	}
+	participants, err := h.participantService.GetParticipantsByInvitationID(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Katılımcılar getirilirken bir hata oluştu."
+		participants = []models.InvitationParticipant{}
+	}
+	renderData["Participants"] = participants
+	renderData["TicketCodes"] = h.checkInService.TicketCodes(participants)
+	renderData["Channels"] = h.messagingService.AvailableChannels()
+
+	if summary, err := h.participantService.GetStatusSummary(invitation.ID); err == nil {
+		renderData["Summary"] = summary
+	}
+	if questionSummaries, err := h.questionService.GetQuestionSummaries(invitation.ID); err == nil {
+		renderData["QuestionSummaries"] = questionSummaries
+	}
+	if eventSummaries, err := h.eventService.GetEventSummaries(invitation.ID); err == nil {
+		renderData["EventSummaries"] = eventSummaries
+	}
+	return renderer.Render(c, "panel/invitations/participants", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationParticipantHandler) ExportParticipants(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	format := strings.ToLower(c.Query("format", spreadsheet.FormatXLSX))
+	contentType := "text/csv; charset=utf-8"
+	switch format {
+	case spreadsheet.FormatCSV:
+	case spreadsheet.FormatXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Desteklenmeyen dışa aktarma biçimi.")
+		return c.Redirect(fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID), http.StatusSeeOther)
+	}
+
+	c.Attachment(fmt.Sprintf("katilimcilar-%s.%s", invitation.InvitationKey, format))
+	c.Set(fiber.HeaderContentType, contentType)
+	if err := h.participantService.ExportParticipants(c.Response().BodyWriter(), invitation.ID, format); err != nil {
+		logconfig.Log.Error("Katılımcılar dışa aktarılamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		c.Response().ResetBody()
+		c.Response().Header.Del(fiber.HeaderContentDisposition)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Katılımcılar dışa aktarılamadı.")
+		return c.Redirect(fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID), http.StatusSeeOther)
+	}
+	return nil
+}
+
+func (h *PanelInvitationParticipantHandler) ShowImportParticipants(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	return renderer.Render(c, "panel/invitations/participants_import", "layouts/panel", fiber.Map{
+		"Title":      "Katılımcıları İçe Aktar",
+		"Invitation": invitation,
+	})
+}
+
+func (h *PanelInvitationParticipantHandler) PreviewImportParticipants(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	importURL := fmt.Sprintf("/panel/invitations/participants/%d/import", invitation.ID)
+
+	fileName, err := filemanager.UploadTempFile(c, "file", services.ParticipantImportContentType)
+	if err != nil {
+		msg := "Dosya yüklenemedi: " + err.Error()
+		if err == filemanager.ErrFileNotProvided {
+			msg = "Lütfen bir CSV veya XLSX dosyası seçin."
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		return c.Redirect(importURL, http.StatusSeeOther)
+	}
+
+	preview, err := h.participantService.PreviewImport(fileName)
+	if err != nil {
+		filemanager.DeleteTempFile(services.ParticipantImportContentType, fileName)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(importURL, http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/participants_import", "layouts/panel", fiber.Map{
+		"Title":      "Sütunları Eşleştir",
+		"Invitation": invitation,
+		"Preview":    preview,
+	})
+}
+
+func (h *PanelInvitationParticipantHandler) ImportParticipants(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	importURL := fmt.Sprintf("/panel/invitations/participants/%d/import", invitation.ID)
+
+	if err := requests.ValidateInvitationParticipantImportRequest(c); err != nil {
+		return c.Redirect(importURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationParticipantImportRequest").(requests.InvitationParticipantImportRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+
+	result, err := h.participantService.ImportParticipants(ctxWithUser, invitation.ID, req.FileName, services.ParticipantColumnMapping{
+		TitleColumn:       req.TitleColumn,
+		PhoneNumberColumn: req.PhoneNumberColumn,
+		GuestCountColumn:  req.GuestCountColumn,
+		HasHeader:         req.HasHeader,
+	})
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İçe aktarma başarısız: "+err.Error())
+		return c.Redirect(importURL, http.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "panel/invitations/participants_import", "layouts/panel", fiber.Map{
+		"Title":      "İçe Aktarma Sonucu",
+		"Invitation": invitation,
+		"Result":     result,
+	})
+}
+
+func (h *PanelInvitationParticipantHandler) DeleteParticipant(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID)
+
+	participantID, err := strconv.Atoi(c.Params("participantID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz katılımcı ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	participant, err := h.participantService.GetParticipantByID(uint(participantID))
+	if err != nil || participant.InvitationID != invitation.ID {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Katılımcı bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.participantService.DeleteParticipant(ctxWithUser, participant.ID); err != nil {
+		errMsg := "Katılımcı silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Katılımcı başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Katılımcı başarıyla silindi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationQuestionHandler struct {
+	invitationService services.IInvitationService
+	questionService   services.IInvitationQuestionService
+}
+
+func NewPanelInvitationQuestionHandler() *PanelInvitationQuestionHandler {
+	return &PanelInvitationQuestionHandler{
+		invitationService: services.NewInvitationService(),
+		questionService:   services.NewInvitationQuestionService(),
+	}
+}
+
+func (h *PanelInvitationQuestionHandler) ListQuestions(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Katılım Formu Soruları",
+		"Invitation": invitation,
+	}
+	questions, err := h.questionService.GetQuestionsByInvitationID(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Sorular getirilirken bir hata oluştu."
+		questions = []models.InvitationQuestion{}
+	}
+	renderData["Questions"] = questions
+	return renderer.Render(c, "panel/invitations/questions", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelInvitationQuestionHandler) CreateQuestion(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/questions/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationQuestionRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationQuestionRequest").(requests.InvitationQuestionRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.questionService.CreateQuestion(ctxWithUser, invitation.ID, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Soru eklenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru katılım formuna eklendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationQuestionHandler) DeleteQuestion(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionEdit)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/questions/%d", invitation.ID)
+
+	questionID, err := strconv.Atoi(c.Params("questionID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz soru ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	question, err := h.questionService.GetQuestionByID(uint(questionID))
+	if err != nil || question.InvitationID != invitation.ID {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Soru bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.questionService.DeleteQuestion(ctxWithUser, question.ID); err != nil {
+		errMsg := "Soru silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Soru başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Soru başarıyla silindi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelInvitationTableHandler struct {
+	invitationService services.IInvitationService
+	tableService      services.IInvitationTableService
+}
+
+func NewPanelInvitationTableHandler() *PanelInvitationTableHandler {
+	return &PanelInvitationTableHandler{
+		invitationService: services.NewInvitationService(),
+		tableService:      services.NewInvitationTableService(),
+	}
+}
+
+func (h *PanelInvitationTableHandler) ListTables(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":      "Oturma Planı",
+		"Invitation": invitation,
+	}
+	plan, err := h.tableService.GetSeatingPlan(invitation.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = "Oturma planı getirilirken bir hata oluştu."
+		plan = &services.SeatingPlan{}
+	}
+	renderData["Plan"] = plan
+	return renderer.Render(c, "panel/invitations/tables", "layouts/panel", renderData, http.StatusOK)
+}
+
+// PrintTables, oturma planını yazdırılmak üzere panel menüsü olmadan tek sayfa olarak gösterir.
+func (h *PanelInvitationTableHandler) PrintTables(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionView)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	plan, err := h.tableService.GetSeatingPlan(invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturma planı getirilirken bir hata oluştu.")
+		return c.Redirect(fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID), http.StatusSeeOther)
+	}
+	return renderer.Render(c, "panel/invitations/tables_print", "", fiber.Map{
+		"Title":      "Oturma Planı",
+		"Invitation": invitation,
+		"Plan":       plan,
+	}, http.StatusOK)
+}
+
+func (h *PanelInvitationTableHandler) CreateTable(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID)
+
+	if err := requests.ValidateInvitationTableRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationTableRequest").(requests.InvitationTableRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.tableService.CreateTable(ctxWithUser, invitation.ID, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Masa eklenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Masa eklendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationTableHandler) UpdateTable(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID)
+
+	table, err := h.getInvitationTable(c, invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Masa bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateInvitationTableRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationTableRequest").(requests.InvitationTableRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.tableService.UpdateTable(ctxWithUser, table, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Masa güncellenemedi: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Masa güncellendi.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationTableHandler) DeleteTable(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Davetiye bulunamadı."})
+	}
+	listURL := fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID)
+
+	table, err := h.getInvitationTable(c, invitation.ID)
+	if err != nil {
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Masa bulunamadı."})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Masa bulunamadı.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.tableService.DeleteTable(ctxWithUser, table.ID); err != nil {
+		errMsg := "Masa silinemedi: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(listURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Masa silindi, katılımcıları yerleştirilmemişler listesine taşındı."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Masa silindi, katılımcıları yerleştirilmemişler listesine taşındı.")
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationTableHandler) AssignParticipant(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID)
+
+	participantID, err := strconv.Atoi(c.Params("participantID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz katılımcı ID'si.")
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateInvitationTableAssignRequest(c); err != nil {
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+	req := c.Locals("invitationTableAssignRequest").(requests.InvitationTableAssignRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.tableService.AssignParticipant(ctxWithUser, invitation.ID, uint(participantID), req.TableID); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Masa ataması yapılamadı: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	if req.TableID == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Katılımcı masadan kaldırıldı.")
+	} else {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Katılımcı masaya yerleştirildi.")
+	}
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationTableHandler) AutoAssign(c *fiber.Ctx) error {
+	invitation, err := getAuthorizedInvitation(c, h.invitationService, models.InvitationPermissionManageGuests)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		return c.Redirect("/panel/invitations", http.StatusSeeOther)
+	}
+	listURL := fmt.Sprintf("/panel/invitations/tables/%d", invitation.ID)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	outcome, err := h.tableService.AutoAssign(ctxWithUser, invitation.ID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Otomatik yerleştirme yapılamadı: "+err.Error())
+		return c.Redirect(listURL, http.StatusSeeOther)
+	}
+
+	if len(outcome.Unplaced) > 0 {
+		names := make([]string, 0, len(outcome.Unplaced))
+		for _, participant := range outcome.Unplaced {
+			names = append(names, fmt.Sprintf("%s (%d kişi)", participant.Title, participant.GuestCount))
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, fmt.Sprintf(
+			"%d katılımcı yerleştirildi. Boş koltuklara sığmayan %d katılımcı için masa ekleyin veya kapasiteyi artırın: %s",
+			outcome.Assigned, len(outcome.Unplaced), strings.Join(names, ", ")))
+		return c.Redirect(listURL, http.StatusFound)
+	}
+	if outcome.Assigned == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Yerleştirilmeyi bekleyen katılımcı yok.")
+		return c.Redirect(listURL, http.StatusFound)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, fmt.Sprintf("%d katılımcı masalara yerleştirildi.", outcome.Assigned))
+	return c.Redirect(listURL, http.StatusFound)
+}
+
+func (h *PanelInvitationTableHandler) getInvitationTable(c *fiber.Ctx, invitationID uint) (*models.InvitationTable, error) {
+	tableID, err := strconv.Atoi(c.Params("tableID"))
+	if err != nil {
+		return nil, err
+	}
+	table, err := h.tableService.GetTableByID(uint(tableID))
+	if err != nil {
+		return nil, err
+	}
+	if table.InvitationID != invitationID {
+		return nil, services.ErrTableNotFound
+	}
+	return table, nil
+}
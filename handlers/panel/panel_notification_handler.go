@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const notificationsURL = "/panel/notifications"
+
+type PanelNotificationHandler struct {
+	notificationService services.INotificationService
+}
+
+func NewPanelNotificationHandler() *PanelNotificationHandler {
+	return &PanelNotificationHandler{notificationService: services.NewNotificationService()}
+}
+
+func (h *PanelNotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uint)
+
+	renderData := fiber.Map{
+		"Title": "Bildirimler",
+	}
+	notifications, err := h.notificationService.GetNotifications(userID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		notifications = []models.Notification{}
+	}
+	renderData["Notifications"] = notifications
+
+	preference, err := h.notificationService.GetPreference(userID)
+	if err != nil {
+		preference = models.DefaultNotificationPreference(userID)
+	}
+	renderData["Preference"] = preference
+	return renderer.Render(c, "panel/notifications/list", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelNotificationHandler) UnreadCount(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uint)
+	count, err := h.notificationService.CountUnread(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Bildirim sayısı alınamadı."})
+	}
+	return c.JSON(fiber.Map{"unread": count})
+}
+
+func (h *PanelNotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uint)
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return notificationResponse(c, fiber.StatusBadRequest, "Geçersiz bildirim ID'si.", false)
+	}
+
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.notificationService.MarkRead(ctxWithUser, userID, uint(id)); err != nil {
+		return notificationResponse(c, fiber.StatusNotFound, err.Error(), false)
+	}
+	return notificationResponse(c, fiber.StatusOK, "Bildirim okundu olarak işaretlendi.", true)
+}
+
+func (h *PanelNotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.notificationService.MarkAllRead(ctxWithUser, userID); err != nil {
+		return notificationResponse(c, fiber.StatusInternalServerError, err.Error(), false)
+	}
+	return notificationResponse(c, fiber.StatusOK, "Tüm bildirimler okundu olarak işaretlendi.", true)
+}
+
+func (h *PanelNotificationHandler) UpdatePreference(c *fiber.Ctx) error {
+	if err := requests.ValidateNotificationPreferenceRequest(c); err != nil {
+		return c.Redirect(notificationsURL, http.StatusSeeOther)
+	}
+	req := c.Locals("notificationPreferenceRequest").(requests.NotificationPreferenceRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.notificationService.UpdatePreference(ctxWithUser, userID, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(notificationsURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Bildirim tercihleriniz kaydedildi.")
+	return c.Redirect(notificationsURL, http.StatusFound)
+}
+
+func notificationResponse(c *fiber.Ctx, status int, message string, success bool) error {
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		if !success {
+			return c.Status(status).JSON(fiber.Map{"error": message})
+		}
+		return c.JSON(fiber.Map{"message": message})
+	}
+	key := flashmessages.FlashSuccessKey
+	if !success {
+		key = flashmessages.FlashErrorKey
+	}
+	_ = flashmessages.SetFlashMessage(c, key, message)
+	return c.Redirect(notificationsURL, http.StatusSeeOther)
+}
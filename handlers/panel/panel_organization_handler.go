@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/queryparams"
+	"davet.link/pkg/renderer"
+	"davet.link/requests"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PanelOrganizationHandler struct {
+	organizationService services.IOrganizationService
+	bankService         services.IBankService
+	socialMediaService  services.ISocialMediaService
+}
+
+func NewPanelOrganizationHandler() *PanelOrganizationHandler {
+	return &PanelOrganizationHandler{
+		organizationService: services.NewOrganizationService(),
+		bankService:         services.NewBankService(),
+		socialMediaService:  services.NewSocialMediaService(),
+	}
+}
+
+func (h *PanelOrganizationHandler) ListOrganizations(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uint)
+	renderData := fiber.Map{"Title": "Organizasyonlar"}
+	memberships, err := h.organizationService.GetMemberships(userID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		memberships = []models.OrganizationMember{}
+	}
+	renderData["Memberships"] = memberships
+	return renderer.Render(c, "panel/organizations/list", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelOrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	if err := requests.ValidateOrganizationRequest(c); err != nil {
+		return c.Redirect("/panel/organizations", http.StatusSeeOther)
+	}
+	req := c.Locals("organizationRequest").(requests.OrganizationRequest)
+
+	logo, err := filemanager.UploadFile(c, "logo", "organizations")
+	if err != nil && err != filemanager.ErrFileNotProvided {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Logo yüklenemedi: "+err.Error())
+		return c.Redirect("/panel/organizations", http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	organization, err := h.organizationService.CreateOrganization(ctxWithUser, userID, req, logo)
+	if err != nil {
+		if logo != "" {
+			filemanager.DeleteFile("organizations", logo)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect("/panel/organizations", http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Organizasyon başarıyla oluşturuldu.")
+	return c.Redirect(fmt.Sprintf("/panel/organizations/%d", organization.ID), http.StatusFound)
+}
+
+func (h *PanelOrganizationHandler) ShowOrganization(c *fiber.Ctx) error {
+	organization, member, err := h.getOrganization(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Organizasyon bulunamadı.")
+		return c.Redirect("/panel/organizations", http.StatusSeeOther)
+	}
+
+	renderData := fiber.Map{
+		"Title":        organization.Name,
+		"Organization": organization,
+		"Membership":   member,
+	}
+	cards, err := h.organizationService.GetOrganizationCards(organization.ID)
+	if err != nil {
+		renderData[renderer.FlashErrorKeyView] = err.Error()
+		cards = []models.Card{}
+	}
+	renderData["Cards"] = cards
+
+	if member.IsAdmin() {
+		banksResult, _ := h.bankService.GetAllBanks(queryparams.ListParams{PerPage: 1000})
+		socialMediasResult, _ := h.socialMediaService.GetAllSocialMedias(queryparams.ListParams{PerPage: 1000})
+		if banksResult != nil {
+			renderData["Banks"] = banksResult.Data
+		}
+		if socialMediasResult != nil {
+			renderData["SocialMedias"] = socialMediasResult.Data
+		}
+	}
+	return renderer.Render(c, "panel/organizations/show", "layouts/panel", renderData, http.StatusOK)
+}
+
+func (h *PanelOrganizationHandler) UpdateOrganization(c *fiber.Ctx) error {
+	organization, showURL, err := h.getAdminOrganization(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	if err := requests.ValidateOrganizationRequest(c); err != nil {
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+	req := c.Locals("organizationRequest").(requests.OrganizationRequest)
+
+	logo, err := filemanager.UploadFile(c, "logo", "organizations")
+	if err != nil && err != filemanager.ErrFileNotProvided {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Logo yüklenemedi: "+err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	oldLogo := organization.Logo
+	userID, _ := c.Locals("userID").(uint)
+	organization.UpdatedBy = userID
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.organizationService.UpdateOrganization(ctxWithUser, organization, req, logo); err != nil {
+		if logo != "" {
+			filemanager.DeleteFile("organizations", logo)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+	if logo != "" && oldLogo != "" {
+		filemanager.DeleteFile("organizations", oldLogo)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Organizasyon başarıyla güncellendi.")
+	return c.Redirect(showURL, http.StatusFound)
+}
+
+func (h *PanelOrganizationHandler) AddMember(c *fiber.Ctx) error {
+	organization, showURL, err := h.getAdminOrganization(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	if err := requests.ValidateOrganizationMemberRequest(c); err != nil {
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+	req := c.Locals("organizationMemberRequest").(requests.OrganizationMemberRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	member, err := h.organizationService.AddMember(ctxWithUser, organization, req)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Üye eklenemedi: "+err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, member.User.Name+" organizasyona eklendi.")
+	return c.Redirect(showURL, http.StatusFound)
+}
+
+func (h *PanelOrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	organization, showURL, err := h.getAdminOrganization(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	memberUserID, err := strconv.Atoi(c.Params("userID"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if err := h.organizationService.RemoveMember(ctxWithUser, organization, uint(memberUserID)); err != nil {
+		errMsg := "Üye çıkarılamadı: " + err.Error()
+		if strings.Contains(c.Get("Accept"), "application/json") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errMsg})
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		return c.Redirect(showURL, fiber.StatusSeeOther)
+	}
+	if strings.Contains(c.Get("Accept"), "application/json") {
+		return c.JSON(fiber.Map{"message": "Üye organizasyondan çıkarıldı."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Üye organizasyondan çıkarıldı.")
+	return c.Redirect(showURL, http.StatusFound)
+}
+
+func (h *PanelOrganizationHandler) CreateMemberCard(c *fiber.Ctx) error {
+	organization, showURL, err := h.getAdminOrganization(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	if err := requests.ValidateOrganizationCardRequest(c); err != nil {
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+	req := c.Locals("organizationCardRequest").(requests.OrganizationCardRequest)
+
+	userID, _ := c.Locals("userID").(uint)
+	ctxWithUser := context.WithValue(c.UserContext(), "user_id", userID)
+	if _, err := h.organizationService.CreateMemberCard(ctxWithUser, organization, req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart oluşturulamadı: "+err.Error())
+		return c.Redirect(showURL, http.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Üye kartı başarıyla oluşturuldu.")
+	return c.Redirect(showURL, http.StatusFound)
+}
+
+// getOrganization, URL'deki organizasyonu oturumdaki kullanıcının üyeliğiyle birlikte getirir.
+func (h *PanelOrganizationHandler) getOrganization(c *fiber.Ctx) (*models.Organization, *models.OrganizationMember, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, nil, services.ErrOrganizationNotFound
+	}
+	userID, _ := c.Locals("userID").(uint)
+	return h.organizationService.GetOrganizationForUser(uint(id), userID)
+}
+
+// getAdminOrganization, organizasyonu yalnızca kullanıcı yöneticiyse döner. Hata durumunda
+// dönen adres, kullanıcının yönlendirileceği sayfadır.
+func (h *PanelOrganizationHandler) getAdminOrganization(c *fiber.Ctx) (*models.Organization, string, error) {
+	organization, member, err := h.getOrganization(c)
+	if err != nil {
+		return nil, "/panel/organizations", err
+	}
+	showURL := fmt.Sprintf("/panel/organizations/%d", organization.ID)
+	if !member.IsAdmin() {
+		return nil, showURL, services.ErrOrganizationForbidden
+	}
+	return organization, showURL, nil
+}
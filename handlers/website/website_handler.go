@@ -1,18 +1,63 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"davet.link/configs/sessionconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/ics"
+	"davet.link/pkg/ogimage"
 	"davet.link/pkg/renderer"
+	"davet.link/pkg/seo"
+	"davet.link/requests"
+	"davet.link/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// maxGuestPhotosPerUpload, misafirin tek seferde galeriye yükleyebileceği fotoğraf sayısıdır.
+const maxGuestPhotosPerUpload = 10
+
 type WebsiteHandler struct {
+	invitationService services.IInvitationService
+	guestService      services.IInvitationGuestService
+	questionService   services.IInvitationQuestionService
+	eventService      services.IInvitationEventService
+	rsvpService       services.IRSVPService
+	cardService       services.ICardService
+	analyticsService  services.IAnalyticsService
+	pageService       services.IPageService
+	seoService        services.ISEOService
+	venueService      services.IVenueService
+	checkInService    services.IInvitationCheckInService
+	guestbookService  services.IInvitationGuestbookService
+	galleryService    services.IInvitationGalleryService
+	giftService       services.IInvitationGiftService
 }
 
 func NewWebsiteHandler() *WebsiteHandler {
-	return &WebsiteHandler{}
+	return &WebsiteHandler{
+		invitationService: services.NewInvitationService(),
+		guestService:      services.NewInvitationGuestService(),
+		questionService:   services.NewInvitationQuestionService(),
+		eventService:      services.NewInvitationEventService(),
+		rsvpService:       services.NewRSVPService(),
+		cardService:       services.NewCardService(),
+		analyticsService:  services.NewAnalyticsService(),
+		pageService:       services.NewPageService(),
+		seoService:        services.NewSEOService(),
+		venueService:      services.NewVenueService(),
+		checkInService:    services.NewInvitationCheckInService(),
+		guestbookService:  services.NewInvitationGuestbookService(),
+		galleryService:    services.NewInvitationGalleryService(),
+		giftService:       services.NewInvitationGiftService(),
+	}
 }
 
 func (h *WebsiteHandler) ShowHomePage(c *fiber.Ctx) error {
@@ -20,24 +65,759 @@ func (h *WebsiteHandler) ShowHomePage(c *fiber.Ctx) error {
 	return renderer.Render(c, "website/home", "layouts/website", mapData, http.StatusOK)
 }
 
-func (h *WebsiteHandler) ShowTermsOfUse(c *fiber.Ctx) error {
-	return renderer.Render(c, "website/terms_of_use", "layouts/website", fiber.Map{}, http.StatusOK)
+// ShowStaticPage, koda gömülü tanıtım sayfalarını sunar; şablon adı rotada sabittir.
+func (h *WebsiteHandler) ShowStaticPage(template string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return renderer.Render(c, template, "layouts/website", fiber.Map{}, http.StatusOK)
+	}
+}
+
+// ShowPage, yönetim panelinden düzenlenen yayındaki sayfayı sabit website/page şablonuyla
+// sunar. Bilinmeyen veya taslak sayfalar 404 döner.
+func (h *WebsiteHandler) ShowPage(c *fiber.Ctx) error {
+	page, err := h.pageService.GetPublishedPageBySlug(c.Params("pageSlug"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return renderer.Render(c, "website/page", "layouts/website", fiber.Map{
+		"Page": page,
+		"Meta": services.PageMeta(page),
+	}, http.StatusOK)
 }
 
-func (h *WebsiteHandler) ShowStaticPage(c *fiber.Ctx) error {
-	page := c.Params("staticPageName")
-	template := "website/" + page
-	return renderer.Render(c, template, "layouts/website", fiber.Map{}, http.StatusOK)
+// ShowSitemap, site haritasını veritabanındaki herkese açık kayıtlardan üretir.
+func (h *WebsiteHandler) ShowSitemap(c *fiber.Ctx) error {
+	urls, err := h.seoService.SitemapURLs()
+	if err != nil {
+		return fiber.ErrInternalServerError
+	}
+	c.Set(fiber.HeaderContentType, "application/xml; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return seo.WriteSitemap(c, urls)
 }
 
 func (h *WebsiteHandler) ShowInvitation(c *fiber.Ctx) error {
-	invitationKey := c.Params("invitationKey")
-	// TODO: Davetiye verisini çek ve render et
-	return renderer.Render(c, "website/invitation", "layouts/website", fiber.Map{"InvitationKey": invitationKey}, http.StatusOK)
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	h.analyticsService.TrackView(models.PageViewInvitation, invitation.ID, viewInput(c))
+	questions, _ := h.questionService.GetQuestionsByInvitationID(invitation.ID)
+
+	// Bu tarayıcıdan daha önce yanıt verildiyse form önceki yanıtla doldurulur.
+	var formData *requests.RSVPRequest
+	answerValues := map[uint]string{}
+	var eventValues map[uint]bool
+	participant, err := h.rsvpService.GetEditableParticipant(invitation.ID, rsvpEditToken(c, invitation.ID))
+	if err == nil {
+		formData = &requests.RSVPRequest{
+			Title:       participant.Title,
+			PhoneNumber: participant.PhoneNumber,
+			GuestCount:  participant.GuestCount,
+			Status:      string(participant.Status),
+			Message:     participant.Message,
+		}
+		if values, err := h.questionService.GetAnswerValues(participant.ID); err == nil {
+			answerValues = values
+		}
+		if values, err := h.eventService.GetResponseValues(participant.ID); err == nil {
+			eventValues = values
+		}
+	} else {
+		participant = nil
+	}
+
+	appearance := services.InvitationAppearance(invitation)
+	return renderer.Render(c, appearance.Theme.View, "layouts/website", fiber.Map{
+		"Invitation":      invitation,
+		"Headline":        services.InvitationHeadline(invitation),
+		"DetailEntries":   services.InvitationDetailEntries(invitation),
+		"Appearance":      appearance,
+		"Questions":       questions,
+		"Participant":     participant,
+		"FormData":        formData,
+		"AnswerValues":    answerValues,
+		"EventValues":     eventValues,
+		"RSVPAction":      "/" + invitation.InvitationKey + "/rsvp",
+		"Guestbook":       h.guestbookService.GetPublicEntries(invitation),
+		"GuestbookAction": "/" + invitation.InvitationKey + "/guestbook",
+		"Gallery":         h.galleryService.GetPreview(invitation),
+		"GalleryURL":      "/" + invitation.InvitationKey + "/gallery",
+		"Gifts":           h.giftService.GetPreview(invitation),
+		"GiftsURL":        "/" + invitation.InvitationKey + "/gifts",
+		"Meta":            services.InvitationMeta(invitation),
+	}, http.StatusOK)
+}
+
+// ShowInvitationPreview, davetiye bağlantısı paylaşıldığında gösterilen önizleme görselini üretir.
+func (h *WebsiteHandler) ShowInvitationPreview(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return sendPreview(c, services.InvitationPreview(invitation), invitation.UpdatedAt)
+}
+
+// ShowInvitationCalendar, davetiyeyi takvim uygulamalarına eklemek için ICS dosyası olarak döner.
+func (h *WebsiteHandler) ShowInvitationCalendar(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	events := services.InvitationCalendarEvents(invitation)
+	if len(events) == 0 {
+		return fiber.ErrNotFound
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+invitation.InvitationKey+`.ics"`)
+	return ics.Write(c, events...)
+}
+
+// ShowGuestInvitation, kişiye özel bağlantıyla açılan davetiyeyi misafirin bilgileriyle doldurulmuş
+// katılım formuyla gösterir ve bağlantının açıldığını kaydeder.
+func (h *WebsiteHandler) ShowGuestInvitation(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	_ = h.guestService.MarkGuestOpened(c.UserContext(), guest)
+	h.analyticsService.TrackView(models.PageViewInvitation, guest.InvitationID, viewInput(c))
+
+	formData := requests.RSVPRequest{
+		Title:       guest.Title,
+		PhoneNumber: guest.PhoneNumber,
+		GuestCount:  guest.SeatLimit,
+	}
+	answerValues := map[uint]string{}
+	// Daha önce yanıt vermemiş misafir için program bölümleri işaretli gelir.
+	var eventValues map[uint]bool
+	if guest.Participant != nil {
+		formData.Title = guest.Participant.Title
+		formData.PhoneNumber = guest.Participant.PhoneNumber
+		formData.GuestCount = guest.Participant.GuestCount
+		formData.Status = string(guest.Participant.Status)
+		formData.Message = guest.Participant.Message
+		if values, err := h.questionService.GetAnswerValues(guest.Participant.ID); err == nil {
+			answerValues = values
+		}
+		if values, err := h.eventService.GetResponseValues(guest.Participant.ID); err == nil {
+			eventValues = values
+		}
+	}
+	questions, _ := h.questionService.GetQuestionsByInvitationID(guest.InvitationID)
+
+	// Kişiye özel bağlantılar paylaşım önizlemesini korur ama hiçbir zaman dizinlenmez
+	meta := services.InvitationMeta(&guest.Invitation)
+	meta.NoIndex = true
+
+	appearance := services.InvitationAppearance(&guest.Invitation)
+	return renderer.Render(c, appearance.Theme.View, "layouts/website", fiber.Map{
+		"Invitation":      &guest.Invitation,
+		"Headline":        services.InvitationHeadline(&guest.Invitation),
+		"DetailEntries":   services.InvitationDetailEntries(&guest.Invitation),
+		"Appearance":      appearance,
+		"Guest":           guest,
+		"FormData":        formData,
+		"Questions":       questions,
+		"AnswerValues":    answerValues,
+		"EventValues":     eventValues,
+		"RSVPAction":      "/g/" + guest.Token + "/rsvp",
+		"Guestbook":       h.guestbookService.GetPublicEntries(&guest.Invitation),
+		"GuestbookAction": "/g/" + guest.Token + "/guestbook",
+		"Gallery":         h.galleryService.GetPreview(&guest.Invitation),
+		"GalleryURL":      "/g/" + guest.Token + "/gallery",
+		"Gifts":           h.giftService.GetPreview(&guest.Invitation),
+		"GiftsURL":        "/g/" + guest.Token + "/gifts",
+		"Meta":            meta,
+	}, http.StatusOK)
+}
+
+func (h *WebsiteHandler) SubmitRSVP(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	redirectURL := "/" + invitation.InvitationKey
+
+	if err := requests.ValidateRSVPRequest(c); err != nil {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	req := c.Locals("rsvpRequest").(requests.RSVPRequest)
+
+	result, err := h.rsvpService.SubmitRSVP(c.UserContext(), invitation, req, rsvpEditToken(c, invitation.ID))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := saveRSVPEditToken(c, invitation.ID, result.EditToken); err != nil {
+		return fiber.ErrInternalServerError
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, rsvpSuccessMessage(req, result))
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *WebsiteHandler) SubmitGuestRSVP(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	redirectURL := "/g/" + guest.Token
+
+	if err := requests.ValidateRSVPRequest(c); err != nil {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	req := c.Locals("rsvpRequest").(requests.RSVPRequest)
+
+	result, err := h.rsvpService.SubmitGuestRSVP(c.UserContext(), guest, req)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, rsvpSuccessMessage(req, result))
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *WebsiteHandler) SubmitGuestbookEntry(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.submitGuestbookEntry(c, invitation, nil, "/"+invitation.InvitationKey)
+}
+
+func (h *WebsiteHandler) SubmitGuestGuestbookEntry(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.submitGuestbookEntry(c, &guest.Invitation, guest, "/g/"+guest.Token)
+}
+
+// GuestbookLimitReached, aynı adresten kısa sürede çok fazla mesaj gönderildiğinde formu
+// gönderen sayfaya uyarıyla geri döner.
+func (h *WebsiteHandler) GuestbookLimitReached(c *fiber.Ctx) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kısa sürede çok fazla mesaj gönderdiniz, lütfen biraz sonra tekrar deneyin.")
+	return c.Redirect(strings.TrimSuffix(c.Path(), "/guestbook"), http.StatusSeeOther)
+}
+
+func (h *WebsiteHandler) submitGuestbookEntry(c *fiber.Ctx, invitation *models.Invitation, guest *models.InvitationGuest, redirectURL string) error {
+	if !invitation.IsGuestbook {
+		return fiber.ErrNotFound
+	}
+	if err := requests.ValidateGuestbookEntryRequest(c); err != nil {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	req := c.Locals("guestbookEntryRequest").(requests.GuestbookEntryRequest)
+	successMessage := "Mesajınız için teşekkür ederiz. Ev sahibi onayladıktan sonra anı defterinde görünecek."
+	// Tuzak alanı dolduran botlara mesaj kaydedilmeden başarılı yanıt verilir.
+	if req.IsBot() {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+		return c.Redirect(redirectURL, http.StatusFound)
+	}
+
+	photo, err := filemanager.UploadFile(c, "photo", services.GuestbookContentType)
+	if err != nil && err != filemanager.ErrFileNotProvided {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Fotoğraf yüklenemedi: "+err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := h.guestbookService.SubmitEntry(c.UserContext(), invitation, guest, photo, req); err != nil {
+		filemanager.DeleteFile(services.GuestbookContentType, photo)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successMessage)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// ShowGallery, davetiyenin fotoğraf galerisini gösterir. PIN ile yükleme açıksa PIN'i doğrulanan
+// ziyaretçilere yükleme formu da gösterilir.
+func (h *WebsiteHandler) ShowGallery(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.showGallery(c, invitation, nil, "/"+invitation.InvitationKey)
+}
+
+// ShowGuestGallery, galeriyi kişiye özel bağlantıyla açan misafire gösterir; yükleme açıksa
+// misafir PIN girmeden fotoğraf yükleyebilir.
+func (h *WebsiteHandler) ShowGuestGallery(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.showGallery(c, &guest.Invitation, guest, "/g/"+guest.Token)
+}
+
+func (h *WebsiteHandler) VerifyGalleryPIN(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	galleryURL := "/" + invitation.InvitationKey + "/gallery"
+
+	if err := requests.ValidateGalleryPINRequest(c); err != nil {
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+	req := c.Locals("galleryPINRequest").(requests.GalleryPINRequest)
+	if err := h.galleryService.VerifyPIN(album, req.PIN); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(galleryURL, http.StatusSeeOther)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return fiber.ErrInternalServerError
+	}
+	sess.Set(galleryPINSessionKey(album.ID), album.UploadPINHash)
+	if err := sess.Save(); err != nil {
+		return fiber.ErrInternalServerError
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "PIN doğrulandı, artık fotoğraf yükleyebilirsiniz.")
+	return c.Redirect(galleryURL, http.StatusFound)
+}
+
+func (h *WebsiteHandler) UploadGalleryPhotos(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.uploadGalleryPhotos(c, invitation, nil, "/"+invitation.InvitationKey+"/gallery")
+}
+
+func (h *WebsiteHandler) UploadGuestGalleryPhotos(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.uploadGalleryPhotos(c, &guest.Invitation, guest, "/g/"+guest.Token+"/gallery")
+}
+
+// GalleryLimitReached, kısa sürede çok fazla PIN denemesi veya yükleme yapıldığında galeri
+// sayfasına uyarıyla geri döner.
+func (h *WebsiteHandler) GalleryLimitReached(c *fiber.Ctx) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kısa sürede çok fazla istek gönderdiniz, lütfen biraz sonra tekrar deneyin.")
+	return c.Redirect(strings.TrimSuffix(c.Path(), "/pin"), http.StatusSeeOther)
+}
+
+func (h *WebsiteHandler) showGallery(c *fiber.Ctx, invitation *models.Invitation, guest *models.InvitationGuest, invitationURL string) error {
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	pinVerified := galleryPINVerified(c, album)
+
+	// Galeride misafir fotoğrafları bulunabildiğinden sayfa dizinlenmez.
+	meta := services.InvitationMeta(invitation)
+	meta.NoIndex = true
+	return renderer.Render(c, "website/gallery", "layouts/website", fiber.Map{
+		"Invitation":    invitation,
+		"Album":         album,
+		"Photos":        h.galleryService.GetPublicPhotos(album),
+		"Guest":         guest,
+		"CanUpload":     h.galleryService.CanGuestUpload(album, guest, pinVerified),
+		"NeedsPIN":      guest == nil && album.GuestUpload == models.GalleryUploadPIN && !pinVerified,
+		"InvitationURL": invitationURL,
+		"UploadAction":  invitationURL + "/gallery",
+		"PINAction":     "/" + invitation.InvitationKey + "/gallery/pin",
+		"MaxPhotoSize":  h.galleryService.MaxPhotoSize(),
+		"MaxPhotos":     maxGuestPhotosPerUpload,
+		"Meta":          meta,
+	}, http.StatusOK)
+}
+
+// uploadGalleryPhotos, misafirin seçtiği fotoğrafları albüme ekler. Kota dolduğunda veya
+// geçersiz bir dosyaya gelindiğinde kalan dosyalar yüklenmez; o ana kadar eklenenler korunur.
+func (h *WebsiteHandler) uploadGalleryPhotos(c *fiber.Ctx, invitation *models.Invitation, guest *models.InvitationGuest, redirectURL string) error {
+	album, err := h.galleryService.GetAlbum(invitation.ID)
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	if !h.galleryService.CanGuestUpload(album, guest, galleryPINVerified(c, album)) {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, services.ErrGalleryUploadClosed.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	if err := requests.ValidateGalleryUploadRequest(c); err != nil {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	req := c.Locals("galleryUploadRequest").(requests.GalleryUploadRequest)
+
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["photos"]) == 0 {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen en az bir fotoğraf seçin.")
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	files := form.File["photos"]
+	if len(files) > maxGuestPhotosPerUpload {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, fmt.Sprintf("Tek seferde en fazla %d fotoğraf yükleyebilirsiniz.", maxGuestPhotosPerUpload))
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+
+	uploader := &services.GalleryUploader{Guest: guest, Name: req.Name}
+	uploaded := 0
+	for _, file := range files {
+		fileName, err := filemanager.SaveFormFile(c, file, services.GalleryContentType, h.galleryService.MaxPhotoSize())
+		if err == nil {
+			err = h.galleryService.AddPhoto(c.UserContext(), album, fileName, file.Filename, file.Size, uploader)
+			if err != nil {
+				filemanager.DeleteFile(services.GalleryContentType, fileName)
+			}
+		}
+		if err != nil {
+			msg := fmt.Sprintf("%s yüklenemedi: %s", file.Filename, err.Error())
+			if uploaded > 0 {
+				msg = fmt.Sprintf("%d fotoğraf yüklendi. %s", uploaded, msg)
+			}
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+			return c.Redirect(redirectURL, http.StatusSeeOther)
+		}
+		uploaded++
+	}
+
+	msg := fmt.Sprintf("%d fotoğraf galeriye eklendi, teşekkür ederiz.", uploaded)
+	if album.RequireApproval {
+		msg = fmt.Sprintf("%d fotoğrafınız alındı, teşekkür ederiz. Ev sahibi onayladıktan sonra galeride görünecek.", uploaded)
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, msg)
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// galleryPINSessionKey, albüm PIN'inin bu oturumda doğrulandığını tutan oturum anahtarıdır.
+// Oturumda PIN'in özeti saklanır; davetiye sahibi PIN'i değiştirdiğinde eski doğrulamalar
+// geçersiz olur.
+func galleryPINSessionKey(albumID uint) string {
+	return fmt.Sprintf("gallery_pin_%d", albumID)
+}
+
+func galleryPINVerified(c *fiber.Ctx, album *models.InvitationAlbum) bool {
+	if !album.HasPIN() {
+		return false
+	}
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return false
+	}
+	verified, _ := sess.Get(galleryPINSessionKey(album.ID)).(string)
+	return verified == album.UploadPINHash
+}
+
+// ShowGifts, davetiyenin hediye hesaplarını ve hediye listesini gösterir. Ayrılmış ürünlerde
+// ayırmayı kimin yaptığı gösterilmez.
+func (h *WebsiteHandler) ShowGifts(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.showGifts(c, invitation, nil, "/"+invitation.InvitationKey)
+}
+
+func (h *WebsiteHandler) ShowGuestGifts(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.showGifts(c, &guest.Invitation, guest, "/g/"+guest.Token)
+}
+
+func (h *WebsiteHandler) ReserveGift(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.reserveGift(c, invitation, nil, "/"+invitation.InvitationKey+"/gifts")
+}
+
+func (h *WebsiteHandler) ReserveGuestGift(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.reserveGift(c, &guest.Invitation, guest, "/g/"+guest.Token+"/gifts")
+}
+
+func (h *WebsiteHandler) CancelGiftReservation(c *fiber.Ctx) error {
+	invitation, err := h.invitationService.GetInvitationByKey(c.UserContext(), c.Params("invitationKey"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.cancelGiftReservation(c, invitation, "/"+invitation.InvitationKey+"/gifts")
+}
+
+func (h *WebsiteHandler) CancelGuestGiftReservation(c *fiber.Ctx) error {
+	guest, err := h.guestService.GetGuestByToken(c.UserContext(), c.Params("guestToken"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return h.cancelGiftReservation(c, &guest.Invitation, "/g/"+guest.Token+"/gifts")
+}
+
+// GiftLimitReached, kısa sürede çok fazla ayırma yapıldığında hediye listesine uyarıyla geri
+// döner.
+func (h *WebsiteHandler) GiftLimitReached(c *fiber.Ctx) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kısa sürede çok fazla istek gönderdiniz, lütfen biraz sonra tekrar deneyin.")
+	path := c.Path()
+	if i := strings.LastIndex(path, "/gifts/"); i >= 0 {
+		path = path[:i+len("/gifts")]
+	}
+	return c.Redirect(path, http.StatusSeeOther)
+}
+
+func (h *WebsiteHandler) showGifts(c *fiber.Ctx, invitation *models.Invitation, guest *models.InvitationGuest, invitationURL string) error {
+	banks, err := h.giftService.GetBanks(invitation.ID)
+	if err != nil {
+		return fiber.ErrInternalServerError
+	}
+	items, err := h.giftService.GetItems(invitation.ID)
+	if err != nil {
+		return fiber.ErrInternalServerError
+	}
+	if len(banks) == 0 && len(items) == 0 {
+		return fiber.ErrNotFound
+	}
+
+	meta := services.InvitationMeta(invitation)
+	meta.NoIndex = true
+	return renderer.Render(c, "website/gifts", "layouts/website", fiber.Map{
+		"Invitation":     invitation,
+		"Banks":          banks,
+		"Items":          items,
+		"Guest":          guest,
+		"MyReservations": h.giftService.VisitorReservations(invitation.ID, giftReservationTokens(c, invitation.ID)),
+		"InvitationURL":  invitationURL,
+		"GiftsURL":       invitationURL + "/gifts",
+		"Meta":           meta,
+	}, http.StatusOK)
+}
+
+// reserveGift, ürünün bir adedini ziyaretçi adına ayırır ve ayırmanın anahtarını oturuma ekler;
+// ziyaretçi aynı tarayıcıdan ayırmasını geri alabilir.
+func (h *WebsiteHandler) reserveGift(c *fiber.Ctx, invitation *models.Invitation, guest *models.InvitationGuest, redirectURL string) error {
+	itemID, err := c.ParamsInt("itemID")
+	if err != nil || itemID <= 0 {
+		return fiber.ErrNotFound
+	}
+	if err := requests.ValidateGiftReservationRequest(c); err != nil {
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	req := c.Locals("giftReservationRequest").(requests.GiftReservationRequest)
+
+	reservation, err := h.giftService.Reserve(c.UserContext(), invitation, uint(itemID), guest, req)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	tokens := append(giftReservationTokens(c, invitation.ID), reservation.Token)
+	if err := saveGiftReservationTokens(c, invitation.ID, tokens); err != nil {
+		return fiber.ErrInternalServerError
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ürün sizin için ayrıldı, teşekkür ederiz. Diğer misafirler kimin ayırdığını göremez.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+func (h *WebsiteHandler) cancelGiftReservation(c *fiber.Ctx, invitation *models.Invitation, redirectURL string) error {
+	reservationID, err := c.ParamsInt("reservationID")
+	if err != nil || reservationID <= 0 {
+		return fiber.ErrNotFound
+	}
+	tokens := giftReservationTokens(c, invitation.ID)
+	token, err := h.giftService.CancelVisitorReservation(c.UserContext(), invitation, uint(reservationID), tokens)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, err.Error())
+		return c.Redirect(redirectURL, http.StatusSeeOther)
+	}
+	remaining := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t != token {
+			remaining = append(remaining, t)
+		}
+	}
+	if err := saveGiftReservationTokens(c, invitation.ID, remaining); err != nil {
+		return fiber.ErrInternalServerError
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Ayırmanız geri alındı.")
+	return c.Redirect(redirectURL, http.StatusFound)
+}
+
+// rsvpEditSessionKey, ziyaretçinin genel bağlantıdan verdiği katılım bildirimini sonradan
+// değiştirebilmesi için kaydın anahtarını tutan oturum anahtarıdır.
+func rsvpEditSessionKey(invitationID uint) string {
+	return fmt.Sprintf("rsvp_edit_%d", invitationID)
+}
+
+func rsvpEditToken(c *fiber.Ctx, invitationID uint) string {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return ""
+	}
+	token, _ := sess.Get(rsvpEditSessionKey(invitationID)).(string)
+	return token
+}
+
+func saveRSVPEditToken(c *fiber.Ctx, invitationID uint, token string) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return err
+	}
+	sess.Set(rsvpEditSessionKey(invitationID), token)
+	return sess.Save()
+}
+
+// giftReservationsSessionKey, ziyaretçinin davetiyede yaptığı ayırmaların anahtarlarını boşlukla
+// ayrılmış olarak tutan oturum anahtarıdır.
+func giftReservationsSessionKey(invitationID uint) string {
+	return fmt.Sprintf("gift_reservations_%d", invitationID)
+}
+
+func giftReservationTokens(c *fiber.Ctx, invitationID uint) []string {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return nil
+	}
+	tokens, _ := sess.Get(giftReservationsSessionKey(invitationID)).(string)
+	return strings.Fields(tokens)
+}
+
+func saveGiftReservationTokens(c *fiber.Ctx, invitationID uint, tokens []string) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return err
+	}
+	sess.Set(giftReservationsSessionKey(invitationID), strings.Join(tokens, " "))
+	return sess.Save()
+}
+
+// ShowTicket, katılımcının kapıda okutacağı QR biletini gösterir.
+func (h *WebsiteHandler) ShowTicket(c *fiber.Ctx) error {
+	participant, err := h.checkInService.GetTicket(c.Params("ticketCode"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	meta := services.InvitationMeta(&participant.Invitation)
+	meta.NoIndex = true
+	return renderer.Render(c, "website/ticket", "layouts/website", fiber.Map{
+		"Participant": participant,
+		"TicketCode":  c.Params("ticketCode"),
+		"Meta":        meta,
+	}, http.StatusOK)
+}
+
+// ShowTicketQR, biletin QR kodunu PNG olarak döner; download parametresiyle dosya olarak indirilir.
+func (h *WebsiteHandler) ShowTicketQR(c *fiber.Ctx) error {
+	participant, err := h.checkInService.GetTicket(c.Params("ticketCode"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	var buf bytes.Buffer
+	if err := h.checkInService.WriteTicketQR(&buf, participant); err != nil {
+		return err
+	}
+	if c.QueryBool("download") {
+		c.Attachment(fmt.Sprintf("bilet-%d.png", participant.ID))
+	}
+	c.Set(fiber.HeaderContentType, "image/png")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=3600")
+	return c.Send(buf.Bytes())
+}
+
+func rsvpSuccessMessage(req requests.RSVPRequest, result *services.RSVPResult) string {
+	if result != nil && result.Waitlisted {
+		return "Etkinlik kapasitesi doldu, yedek listeye alındınız. Yer açıldığında katılımınız otomatik olarak onaylanacaktır."
+	}
+	switch models.RSVPStatus(req.Status) {
+	case models.RSVPAttending:
+		if result != nil && result.TableName != "" {
+			return "Katılım bildiriminiz alındı, teşekkür ederiz. Masanız: " + result.TableName
+		}
+		return "Katılım bildiriminiz alındı, teşekkür ederiz."
+	case models.RSVPMaybe:
+		return "Yanıtınız alındı. Kararınız netleştiğinde bu formu tekrar gönderebilirsiniz."
+	default:
+		return "Yanıtınız alındı, teşekkür ederiz."
+	}
 }
 
 func (h *WebsiteHandler) ShowCard(c *fiber.Ctx) error {
-	cardSlug := c.Params("cardSlug")
-	// TODO: Kartvizit verisini çek ve render et
-	return renderer.Render(c, "website/card", "layouts/website", fiber.Map{"CardSlug": cardSlug}, http.StatusOK)
+	card, err := h.cardService.GetActiveCardBySlug(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	h.analyticsService.TrackView(models.PageViewCard, card.ID, viewInput(c))
+	return renderer.Render(c, "website/card", "layouts/website", fiber.Map{
+		"Card": card,
+		"Meta": services.CardMeta(card),
+	}, http.StatusOK)
+}
+
+// ShowCardPreview, kartvizit bağlantısı paylaşıldığında gösterilen önizleme görselini üretir.
+func (h *WebsiteHandler) ShowCardPreview(c *fiber.Ctx) error {
+	card, err := h.cardService.GetActiveCardBySlug(c.UserContext(), c.Params("cardSlug"))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+	return sendPreview(c, services.CardPreview(card), card.UpdatedAt)
+}
+
+// ListProvinces, mekân formlarındaki il seçimi için illeri plaka sırasıyla JSON olarak döner.
+func (h *WebsiteHandler) ListProvinces(c *fiber.Ctx) error {
+	provinces, err := h.venueService.GetProvinces()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	items := make([]fiber.Map, 0, len(provinces))
+	for _, province := range provinces {
+		items = append(items, fiber.Map{"id": province.ID, "code": province.Code, "name": province.Name})
+	}
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.JSON(items)
+}
+
+// ListDistricts, seçilen ilin ilçelerini JSON olarak döner.
+func (h *WebsiteHandler) ListDistricts(c *fiber.Ctx) error {
+	provinceID, err := c.ParamsInt("provinceID")
+	if err != nil || provinceID <= 0 {
+		return fiber.ErrNotFound
+	}
+	districts, err := h.venueService.GetDistricts(uint(provinceID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	items := make([]fiber.Map, 0, len(districts))
+	for _, district := range districts {
+		items = append(items, fiber.Map{"id": district.ID, "name": district.Name})
+	}
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.JSON(items)
+}
+
+// sendPreview, önizleme görselini PNG olarak döner. Görsel kaydın güncellenme zamanına bağlı
+// olduğundan Last-Modified ile koşullu istekler 304 ile yanıtlanır.
+func sendPreview(c *fiber.Ctx, preview ogimage.Preview, updatedAt time.Time) error {
+	c.Set(fiber.HeaderLastModified, updatedAt.UTC().Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	if c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	var buf bytes.Buffer
+	if err := ogimage.Render(&buf, preview); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Send(buf.Bytes())
+}
+
+// viewInput, görüntülenme istatistiği için gereken istek bilgilerini toplar.
+func viewInput(c *fiber.Ctx) services.ViewInput {
+	return services.ViewInput{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		Referer:   c.Get(fiber.HeaderReferer),
+		Host:      c.Hostname(),
+	}
 }
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimitMiddleware, istek gövdesinin boyutunu Content-Length başlığına bakarak, gövde okunmadan
+// denetler. Sunucu gövdeleri akış olarak okuduğunda (StreamRequestBody) genel sınır bu ara katmanla
+// uygulanır; isLargeUpload true dönen istekler için uploadLimit kullanılır. Uzunluğu bildirilmeyen
+// (chunked) gövdeler kabul edilmez.
+func BodyLimitMiddleware(limit int, uploadLimit int, isLargeUpload func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		length := c.Request().Header.ContentLength()
+		if length == 0 || length == -2 {
+			return c.Next()
+		}
+
+		max := limit
+		if isLargeUpload != nil && isLargeUpload(c) {
+			max = uploadLimit
+		}
+		if length == -1 {
+			c.Context().SetConnectionClose()
+			return fiber.ErrLengthRequired
+		}
+		if length > max {
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"davet.link/pkg/renderer"
+	"davet.link/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationMiddleware, panel yerleşiminde gösterilen okunmamış bildirim sayısını hazırlar.
+// Sayı alınamazsa sayfa bildirim rozeti olmadan gösterilir.
+func NotificationMiddleware() fiber.Handler {
+	notificationService := services.NewNotificationService()
+	return func(c *fiber.Ctx) error {
+		userID, _ := c.Locals("userID").(uint)
+		if userID == 0 {
+			return c.Next()
+		}
+
+		count, err := notificationService.CountUnread(userID)
+		if err == nil {
+			c.Locals(renderer.UnreadNotificationsLocalKey, count)
+		}
+		return c.Next()
+	}
+}
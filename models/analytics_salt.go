@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AnalyticsSalt, ziyaretçi özetlerinde kullanılan günlük rastgele tuzdur. Gün bittikten
+// sonra silinir; böylece eski özetler yeniden hesaplanamaz.
+type AnalyticsSalt struct {
+	BaseModel
+
+	Day  time.Time `gorm:"type:date;not null;uniqueIndex"`
+	Salt string    `gorm:"type:varchar(64);not null"`
+}
+
+func (AnalyticsSalt) TableName() string {
+	return "analytics_salts"
+}
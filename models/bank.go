@@ -4,6 +4,8 @@ type Bank struct {
 	BaseModel
 	IsActive bool   `gorm:"default:true;index"`
 	Name     string `gorm:"size:255;not null;index"`
+	// Code, bankanın beş haneli EFT kodudur; TR IBAN'larının 5-9. karakterleriyle eşleşir.
+	Code string `gorm:"size:5;index"`
 }
 
 func (Bank) TableName() string {
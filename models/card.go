@@ -4,7 +4,7 @@ type Card struct {
 	BaseModel
 	// Required fields
 	IsActive bool   `gorm:"not null;default:true;index"`
-	UserID   uint   `gorm:"uniqueIndex;not null"` // Foreign key for one-to-one relationship with User
+	UserID   uint   `gorm:"index;not null"` // Kartın sahibi; bir kullanıcının birden fazla kartı olabilir
 	Slug     string `gorm:"size:255;not null;uniqueIndex"`
 
 	// Optional fields
@@ -13,11 +13,15 @@ type Card struct {
 	Photo     string `gorm:"size:255"`
 	Telephone string `gorm:"size:20"`
 	Email     string `gorm:"size:100"`
-	Location  string `gorm:"size:255"`
+	// Venue, kartta gösterilen iş yeri adresidir; venue_ önekli sütunlarda saklanır.
+	Venue      Venue  `gorm:"embedded;embeddedPrefix:venue_"`
 	WebsiteUrl string `gorm:"size:255"`
 	StoreUrl   string `gorm:"size:255"`
 	// Relationships
 	User        *User   `gorm:"foreignKey:UserID"`
+	// Organizasyona bağlı kartlar organizasyonun logosu, banka ve sosyal medya hesaplarını da gösterir
+	OrganizationID *uint         `gorm:"index"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID"`
 	// Has many relationships with junction tables
 	CardBanks       []CardBank        `gorm:"foreignKey:CardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
     CardSocialMedia []CardSocialMedia `gorm:"foreignKey:CardID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
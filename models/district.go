@@ -0,0 +1,13 @@
+package models
+
+// District, bir ile bağlı ilçedir.
+type District struct {
+	BaseModel
+	ProvinceID uint      `gorm:"not null;uniqueIndex:idx_districts_province_name"`
+	Name       string    `gorm:"size:100;not null;uniqueIndex:idx_districts_province_name"`
+	Province   *Province `gorm:"foreignKey:ProvinceID"`
+}
+
+func (District) TableName() string {
+	return "districts"
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"davet.link/pkg/detailfields"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Invitation struct {
 	BaseModel
@@ -11,29 +16,73 @@ type Invitation struct {
 	UserID         uint      `gorm:"index;not null"`
 	CategoryID     uint      `gorm:"index;not null"`
 	Template       string    `gorm:"type:varchar(100);not null"`
+	// ThemeOptions, şablonun renk, yazı tipi ve arka plan seçenekleridir (JSON)
+	ThemeOptions   string    `gorm:"type:text"`
 	Type           string    `gorm:"type:varchar(50);not null;default:'basic'"`
 	IsConfirmed    bool      `gorm:"not null;default:false;index"`
 	IsParticipant  bool      `gorm:"not null;default:true"`
+	// IsPublic, davetiyenin site haritasında listelenip arama motorlarınca dizinlenmesine izin verir
+	IsPublic       bool      `gorm:"not null;default:false;index"`
+	// IsGuestbook, davetiye sayfasında misafirlerin iyi dilek bırakabildiği anı defterini açar
+	IsGuestbook    bool      `gorm:"not null;default:false"`
 	
 	// --- Opsiyonel Alanlar (Değişiklik Yok) ---
 	Title         string    `gorm:"type:varchar(255)"`
 	Description   string    `gorm:"type:text"`
-	Venue         string    `gorm:"type:varchar(255)"`
-	Address       string    `gorm:"type:varchar(255)"`
-	Location      string    `gorm:"type:varchar(255)"`
+	// Venue, etkinlik mekânıdır; venue_ önekli sütunlarda saklanır.
+	Venue         Venue     `gorm:"embedded;embeddedPrefix:venue_"`
 	Link          string    `gorm:"type:varchar(255)"`
 	Telephone     string    `gorm:"type:varchar(20)"`
 	Note          string    `gorm:"type:text"`
 	Date          time.Time `gorm:"index"`
 	Time          string	`gorm:"type:varchar(10)"`
 	
+	// --- Katılım (RSVP) Sınırları: 0 sınırsız demektir ---
+	RSVPDeadline            *time.Time
+	MaxGuests               int `gorm:"not null;default:0"`
+	MaxGuestsPerParticipant int `gorm:"not null;default:0"`
+	
+	// --- Hatırlatmalar: etkinlikten önceki gönderim zamanları (ör: "7d,1d") ---
+	ReminderOffsets string `gorm:"type:varchar(100)"`
+	
 	// --- Relationships (İlişki tanımları daha sonra ayarlanacak) ---
 	User               *User
 	Category           *InvitationCategory
 	InvitationDetail   *InvitationDetail
 	Participants       []InvitationParticipant
+	// Events, davetiyenin program bölümleridir; yalnızca davetiye sayfasında yüklenir.
+	Events             []InvitationEvent
 }
 
 func (Invitation) TableName() string {
 	return "invitations"
-}
\ No newline at end of file
+}
+// IsRSVPClosed, LCV son tarihi geçmişse true döner.
+func (i *Invitation) IsRSVPClosed() bool {
+	return i.RSVPDeadline != nil && time.Now().After(*i.RSVPDeadline)
+}
+
+// StartsAt, etkinliğin başlangıç zamanını tarih ve saat alanlarını birleştirerek döner.
+// Saat girilmemişse ikinci dönüş değeri false olur ve günün başlangıcı döner.
+func (i *Invitation) StartsAt() (time.Time, bool) {
+	date := i.Date.In(time.Local)
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	hour, minute, ok := strings.Cut(strings.TrimSpace(i.Time), ":")
+	if !ok {
+		return start, false
+	}
+	h, errH := strconv.Atoi(hour)
+	m, errM := strconv.Atoi(minute)
+	if errH != nil || errM != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return start, false
+	}
+	return start.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute), true
+}
+
+// DetailFields, davetiyenin detay alanı değerlerini döner; detay yüklenmemişse boş döner.
+func (i *Invitation) DetailFields() detailfields.Values {
+	if i.InvitationDetail == nil {
+		return detailfields.Values{}
+	}
+	return i.InvitationDetail.Fields
+}
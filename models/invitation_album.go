@@ -0,0 +1,60 @@
+package models
+
+type GalleryUploadMode string
+
+const (
+	// GalleryUploadOff, albüme yalnızca davetiye sahibinin fotoğraf yükleyebilmesidir.
+	GalleryUploadOff GalleryUploadMode = "off"
+	// GalleryUploadLink, kişiye özel bağlantıyla gelen misafirlerin de yükleyebilmesidir.
+	GalleryUploadLink GalleryUploadMode = "link"
+	// GalleryUploadPIN, kişiye özel bağlantıya ek olarak albüm PIN'ini bilen herkesin
+	// yükleyebilmesidir.
+	GalleryUploadPIN GalleryUploadMode = "pin"
+)
+
+// InvitationAlbum, davetiyenin fotoğraf galerisidir. Her davetiyenin tek albümü vardır; albüm
+// davetiye sahibi galeriyi ilk açtığında oluşturulur.
+type InvitationAlbum struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint              `gorm:"uniqueIndex;not null"`
+	GuestUpload  GalleryUploadMode `gorm:"type:varchar(10);not null;default:'off'"`
+	// RequireApproval, misafir fotoğraflarının onaylanmadan galeride gösterilmemesidir.
+	RequireApproval bool `gorm:"not null;default:true"`
+	// QuotaBytes, albümdeki orijinal dosyaların toplamı için ayrılan depolama alanıdır;
+	// UsedBytes fotoğraf eklendikçe ve silindikçe güncellenir.
+	QuotaBytes int64 `gorm:"not null"`
+	UsedBytes  int64 `gorm:"not null;default:0"`
+
+	// Opsiyonel Alanlar
+	Title         string `gorm:"type:varchar(255)"`
+	UploadPINHash string `gorm:"type:varchar(255)"`
+}
+
+func (InvitationAlbum) TableName() string {
+	return "invitation_albums"
+}
+
+// HasPIN, albüm için misafir yükleme PIN'i belirlenip belirlenmediğini bildirir.
+func (a InvitationAlbum) HasPIN() bool {
+	return a.UploadPINHash != ""
+}
+
+// UsagePercent, kotanın yüzde kaçının kullanıldığıdır (0-100).
+func (a InvitationAlbum) UsagePercent() int {
+	if a.QuotaBytes <= 0 {
+		return 100
+	}
+	return int(min(a.UsedBytes*100/a.QuotaBytes, 100))
+}
+
+// QuotaMB, kotanın megabayt cinsinden değeridir.
+func (a InvitationAlbum) QuotaMB() int64 {
+	return a.QuotaBytes / (1024 * 1024)
+}
+
+// RemainingBytes, kotada kalan alandır.
+func (a InvitationAlbum) RemainingBytes() int64 {
+	return max(a.QuotaBytes-a.UsedBytes, 0)
+}
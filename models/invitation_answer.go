@@ -0,0 +1,52 @@
+package models
+
+import "strconv"
+
+// InvitationAnswer, bir katılımcının özel soruya verdiği yanıttır. Yanıt, sorunun
+// tipine göre yalnızca ilgili sütunda tutulur; böylece özetler SQL ile hesaplanabilir.
+type InvitationAnswer struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	ParticipantID uint `gorm:"not null;uniqueIndex:idx_invitation_answers_participant_question"`
+	QuestionID    uint `gorm:"not null;index;uniqueIndex:idx_invitation_answers_participant_question"`
+
+	// Tipli Değerler
+	TextValue   string `gorm:"type:text"`
+	ChoiceValue string `gorm:"type:varchar(255);index"`
+	BoolValue   *bool
+	NumberValue *float64 `gorm:"type:numeric(12,2)"`
+
+	// İlişki Tanımları
+	Participant InvitationParticipant `gorm:"foreignKey:ParticipantID"`
+	Question    InvitationQuestion    `gorm:"foreignKey:QuestionID"`
+}
+
+func (InvitationAnswer) TableName() string {
+	return "invitation_answers"
+}
+
+// FormValue, yanıtı katılım formunda yeniden gösterilecek ham değere çevirir.
+func (a InvitationAnswer) FormValue() string {
+	switch {
+	case a.ChoiceValue != "":
+		return a.ChoiceValue
+	case a.BoolValue != nil:
+		return strconv.FormatBool(*a.BoolValue)
+	case a.NumberValue != nil:
+		return strconv.FormatFloat(*a.NumberValue, 'f', -1, 64)
+	default:
+		return a.TextValue
+	}
+}
+
+// DisplayValue, yanıtı liste ve dışa aktarma ekranları için okunur hale getirir.
+func (a InvitationAnswer) DisplayValue() string {
+	if a.BoolValue != nil {
+		if *a.BoolValue {
+			return "Evet"
+		}
+		return "Hayır"
+	}
+	return a.FormValue()
+}
@@ -0,0 +1,22 @@
+package models
+
+// InvitationBank, davetiye sayfasında para ve takı hediyesi için gösterilen banka hesabıdır.
+type InvitationBank struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint   `gorm:"index;not null"`
+	BankID       uint   `gorm:"index;not null"`
+	IBAN         string `gorm:"size:50;not null"`
+
+	// Opsiyonel Alanlar
+	// AccountHolder, hesabın sahibidir; gönderen misafirin alıcı adını doğru yazabilmesi içindir.
+	AccountHolder string `gorm:"type:varchar(100)"`
+
+	// İlişki Tanımı
+	Bank Bank `gorm:"foreignKey:BankID"`
+}
+
+func (InvitationBank) TableName() string {
+	return "invitation_banks"
+}
@@ -1,5 +1,7 @@
 package models
 
+import "davet.link/pkg/detailfields"
+
 type InvitationCategory struct {
 	BaseModel
 	
@@ -8,6 +10,10 @@ type InvitationCategory struct {
 	Template string `gorm:"type:varchar(255);not null"`
 	Name     string `gorm:"type:varchar(255);not null;index"`
 	Icon     string `gorm:"type:varchar(50);not null"`
+
+	// FieldSchema, bu kategorideki davetiyelerin detay alanlarıdır. Boşsa Template türünün
+	// varsayılan alanları kullanılır.
+	FieldSchema detailfields.Schema `gorm:"type:jsonb"`
 	
 	// İlişki Tanımı
 	Invitations []Invitation `gorm:"foreignKey:CategoryID"`
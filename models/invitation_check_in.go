@@ -0,0 +1,21 @@
+package models
+
+// InvitationCheckIn, kapıda yapılan her girişin kaydıdır. Bir bilet birden fazla kişiyi
+// kapsadığından aynı katılımcı için gruplar halinde birden fazla kayıt oluşabilir.
+type InvitationCheckIn struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID  uint `gorm:"index;not null"`
+	ParticipantID uint `gorm:"index;not null"`
+	Count         int  `gorm:"not null"`
+	CheckedInByID uint `gorm:"not null"`
+
+	// İlişki Tanımları
+	Participant *InvitationParticipant `gorm:"foreignKey:ParticipantID"`
+	CheckedInBy *User                  `gorm:"foreignKey:CheckedInByID"`
+}
+
+func (InvitationCheckIn) TableName() string {
+	return "invitation_check_ins"
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+type InvitationRole string
+
+const (
+	// InvitationRoleOwner, davetiyenin sahibidir; ortak ev sahibi kaydı olarak saklanmaz.
+	InvitationRoleOwner        InvitationRole = "owner"
+	InvitationRoleEditor       InvitationRole = "editor"
+	InvitationRoleGuestManager InvitationRole = "guest_manager"
+	InvitationRoleViewer       InvitationRole = "viewer"
+)
+
+// CoHostRoles, ortak ev sahiplerine verilebilecek rollerdir.
+var CoHostRoles = []InvitationRole{InvitationRoleEditor, InvitationRoleGuestManager, InvitationRoleViewer}
+
+func (r InvitationRole) Label() string {
+	switch r {
+	case InvitationRoleOwner:
+		return "Sahip"
+	case InvitationRoleEditor:
+		return "Düzenleyici"
+	case InvitationRoleGuestManager:
+		return "Misafir Listesi Yöneticisi"
+	case InvitationRoleViewer:
+		return "Görüntüleyici"
+	default:
+		return string(r)
+	}
+}
+
+// InvitationPermission, panelde davetiye üzerinde yapılabilecek işlem grubudur.
+type InvitationPermission string
+
+const (
+	// InvitationPermissionView, davetiyeyi, katılımcıları ve istatistikleri görmektir.
+	InvitationPermissionView InvitationPermission = "view"
+	// InvitationPermissionManageGuests, misafir ve katılımcı listesini düzenlemek ve
+	// misafirlere mesaj göndermektir.
+	InvitationPermissionManageGuests InvitationPermission = "manage_guests"
+	// InvitationPermissionEdit, davetiyeyi, programını ve sorularını düzenlemektir.
+	InvitationPermissionEdit InvitationPermission = "edit"
+	// InvitationPermissionManage, davetiyeyi silmek ve ortak ev sahiplerini yönetmektir;
+	// yalnızca sahibe açıktır.
+	InvitationPermissionManage InvitationPermission = "manage"
+)
+
+// Can, rolün verilen işlem grubuna yetkisi olup olmadığını döner.
+func (r InvitationRole) Can(permission InvitationPermission) bool {
+	switch r {
+	case InvitationRoleOwner:
+		return true
+	case InvitationRoleEditor:
+		return permission != InvitationPermissionManage
+	case InvitationRoleGuestManager:
+		return permission == InvitationPermissionView || permission == InvitationPermissionManageGuests
+	case InvitationRoleViewer:
+		return permission == InvitationPermissionView
+	default:
+		return false
+	}
+}
+
+// InvitationCoHost, davetiye sahibinin e-posta ile davet ettiği ortak ev sahibidir. Davet,
+// bağlantıdaki jetonla kabul edilene kadar bekler; kabul eden kullanıcı UserID'ye yazılır.
+type InvitationCoHost struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint           `gorm:"not null;uniqueIndex:idx_invitation_co_hosts_invitation_email,priority:1"`
+	Email        string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_invitation_co_hosts_invitation_email,priority:2"`
+	Role         InvitationRole `gorm:"type:varchar(20);not null;default:'viewer'"`
+	Token        string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	InvitedByID  uint           `gorm:"not null"`
+
+	// Opsiyonel Alanlar
+	UserID     *uint `gorm:"index"`
+	AcceptedAt *time.Time
+
+	// İlişki Tanımları
+	Invitation *Invitation `gorm:"foreignKey:InvitationID"`
+	User       *User       `gorm:"foreignKey:UserID"`
+	InvitedBy  *User       `gorm:"foreignKey:InvitedByID"`
+}
+
+func (h *InvitationCoHost) IsAccepted() bool {
+	return h.AcceptedAt != nil
+}
+
+func (InvitationCoHost) TableName() string {
+	return "invitation_co_hosts"
+}
@@ -0,0 +1,48 @@
+package models
+
+type CoHostAction string
+
+const (
+	CoHostActionInvited     CoHostAction = "invited"
+	CoHostActionAccepted    CoHostAction = "accepted"
+	CoHostActionRoleChanged CoHostAction = "role_changed"
+	CoHostActionRemoved     CoHostAction = "removed"
+)
+
+func (a CoHostAction) Label() string {
+	switch a {
+	case CoHostActionInvited:
+		return "Davet edildi"
+	case CoHostActionAccepted:
+		return "Daveti kabul etti"
+	case CoHostActionRoleChanged:
+		return "Rolü değiştirildi"
+	case CoHostActionRemoved:
+		return "Çıkarıldı"
+	default:
+		return string(a)
+	}
+}
+
+// InvitationCoHostLog, ortak ev sahipleriyle ilgili her değişikliğin kaydıdır. Ortak ev
+// sahibi silinse de kayıt e-posta adresiyle birlikte korunur.
+type InvitationCoHostLog struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint         `gorm:"index;not null"`
+	ActorID      uint         `gorm:"not null"`
+	Email        string       `gorm:"type:varchar(255);not null"`
+	Action       CoHostAction `gorm:"type:varchar(20);not null"`
+
+	// Opsiyonel Alanlar
+	Role         InvitationRole `gorm:"type:varchar(20)"`
+	PreviousRole InvitationRole `gorm:"type:varchar(20)"`
+
+	// İlişki Tanımları
+	Actor *User `gorm:"foreignKey:ActorID"`
+}
+
+func (InvitationCoHostLog) TableName() string {
+	return "invitation_co_host_logs"
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// InvitationEvent, tek bir davetiye altındaki programın bir bölümüdür (ör. kına, nikah, düğün).
+// Program, başlangıç zamanına göre sıralanır.
+type InvitationEvent struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint      `gorm:"index;not null"`
+	Title        string    `gorm:"type:varchar(255);not null"`
+	StartsAt     time.Time `gorm:"not null;index"`
+
+	// Opsiyonel Alanlar
+	EndsAt *time.Time
+	Venue  Venue `gorm:"embedded;embeddedPrefix:venue_"`
+	// IsRSVP, misafirlerin bu bölüme ayrıca katılıp katılmayacaklarını bildirmesini sağlar.
+	IsRSVP bool `gorm:"not null;default:false"`
+
+	// İlişki Tanımı
+	Invitation Invitation `gorm:"foreignKey:InvitationID"`
+}
+
+func (InvitationEvent) TableName() string {
+	return "invitation_events"
+}
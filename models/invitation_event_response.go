@@ -0,0 +1,20 @@
+package models
+
+// InvitationEventResponse, bir katılımcının katılım bildirimi açık olan program bölümüne
+// katılıp katılmayacağını tutar.
+type InvitationEventResponse struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	ParticipantID uint `gorm:"not null;uniqueIndex:idx_invitation_event_responses_participant_event"`
+	EventID       uint `gorm:"not null;index;uniqueIndex:idx_invitation_event_responses_participant_event"`
+	IsAttending   bool `gorm:"not null;default:false"`
+
+	// İlişki Tanımları
+	Participant InvitationParticipant `gorm:"foreignKey:ParticipantID"`
+	Event       InvitationEvent       `gorm:"foreignKey:EventID"`
+}
+
+func (InvitationEventResponse) TableName() string {
+	return "invitation_event_responses"
+}
@@ -0,0 +1,35 @@
+package models
+
+// InvitationGiftItem, davetiyenin hediye listesindeki bir üründür. ReservedCount, ürün için
+// yapılan ayırmaların toplamıdır ve ayırmayla aynı işlemde güncellenir.
+type InvitationGiftItem struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID  uint   `gorm:"index;not null"`
+	Name          string `gorm:"type:varchar(150);not null"`
+	Quantity      int    `gorm:"not null;default:1"`
+	ReservedCount int    `gorm:"not null;default:0"`
+
+	// Opsiyonel Alanlar
+	Description string `gorm:"type:varchar(500)"`
+	// URL, ürünün satın alınabileceği mağaza bağlantısıdır.
+	URL string `gorm:"type:varchar(500)"`
+
+	// İlişki Tanımı
+	Reservations []InvitationGiftReservation `gorm:"foreignKey:GiftItemID"`
+}
+
+func (InvitationGiftItem) TableName() string {
+	return "invitation_gift_items"
+}
+
+// Remaining, üründen henüz ayrılmamış adettir.
+func (i InvitationGiftItem) Remaining() int {
+	return max(i.Quantity-i.ReservedCount, 0)
+}
+
+// IsReserved, ürünün tüm adetlerinin ayrıldığını bildirir.
+func (i InvitationGiftItem) IsReserved() bool {
+	return i.Remaining() == 0
+}
@@ -0,0 +1,26 @@
+package models
+
+// InvitationGiftReservation, bir misafirin hediye listesindeki ürünü alacağını bildirmesidir.
+// Ayırmayı yapan diğer misafirlere gösterilmez; yalnızca davetiye sahibi görür.
+type InvitationGiftReservation struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	GiftItemID   uint `gorm:"index;not null"`
+	InvitationID uint `gorm:"index;not null"`
+	// Token, ayırmayı yapan ziyaretçinin oturumunda tutulur ve ayırmayı geri almasını sağlar.
+	Token string `gorm:"type:varchar(64);uniqueIndex;not null"`
+
+	// Opsiyonel Alanlar
+	Name string `gorm:"type:varchar(100)"`
+	Note string `gorm:"type:varchar(255)"`
+	// GuestID, ayırma kişiye özel bağlantıdan yapıldıysa misafirin kaydıdır.
+	GuestID *uint `gorm:"index"`
+
+	// İlişki Tanımı
+	Guest *InvitationGuest `gorm:"foreignKey:GuestID"`
+}
+
+func (InvitationGiftReservation) TableName() string {
+	return "invitation_gift_reservations"
+}
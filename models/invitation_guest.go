@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type InvitationGuestStatus string
+
+const (
+	GuestStatusInvited    InvitationGuestStatus = "invited"
+	GuestStatusOpened     InvitationGuestStatus = "opened"
+	GuestStatusAccepted   InvitationGuestStatus = "accepted"
+	GuestStatusDeclined   InvitationGuestStatus = "declined"
+	GuestStatusMaybe      InvitationGuestStatus = "maybe"
+	GuestStatusWaitlisted InvitationGuestStatus = "waitlisted"
+)
+
+// InvitationGuest, davetiye sahibinin önceden kaydettiği ve kişiye özel bağlantı
+// ile davet ettiği misafiri (genellikle bir haneyi) temsil eder.
+type InvitationGuest struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint                  `gorm:"index;not null"`
+	Title        string                `gorm:"type:varchar(255);not null"`
+	SeatLimit    int                   `gorm:"not null;default:1"`
+	Token        string                `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Status       InvitationGuestStatus `gorm:"type:varchar(20);not null;default:'invited';index"`
+
+	// Opsiyonel Alanlar
+	PhoneNumber   string `gorm:"type:varchar(20)"`
+	OpenedAt      *time.Time
+	RespondedAt   *time.Time
+	ParticipantID *uint `gorm:"index"`
+
+	// İlişki Tanımları
+	Invitation  Invitation             `gorm:"foreignKey:InvitationID"`
+	Participant *InvitationParticipant `gorm:"foreignKey:ParticipantID"`
+}
+
+func (InvitationGuest) TableName() string {
+	return "invitation_guests"
+}
@@ -0,0 +1,33 @@
+package models
+
+type GuestbookEntryStatus string
+
+const (
+	GuestbookEntryPending  GuestbookEntryStatus = "pending"
+	GuestbookEntryApproved GuestbookEntryStatus = "approved"
+	GuestbookEntryHidden   GuestbookEntryStatus = "hidden"
+)
+
+// InvitationGuestbookEntry, davetiye sayfasındaki anı defterine bırakılan bir iyi dilek
+// mesajıdır. Mesajlar davetiye sahibi onaylayana kadar sayfada gösterilmez.
+type InvitationGuestbookEntry struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint                 `gorm:"index;not null"`
+	Name         string               `gorm:"type:varchar(100);not null"`
+	Message      string               `gorm:"type:text;not null"`
+	Status       GuestbookEntryStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+
+	// Opsiyonel Alanlar
+	Photo string `gorm:"type:varchar(255)"`
+	// GuestID, mesaj kişiye özel bağlantıdan bırakıldıysa misafirin kaydıdır.
+	GuestID *uint `gorm:"index"`
+
+	// İlişki Tanımı
+	Guest *InvitationGuest `gorm:"foreignKey:GuestID"`
+}
+
+func (InvitationGuestbookEntry) TableName() string {
+	return "invitation_guestbook_entries"
+}
@@ -1,18 +1,69 @@
 package models
 
+import "time"
+
+type RSVPStatus string
+
+const (
+	RSVPAttending    RSVPStatus = "attending"
+	RSVPNotAttending RSVPStatus = "not_attending"
+	RSVPMaybe        RSVPStatus = "maybe"
+	RSVPWaitlisted   RSVPStatus = "waitlisted"
+)
+
+// Label, katılım durumunun ekranlarda gösterilen Türkçe karşılığıdır.
+func (s RSVPStatus) Label() string {
+	switch s {
+	case RSVPNotAttending:
+		return "Katılmayacak"
+	case RSVPMaybe:
+		return "Belki"
+	case RSVPWaitlisted:
+		return "Yedek Listede"
+	default:
+		return "Katılacak"
+	}
+}
+
 type InvitationParticipant struct {
 	BaseModel
 	
 	// Zorunlu Alanlar
-	Title        string `gorm:"type:varchar(255);not null"`
-	PhoneNumber  string `gorm:"type:varchar(20);not null"`
-	GuestCount   int    `gorm:"not null;default:1"`
-	InvitationID uint   `gorm:"index;not null"`
+	Title        string     `gorm:"type:varchar(255);not null"`
+	PhoneNumber  string     `gorm:"type:varchar(20);not null"`
+	GuestCount   int        `gorm:"not null;default:1"`
+	InvitationID uint       `gorm:"index;not null"`
+	Status       RSVPStatus `gorm:"type:varchar(20);not null;default:'attending';index"`
+	
+	// Opsiyonel Alanlar
+	Message      string `gorm:"type:text"`
+	WaitlistedAt *time.Time
+	// EditToken, genel bağlantıdan yanıt veren misafirin oturumunda saklanır; misafir yanıtını
+	// bu anahtarla değiştirebilir veya iptal edebilir.
+	EditToken *string `gorm:"type:varchar(64);uniqueIndex"`
+
+	// Kapıda Giriş
+	CheckedInCount int `gorm:"not null;default:0"`
+	CheckedInAt    *time.Time
+
+	// Oturma Planı
+	TableID *uint `gorm:"index"`
 	
 	// İlişki Tanımı
-	Invitation Invitation `gorm:"foreignKey:InvitationID"`
+	Invitation     Invitation                `gorm:"foreignKey:InvitationID"`
+	Answers        []InvitationAnswer        `gorm:"foreignKey:ParticipantID"`
+	EventResponses []InvitationEventResponse `gorm:"foreignKey:ParticipantID"`
+	Table          *InvitationTable          `gorm:"foreignKey:TableID"`
 }
 
 func (InvitationParticipant) TableName() string {
 	return "invitation_participants"
-}
\ No newline at end of file
+}
+
+// RemainingCheckIns, katılımcının biletiyle kapıdan henüz girmemiş kişi sayısıdır.
+func (p InvitationParticipant) RemainingCheckIns() int {
+	if remaining := p.GuestCount - p.CheckedInCount; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
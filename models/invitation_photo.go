@@ -0,0 +1,37 @@
+package models
+
+type PhotoStatus string
+
+const (
+	PhotoPending  PhotoStatus = "pending"
+	PhotoApproved PhotoStatus = "approved"
+	PhotoHidden   PhotoStatus = "hidden"
+)
+
+// InvitationPhoto, davetiye albümündeki bir fotoğraftır. Dosya yüklendiği haliyle saklanır;
+// toplu indirmede orijinal dosya kullanılır.
+type InvitationPhoto struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	AlbumID      uint        `gorm:"index;not null"`
+	InvitationID uint        `gorm:"index;not null"`
+	FileName     string      `gorm:"type:varchar(255);not null"`
+	Size         int64       `gorm:"not null"`
+	Status       PhotoStatus `gorm:"type:varchar(20);not null;default:'approved';index"`
+	// ByGuest, fotoğrafın davetiye sahibi yerine bir misafir tarafından yüklendiğini belirtir.
+	ByGuest bool `gorm:"not null;default:false"`
+
+	// Opsiyonel Alanlar
+	OriginalName string `gorm:"type:varchar(255)"`
+	UploaderName string `gorm:"type:varchar(100)"`
+	// GuestID, fotoğraf kişiye özel bağlantıdan yüklendiyse misafirin kaydıdır.
+	GuestID *uint `gorm:"index"`
+
+	// İlişki Tanımı
+	Guest *InvitationGuest `gorm:"foreignKey:GuestID"`
+}
+
+func (InvitationPhoto) TableName() string {
+	return "invitation_photos"
+}
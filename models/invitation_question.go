@@ -0,0 +1,47 @@
+package models
+
+import "strings"
+
+type QuestionType string
+
+const (
+	QuestionTypeText    QuestionType = "text"
+	QuestionTypeChoice  QuestionType = "choice"
+	QuestionTypeBoolean QuestionType = "boolean"
+	QuestionTypeNumber  QuestionType = "number"
+)
+
+// InvitationQuestion, davetiye sahibinin katılım formuna eklediği özel sorudur
+// (ör. menü tercihi, servis ihtiyacı).
+type InvitationQuestion struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint         `gorm:"index;not null"`
+	Label        string       `gorm:"type:varchar(255);not null"`
+	Type         QuestionType `gorm:"type:varchar(20);not null"`
+	IsRequired   bool         `gorm:"not null;default:false"`
+	SortOrder    int          `gorm:"not null;default:0"`
+
+	// Opsiyonel Alanlar
+	// Options, seçmeli soruların seçeneklerini satır satır tutar.
+	Options string `gorm:"type:text"`
+
+	// İlişki Tanımı
+	Invitation Invitation `gorm:"foreignKey:InvitationID"`
+}
+
+func (InvitationQuestion) TableName() string {
+	return "invitation_questions"
+}
+
+// OptionList, seçmeli sorunun boş olmayan seçeneklerini döner.
+func (q InvitationQuestion) OptionList() []string {
+	var options []string
+	for _, line := range strings.Split(q.Options, "\n") {
+		if option := strings.TrimSpace(line); option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
@@ -0,0 +1,37 @@
+package models
+
+// InvitationTable, davetiyenin oturma planındaki bir masadır. Katılımcılar kişi sayılarıyla
+// (GuestCount) birlikte masanın kapasitesinden düşülür.
+type InvitationTable struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	InvitationID uint   `gorm:"index;not null"`
+	Name         string `gorm:"type:varchar(100);not null"`
+	Capacity     int    `gorm:"not null"`
+
+	// İlişki Tanımı
+	Participants []InvitationParticipant `gorm:"foreignKey:TableID"`
+}
+
+// SeatedGuests, masaya yerleştirilmiş ve katılımı onaylı katılımcıların toplam kişi sayısıdır.
+// Participants yüklenmemişse 0 döner.
+func (t InvitationTable) SeatedGuests() int {
+	total := 0
+	for _, participant := range t.Participants {
+		if participant.Status == RSVPAttending {
+			total += participant.GuestCount
+		}
+	}
+	return total
+}
+
+// FreeSeats, masada kalan boş koltuk sayısıdır; kişi sayısı sonradan artan katılımcılar
+// yüzünden masa dolup taşmışsa negatif olabilir.
+func (t InvitationTable) FreeSeats() int {
+	return t.Capacity - t.SeatedGuests()
+}
+
+func (InvitationTable) TableName() string {
+	return "invitation_tables"
+}
@@ -0,0 +1,19 @@
+package models
+
+// MessageBroadcast, davetiye sahibinin tüm katılımcılara gönderdiği duyurudur.
+// Her alıcı için ayrı bir OutboundMessage oluşturulur ve teslim durumu orada izlenir.
+type MessageBroadcast struct {
+	BaseModel
+
+	InvitationID   uint           `gorm:"index;not null"`
+	UserID         uint           `gorm:"index;not null"`
+	Channel        MessageChannel `gorm:"type:varchar(20);not null"`
+	Body           string         `gorm:"type:text;not null"`
+	RecipientCount int            `gorm:"not null;default:0"`
+
+	Messages []OutboundMessage `gorm:"foreignKey:BroadcastID"`
+}
+
+func (MessageBroadcast) TableName() string {
+	return "message_broadcasts"
+}
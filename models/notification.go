@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationRSVPCreated   NotificationType = "rsvp_created"
+	NotificationRSVPUpdated   NotificationType = "rsvp_updated"
+	NotificationRSVPCancelled NotificationType = "rsvp_cancelled"
+	NotificationRSVPPromoted  NotificationType = "rsvp_promoted"
+)
+
+// Notification, kullanıcının panelindeki bildirim merkezinde gösterilen ve
+// tercihine göre e-posta ile de iletilen tek bir olayı temsil eder.
+type Notification struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	UserID uint             `gorm:"index;not null"`
+	Type   NotificationType `gorm:"type:varchar(30);not null"`
+	Title  string           `gorm:"type:varchar(255);not null"`
+
+	// Opsiyonel Alanlar
+	Body          string `gorm:"type:text"`
+	Link          string `gorm:"type:varchar(255)"`
+	InvitationID  *uint  `gorm:"index"`
+	ParticipantID *uint
+	ReadAt        *time.Time `gorm:"index"`
+	EmailedAt     *time.Time
+
+	User       *User       `gorm:"foreignKey:UserID"`
+	Invitation *Invitation `gorm:"foreignKey:InvitationID"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
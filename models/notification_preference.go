@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type NotificationFrequency string
+
+const (
+	NotificationInstant     NotificationFrequency = "instant"
+	NotificationDailyDigest NotificationFrequency = "daily_digest"
+)
+
+// NotificationPreference, kullanıcının bildirimleri e-posta ile nasıl almak istediğini tutar.
+// Kaydı olmayan kullanıcılar için anlık e-posta varsayılır.
+type NotificationPreference struct {
+	BaseModel
+
+	UserID       uint                  `gorm:"uniqueIndex;not null"`
+	EmailEnabled bool                  `gorm:"not null;default:true"`
+	Frequency    NotificationFrequency `gorm:"type:varchar(20);not null;default:'instant'"`
+	DigestHour   int                   `gorm:"not null;default:9"`
+	LastDigestAt *time.Time
+
+	User *User `gorm:"foreignKey:UserID"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// DefaultNotificationPreference, kaydı olmayan kullanıcı için geçerli tercihleri döner.
+func DefaultNotificationPreference(userID uint) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:       userID,
+		EmailEnabled: true,
+		Frequency:    NotificationInstant,
+		DigestHour:   9,
+	}
+}
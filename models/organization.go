@@ -0,0 +1,21 @@
+package models
+
+// Organization, çalışanları için ortak marka, banka ve sosyal medya bilgileriyle kartvizit
+// oluşturabilen şirket veya ekiptir. Organizasyona bağlı kartlar bu bilgileri kendi
+// bilgilerine ek olarak gösterir.
+type Organization struct {
+	BaseModel
+
+	Name       string `gorm:"type:varchar(255);not null;index"`
+	Logo       string `gorm:"type:varchar(255)"`
+	BrandColor string `gorm:"type:varchar(7)"`
+	WebsiteUrl string `gorm:"type:varchar(255)"`
+
+	Members     []OrganizationMember      `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Banks       []OrganizationBank        `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	SocialMedia []OrganizationSocialMedia `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
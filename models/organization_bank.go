@@ -0,0 +1,14 @@
+package models
+
+// OrganizationBank, organizasyonun tüm üye kartlarında gösterilen banka hesabıdır.
+type OrganizationBank struct {
+	BaseModel
+	OrganizationID uint   `gorm:"index;not null"`
+	BankID         uint   `gorm:"index;not null"`
+	IBAN           string `gorm:"size:50;not null"`
+	Bank           Bank   `gorm:"foreignKey:BankID"`
+}
+
+func (OrganizationBank) TableName() string {
+	return "organization_banks"
+}
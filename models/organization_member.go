@@ -0,0 +1,40 @@
+package models
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+func (r OrganizationRole) Label() string {
+	switch r {
+	case OrganizationRoleAdmin:
+		return "Yönetici"
+	case OrganizationRoleMember:
+		return "Üye"
+	default:
+		return string(r)
+	}
+}
+
+// OrganizationMember, bir kullanıcının organizasyondaki üyeliğidir. Yöneticiler organizasyon
+// bilgilerini düzenleyebilir, üye ekleyip çıkarabilir ve üyeler adına kart oluşturabilir.
+type OrganizationMember struct {
+	BaseModel
+
+	OrganizationID uint             `gorm:"not null;uniqueIndex:idx_organization_members_org_user,priority:1"`
+	UserID         uint             `gorm:"not null;index;uniqueIndex:idx_organization_members_org_user,priority:2"`
+	Role           OrganizationRole `gorm:"type:varchar(20);not null;default:'member'"`
+
+	Organization *Organization `gorm:"foreignKey:OrganizationID"`
+	User         *User         `gorm:"foreignKey:UserID"`
+}
+
+func (m *OrganizationMember) IsAdmin() bool {
+	return m.Role == OrganizationRoleAdmin
+}
+
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
@@ -0,0 +1,14 @@
+package models
+
+// OrganizationSocialMedia, organizasyonun tüm üye kartlarında gösterilen sosyal medya hesabıdır.
+type OrganizationSocialMedia struct {
+	BaseModel
+	OrganizationID uint        `gorm:"index;not null"`
+	SocialMediaID  uint        `gorm:"index;not null"`
+	URL            string      `gorm:"size:255;not null"`
+	SocialMedia    SocialMedia `gorm:"foreignKey:SocialMediaID"`
+}
+
+func (OrganizationSocialMedia) TableName() string {
+	return "organization_social_media"
+}
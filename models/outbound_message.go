@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+type MessageChannel string
+
+const (
+	MessageChannelSMS      MessageChannel = "sms"
+	MessageChannelWhatsApp MessageChannel = "whatsapp"
+)
+
+func (c MessageChannel) Label() string {
+	if c == MessageChannelWhatsApp {
+		return "WhatsApp"
+	}
+	return "SMS"
+}
+
+type OutboundMessageStatus string
+
+const (
+	OutboundMessagePending OutboundMessageStatus = "pending"
+	OutboundMessageSending OutboundMessageStatus = "sending"
+	OutboundMessageSent    OutboundMessageStatus = "sent"
+	OutboundMessageFailed  OutboundMessageStatus = "failed"
+)
+
+func (s OutboundMessageStatus) Label() string {
+	switch s {
+	case OutboundMessageSent:
+		return "Gönderildi"
+	case OutboundMessageFailed:
+		return "Başarısız"
+	case OutboundMessageSending:
+		return "Gönderiliyor"
+	default:
+		return "Sırada"
+	}
+}
+
+const (
+	OutboundMessageKindReminder     = "reminder"
+	OutboundMessageKindAnnouncement = "announcement"
+	OutboundMessageKindTicket       = "ticket"
+)
+
+// OutboundMessage, misafirlere gönderilmek üzere kuyruğa alınan mesajı temsil eder.
+// DedupKey aynı mesajın (ör: aynı katılımcıya aynı hatırlatma) ikinci kez kuyruğa
+// alınmasını engeller.
+type OutboundMessage struct {
+	BaseModel
+
+	// Zorunlu Alanlar
+	Channel   MessageChannel        `gorm:"type:varchar(20);not null;default:'sms'"`
+	Recipient string                `gorm:"type:varchar(20);not null"`
+	Body      string                `gorm:"type:text;not null"`
+	Kind      string                `gorm:"type:varchar(30);not null;index"`
+	Status    OutboundMessageStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	DedupKey  string                `gorm:"type:varchar(150);uniqueIndex;not null"`
+
+	// Opsiyonel Alanlar
+	UserID            *uint `gorm:"index"`
+	InvitationID      *uint `gorm:"index"`
+	ParticipantID     *uint
+	BroadcastID       *uint  `gorm:"index"`
+	Attempts          int    `gorm:"not null;default:0"`
+	LastError         string `gorm:"type:text"`
+	Provider          string `gorm:"type:varchar(50)"`
+	ProviderMessageID string `gorm:"type:varchar(255)"`
+	SentAt            *time.Time
+
+	Participant *InvitationParticipant `gorm:"foreignKey:ParticipantID"`
+}
+
+func (OutboundMessage) TableName() string {
+	return "outbound_messages"
+}
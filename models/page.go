@@ -0,0 +1,17 @@
+package models
+
+// Page, yönetim panelinden düzenlenen içerik sayfalarıdır (kullanım şartları, KVKK vb.).
+// Gövde Markdown olarak saklanır ve website/page şablonuyla /sayfa/:slug adresinde sunulur.
+type Page struct {
+	BaseModel
+	Title           string `gorm:"size:255;not null"`
+	Slug            string `gorm:"size:100;not null;uniqueIndex"`
+	Body            string `gorm:"type:text"`
+	MetaTitle       string `gorm:"size:255"`
+	MetaDescription string `gorm:"size:500"`
+	IsPublished     bool   `gorm:"not null;default:false;index"`
+}
+
+func (Page) TableName() string {
+	return "pages"
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type PageViewSubject string
+
+const (
+	PageViewInvitation PageViewSubject = "invitation"
+	PageViewCard       PageViewSubject = "card"
+)
+
+// PageView, herkese açık bir davetiye veya kartvizit sayfasının tek bir görüntülenmesidir.
+// Ziyaretçi yalnızca günlük tuzla özetlenmiş IP+User-Agent ile temsil edilir; IP adresi,
+// tam User-Agent veya tam yönlendiren adres saklanmaz.
+type PageView struct {
+	BaseModel
+
+	SubjectType    PageViewSubject `gorm:"type:varchar(20);not null;index:idx_page_views_subject_day,priority:1"`
+	SubjectID      uint            `gorm:"not null;index:idx_page_views_subject_day,priority:2"`
+	Day            time.Time       `gorm:"type:date;not null;index:idx_page_views_subject_day,priority:3"`
+	VisitorHash    string          `gorm:"type:char(64);not null"`
+	ReferrerDomain string          `gorm:"type:varchar(255)"`
+	DeviceClass    string          `gorm:"type:varchar(20);not null"`
+}
+
+func (PageView) TableName() string {
+	return "page_views"
+}
@@ -0,0 +1,13 @@
+package models
+
+// Province, Türkiye'nin illeridir; Code plaka kodudur. Veriler seeder ile yüklenir.
+type Province struct {
+	BaseModel
+	Code      uint       `gorm:"not null;uniqueIndex"`
+	Name      string     `gorm:"size:100;not null"`
+	Districts []District `gorm:"foreignKey:ProvinceID"`
+}
+
+func (Province) TableName() string {
+	return "provinces"
+}
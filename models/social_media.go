@@ -5,6 +5,11 @@ type SocialMedia struct {
 	IsActive bool   `gorm:"default:true;index"`
 	Icon     string `gorm:"size:50;not null"`  // Font Awesome icon class name
 	Name     string `gorm:"size:255;not null;index"`
+	// BaseURL, kullanıcı adının eklendiği profil adresi önekidir (örn. https://www.instagram.com/).
+	// Boşsa platform için yalnızca genel bağlantı doğrulaması yapılır.
+	BaseURL string `gorm:"size:255"`
+	// HandlePattern, kullanıcı adının uyması gereken düzenli ifadedir.
+	HandlePattern string `gorm:"size:255"`
 }
 
 // TableName returns the table name for the SocialMedia model
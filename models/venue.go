@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strings"
+
+	"davet.link/pkg/maplink"
+)
+
+// Venue, davetiye, program bölümü ve kartvizitlerde kullanılan yapılandırılmış mekân
+// bilgisidir; tablolara "venue_" önekli sütunlar olarak gömülür.
+type Venue struct {
+	Name         string `gorm:"type:varchar(255)"`
+	AddressLine1 string `gorm:"type:varchar(255)"`
+	AddressLine2 string `gorm:"type:varchar(255)"`
+	ProvinceID   *uint
+	DistrictID   *uint
+	// Province ve District, seçilen il ve ilçenin adlarıdır; her gösterimde tabloya gitmemek
+	// için kayıtla birlikte saklanır.
+	Province string `gorm:"type:varchar(100)"`
+	District string `gorm:"type:varchar(100)"`
+	// Latitude ve Longitude, MapURL'den çıkarılan koordinatlardır; bulunamazsa boş kalır.
+	Latitude  *float64 `gorm:"type:numeric(9,6)"`
+	Longitude *float64 `gorm:"type:numeric(9,6)"`
+	// MapURL, sahibinin yapıştırdığı Google Maps, Apple Maps ya da Yandex bağlantısıdır.
+	MapURL string `gorm:"type:varchar(1000)"`
+}
+
+// IsZero, mekâna ait hiçbir bilgi girilmemişse true döner.
+func (v Venue) IsZero() bool {
+	return v.Name == "" && v.AddressLine1 == "" && v.AddressLine2 == "" && v.Province == "" &&
+		v.District == "" && v.MapURL == "" && v.Coordinates() == nil
+}
+
+// Coordinates, koordinat biliniyorsa döner; aksi halde nil döner.
+func (v Venue) Coordinates() *maplink.Coordinates {
+	if v.Latitude == nil || v.Longitude == nil {
+		return nil
+	}
+	return &maplink.Coordinates{Latitude: *v.Latitude, Longitude: *v.Longitude}
+}
+
+// Locality, "İlçe / İl" biçimindeki yer adıdır.
+func (v Venue) Locality() string {
+	return strings.Join(nonEmptyStrings(v.District, v.Province), " / ")
+}
+
+// AddressText, adres satırları ile il ve ilçeyi tek satırda birleştirir.
+func (v Venue) AddressText() string {
+	return strings.Join(nonEmptyStrings(v.AddressLine1, v.AddressLine2, v.Locality()), ", ")
+}
+
+// Label, mekânın takvim ve mesajlarda kullanılan adıdır: ad ve adres, ikisi de yoksa bağlantı.
+func (v Venue) Label() string {
+	if label := strings.Join(nonEmptyStrings(v.Name, v.AddressText()), ", "); label != "" {
+		return label
+	}
+	return v.MapURL
+}
+
+// ShareURL, mesaj ve takvimlerde paylaşılan tek harita bağlantısıdır: koordinat biliniyorsa
+// Google Maps bağlantısı, aksi halde yapıştırılan bağlantı döner.
+func (v Venue) ShareURL() string {
+	if v.Coordinates() != nil {
+		return string(v.MapLinks().Google)
+	}
+	return v.MapURL
+}
+
+// MapLinks, mekânı Google Maps, Apple Maps ve Yandex Navigasyon'da açan bağlantılardır.
+// Koordinat yoksa ad ve adresle arama yapılır.
+func (v Venue) MapLinks() maplink.Links {
+	return maplink.Build(v.Name, v.AddressText(), v.Coordinates())
+}
+
+func nonEmptyStrings(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
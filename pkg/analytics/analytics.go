@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+	DeviceBot     = "bot"
+)
+
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "whatsapp", "telegrambot", "preview", "curl", "wget", "python-requests", "headless"}
+
+// DeviceClass, User-Agent başlığından kaba cihaz sınıfını belirler. Ayrıntılı tarayıcı veya
+// işletim sistemi bilgisi bilinçli olarak saklanmaz.
+func DeviceClass(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return DeviceBot
+	}
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return DeviceBot
+		}
+	}
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return DeviceTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "android"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// ReferrerDomain, Referer başlığından yalnızca alan adını döner. Sitenin kendi alan adı ve
+// geçersiz değerler için boş döner; tam adres (yol, sorgu) saklanmaz.
+func ReferrerDomain(referer string, ownHost string) string {
+	if referer == "" {
+		return ""
+	}
+	u, err := url.Parse(referer)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	own := strings.TrimPrefix(strings.ToLower(ownHost), "www.")
+	if i := strings.IndexByte(own, ':'); i >= 0 {
+		own = own[:i]
+	}
+	if host == own {
+		return ""
+	}
+	return host
+}
+
+// VisitorHash, IP ve User-Agent'ı günlük tuzla birlikte özetler. Tuz her gün değiştiği ve
+// eski tuzlar silindiği için özetten ziyaretçiye veya günler arası eşleştirmeye ulaşılamaz.
+func VisitorHash(dailySalt, ip, userAgent string) string {
+	sum := sha256.Sum256([]byte(dailySalt + "|" + ip + "|" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Buffer, kayıtları bellekte biriktirip boyut veya süre dolduğunda toplu olarak yazar.
+// Add engellemez; tampon doluysa kayıt düşürülür ve false döner.
+type Buffer[T any] struct {
+	items    chan T
+	size     int
+	interval time.Duration
+	flush    func([]T)
+	done     chan struct{}
+	once     sync.Once
+}
+
+func NewBuffer[T any](capacity int, batchSize int, interval time.Duration, flush func([]T)) *Buffer[T] {
+	return &Buffer[T]{
+		items:    make(chan T, capacity),
+		size:     batchSize,
+		interval: interval,
+		flush:    flush,
+		done:     make(chan struct{}),
+	}
+}
+
+func (b *Buffer[T]) Add(item T) bool {
+	select {
+	case b.items <- item:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run, ctx iptal edilene kadar kayıtları toplar ve yazar; çıkmadan önce kalanları da yazar.
+func (b *Buffer[T]) Run(ctx context.Context) {
+	defer b.once.Do(func() { close(b.done) })
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	batch := make([]T, 0, b.size)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		b.flush(batch)
+		batch = make([]T, 0, b.size)
+	}
+
+	for {
+		select {
+		case item := <-b.items:
+			batch = append(batch, item)
+			if len(batch) >= b.size {
+				write()
+			}
+		case <-ticker.C:
+			write()
+		case <-ctx.Done():
+			for {
+				select {
+				case item := <-b.items:
+					batch = append(batch, item)
+				default:
+					write()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Done, Run son yazımı tamamlayıp çıktığında kapanır.
+func (b *Buffer[T]) Done() <-chan struct{} {
+	return b.done
+}
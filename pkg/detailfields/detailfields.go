@@ -0,0 +1,517 @@
+package detailfields
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldType, davetiye detayındaki bir alanın türüdür; formda nasıl girileceğini, nasıl
+// saklanacağını ve davetiye sayfasında nasıl gösterileceğini belirler.
+type FieldType string
+
+const (
+	// TypeText, tek satırlık serbest metindir (ör: başlık, okul adı).
+	TypeText FieldType = "text"
+	// TypeDate, YYYY-AA-GG biçiminde saklanan tarihtir.
+	TypeDate FieldType = "date"
+	// TypePerson, ad ve soyaddan oluşan kişidir.
+	TypePerson FieldType = "person"
+	// TypeDeceased, For alanında belirtilen kişinin merhum olduğunu işaretler.
+	TypeDeceased FieldType = "deceased"
+	// TypeList, her satırı ayrı bir madde olan listedir (ör: konuşmacılar, sponsorlar).
+	TypeList FieldType = "list"
+)
+
+// DateLayout, tarih alanlarının formda ve veritabanında kullanılan biçimidir.
+const DateLayout = "2006-01-02"
+
+const (
+	maxFields     = 50
+	maxTextLength = 500
+	maxNameLength = 100
+	maxListItems  = 50
+	maxItemLength = 255
+)
+
+// TypeInfo, kategori formunda seçilebilen alan türüdür.
+type TypeInfo struct {
+	Type  FieldType
+	Label string
+}
+
+var types = []TypeInfo{
+	{Type: TypeText, Label: "Metin"},
+	{Type: TypeDate, Label: "Tarih"},
+	{Type: TypePerson, Label: "Kişi (Ad Soyad)"},
+	{Type: TypeDeceased, Label: "Merhum İşareti"},
+	{Type: TypeList, Label: "Liste"},
+}
+
+// Types, desteklenen alan türlerini döner.
+func Types() []TypeInfo {
+	return types
+}
+
+func knownType(t FieldType) bool {
+	for _, info := range types {
+		if info.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Field, kategori şemasındaki bir detay alanıdır. Key formda fields[<key>] adıyla gönderilir
+// ve değerler bu anahtarla saklanır.
+type Field struct {
+	Key      string    `json:"key"`
+	Label    string    `json:"label"`
+	Type     FieldType `json:"type"`
+	Required bool      `json:"required,omitempty"`
+	// For, merhum işaretinin ait olduğu kişi alanının anahtarıdır; yalnızca TypeDeceased
+	// alanlarında kullanılır.
+	For string `json:"for,omitempty"`
+}
+
+// Schema, bir davetiye kategorisinin detay alanlarıdır. Veritabanında JSONB olarak saklanır.
+type Schema []Field
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Validate, şemanın tutarlı olduğunu denetler: anahtarlar benzersiz ve küçük harfli,
+// türler tanımlı, merhum işaretleri de şemadaki bir kişi alanına bağlı olmalıdır.
+func (s Schema) Validate() error {
+	if len(s) > maxFields {
+		return fmt.Errorf("en fazla %d alan tanımlanabilir", maxFields)
+	}
+	seen := map[string]bool{}
+	for i, field := range s {
+		position := i + 1
+		if !keyPattern.MatchString(field.Key) {
+			return fmt.Errorf("%d. alanın anahtarı geçersiz: küçük harfle başlamalı, yalnızca küçük harf, rakam ve alt çizgi içermelidir", position)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("%q anahtarı birden fazla alanda kullanılmış", field.Key)
+		}
+		seen[field.Key] = true
+		if strings.TrimSpace(field.Label) == "" || utf8.RuneCountInString(field.Label) > maxNameLength {
+			return fmt.Errorf("%q alanının etiketi boş olamaz ve en fazla %d karakter olabilir", field.Key, maxNameLength)
+		}
+		if !knownType(field.Type) {
+			return fmt.Errorf("%q alanının türü geçersiz: %s", field.Key, field.Type)
+		}
+		if field.Type != TypeDeceased {
+			if field.For != "" {
+				return fmt.Errorf("%q alanı bir kişiye bağlanamaz; yalnızca merhum işaretleri bağlanabilir", field.Key)
+			}
+			continue
+		}
+		target, ok := s.Field(field.For)
+		if !ok || target.Type != TypePerson {
+			return fmt.Errorf("%q merhum işareti şemadaki bir kişi alanına bağlanmalıdır", field.Key)
+		}
+	}
+	return nil
+}
+
+// Field, anahtarı verilen alanı döner.
+func (s Schema) Field(key string) (Field, bool) {
+	for _, field := range s {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// DeceasedFor, kişi alanına bağlı merhum işaretini döner.
+func (s Schema) DeceasedFor(key string) (Field, bool) {
+	for _, field := range s {
+		if field.Type == TypeDeceased && field.For == key {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Normalize, formdan ya da veritabanından gelen değerleri şemaya göre doğrular ve saklanacak
+// biçime çevirir: metinler kırpılır, kişiler {name, surname}, listeler metin dizisi, merhum
+// işaretleri true olarak tutulur. Boş değerler ve şemada olmayan anahtarlar atılır. Zorunlu
+// alanlar burada denetlenmez; bkz. Missing.
+func (s Schema) Normalize(raw Values) (Values, error) {
+	values := Values{}
+	for _, field := range s {
+		value, ok := raw[field.Key]
+		if !ok || value == nil {
+			continue
+		}
+		switch field.Type {
+		case TypeText:
+			text := strings.TrimSpace(asString(value))
+			if utf8.RuneCountInString(text) > maxTextLength {
+				return nil, fmt.Errorf("%s en fazla %d karakter olabilir", field.Label, maxTextLength)
+			}
+			if text != "" {
+				values[field.Key] = text
+			}
+		case TypeDate:
+			text := strings.TrimSpace(asString(value))
+			if text == "" {
+				continue
+			}
+			if _, err := time.Parse(DateLayout, text); err != nil {
+				return nil, fmt.Errorf("%s için geçerli bir tarih giriniz", field.Label)
+			}
+			values[field.Key] = text
+		case TypePerson:
+			person := asPerson(value)
+			if utf8.RuneCountInString(person.Name) > maxNameLength || utf8.RuneCountInString(person.Surname) > maxNameLength {
+				return nil, fmt.Errorf("%s için ad ve soyad en fazla %d karakter olabilir", field.Label, maxNameLength)
+			}
+			if !person.IsZero() {
+				values[field.Key] = person.value()
+			}
+		case TypeDeceased:
+			if asBool(value) {
+				values[field.Key] = true
+			}
+		case TypeList:
+			items := asList(value)
+			if len(items) > maxListItems {
+				return nil, fmt.Errorf("%s en fazla %d madde içerebilir", field.Label, maxListItems)
+			}
+			for _, item := range items {
+				if utf8.RuneCountInString(item) > maxItemLength {
+					return nil, fmt.Errorf("%s maddeleri en fazla %d karakter olabilir", field.Label, maxItemLength)
+				}
+			}
+			if len(items) > 0 {
+				values[field.Key] = items
+			}
+		}
+	}
+	return values, nil
+}
+
+// Missing, boş bırakılan zorunlu alanların etiketlerini döner. Kişi alanlarında adın
+// girilmiş olması yeterlidir.
+func (s Schema) Missing(values Values) []string {
+	var missing []string
+	for _, field := range s {
+		if !field.Required {
+			continue
+		}
+		filled := false
+		switch field.Type {
+		case TypePerson:
+			filled = values.Person(field.Key).Name != ""
+		case TypeList:
+			filled = len(values.List(field.Key)) > 0
+		case TypeDeceased:
+			filled = true
+		default:
+			filled = values.Text(field.Key) != ""
+		}
+		if !filled {
+			missing = append(missing, field.Label)
+		}
+	}
+	return missing
+}
+
+// Entry, davetiye sayfasında gösterilecek dolu bir detay alanıdır. Merhum işaretleri ayrı
+// gösterilmez; bağlı oldukları kişinin Deceased alanına yansır.
+type Entry struct {
+	Field    Field
+	Text     string
+	Date     time.Time
+	Person   Person
+	Deceased bool
+	List     []string
+}
+
+// Entries, şemadaki sırayla dolu alanları döner.
+func (s Schema) Entries(values Values) []Entry {
+	var entries []Entry
+	for _, field := range s {
+		entry := Entry{Field: field}
+		switch field.Type {
+		case TypeDeceased:
+			continue
+		case TypePerson:
+			entry.Person = values.Person(field.Key)
+			if entry.Person.IsZero() {
+				continue
+			}
+			if deceased, ok := s.DeceasedFor(field.Key); ok {
+				entry.Deceased = values.Bool(deceased.Key)
+			}
+		case TypeList:
+			entry.List = values.List(field.Key)
+			if len(entry.List) == 0 {
+				continue
+			}
+		case TypeDate:
+			entry.Date = values.Date(field.Key)
+			if entry.Date.IsZero() {
+				continue
+			}
+		default:
+			entry.Text = values.Text(field.Key)
+			if entry.Text == "" {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Scan, JSONB sütununu şemaya çevirir.
+func (s *Schema) Scan(value any) error {
+	*s = nil
+	data, err := jsonBytes(value)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	return json.Unmarshal(data, s)
+}
+
+// Value, şemayı JSONB olarak yazar; boş şema NULL olarak saklanır ve kategorinin türüne ait
+// varsayılan alanlar kullanılır.
+func (s Schema) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Person, kişi türündeki alanın değeridir.
+type Person struct {
+	Name    string
+	Surname string
+}
+
+// FullName, ad ve soyadı birleştirir.
+func (p Person) FullName() string {
+	return strings.TrimSpace(p.Name + " " + p.Surname)
+}
+
+// IsZero, ad ve soyadın ikisinin de boş olduğunu bildirir.
+func (p Person) IsZero() bool {
+	return p.Name == "" && p.Surname == ""
+}
+
+func (p Person) value() map[string]any {
+	value := map[string]any{}
+	if p.Name != "" {
+		value["name"] = p.Name
+	}
+	if p.Surname != "" {
+		value["surname"] = p.Surname
+	}
+	return value
+}
+
+// Values, davetiye detayındaki alan değerleridir (anahtar → değer). Veritabanında JSONB olarak
+// saklanır; JSON'dan çözülen değerler de Normalize çıktısı da aynı erişimcilerle okunur.
+type Values map[string]any
+
+// Text, metin ve tarih alanlarının değerini döner; listeler satır satır birleştirilir.
+func (v Values) Text(key string) string {
+	if list, ok := v[key].([]string); ok {
+		return strings.Join(list, "\n")
+	}
+	if list, ok := v[key].([]any); ok {
+		return strings.Join(asList(list), "\n")
+	}
+	return asString(v[key])
+}
+
+// Person, kişi alanının değerini döner.
+func (v Values) Person(key string) Person {
+	return asPerson(v[key])
+}
+
+// Bool, merhum işaretinin değerini döner.
+func (v Values) Bool(key string) bool {
+	return asBool(v[key])
+}
+
+// List, liste alanının maddelerini döner.
+func (v Values) List(key string) []string {
+	return asList(v[key])
+}
+
+// Date, tarih alanının değerini döner; boş ya da geçersizse sıfır zaman döner.
+func (v Values) Date(key string) time.Time {
+	date, err := time.Parse(DateLayout, asString(v[key]))
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
+// Flatten, değerleri düz anahtar → metin eşlemesine çevirir. Kişi alanları <key>,
+// <key>_name ve <key>_surname anahtarlarıyla, listeler virgülle birleştirilerek yazılır;
+// şablonların zorunlu alan denetimi ve başlık üretimi bu eşlemeyi kullanır.
+func (v Values) Flatten() map[string]string {
+	flat := map[string]string{}
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := v[key].(type) {
+		case map[string]any, map[string]string, Person:
+			person := asPerson(value)
+			flat[key] = person.FullName()
+			flat[key+"_name"] = person.Name
+			flat[key+"_surname"] = person.Surname
+		case []string, []any:
+			flat[key] = strings.Join(asList(value), ", ")
+		case bool:
+			if value {
+				flat[key] = "true"
+			}
+		default:
+			flat[key] = asString(value)
+		}
+	}
+	return flat
+}
+
+// Scan, JSONB sütununu değerlere çevirir.
+func (v *Values) Scan(value any) error {
+	*v = nil
+	data, err := jsonBytes(value)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Value, değerleri JSONB olarak yazar.
+func (v Values) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+var formKey = regexp.MustCompile(`^fields\[([a-z][a-z0-9_]*)\](?:\[(name|surname)\])?$`)
+
+// FromForm, formdaki fields[<key>] ve kişi alanları için fields[<key>][name],
+// fields[<key>][surname] değerlerini toplar. Sonuç henüz doğrulanmamıştır; kategori şemasına
+// göre Normalize edilmelidir.
+func FromForm(form map[string]string) Values {
+	values := Values{}
+	for name, value := range form {
+		match := formKey.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		key, part := match[1], match[2]
+		if part == "" {
+			values[key] = value
+			continue
+		}
+		person, ok := values[key].(map[string]any)
+		if !ok {
+			person = map[string]any{}
+			values[key] = person
+		}
+		person[part] = value
+	}
+	return values
+}
+
+func jsonBytes(value any) ([]byte, error) {
+	switch data := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return data, nil
+	case string:
+		return []byte(data), nil
+	default:
+		return nil, errors.New("detay alanları çözülemedi: beklenmeyen veri türü")
+	}
+}
+
+func asString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func asBool(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "on", "1", "evet":
+			return true
+		}
+	}
+	return false
+}
+
+// asPerson, kişi değerini okur; eski kayıtlardaki tek parça metin ad olarak kabul edilir.
+func asPerson(value any) Person {
+	switch v := value.(type) {
+	case Person:
+		return v
+	case map[string]any:
+		return Person{Name: strings.TrimSpace(asString(v["name"])), Surname: strings.TrimSpace(asString(v["surname"]))}
+	case map[string]string:
+		return Person{Name: strings.TrimSpace(v["name"]), Surname: strings.TrimSpace(v["surname"])}
+	case string:
+		return Person{Name: strings.TrimSpace(v)}
+	}
+	return Person{}
+}
+
+// asList, liste değerini okur; metin olarak gelen değerler satırlara bölünür. Boş maddeler atılır.
+func asList(value any) []string {
+	var raw []string
+	switch v := value.(type) {
+	case []string:
+		raw = v
+	case []any:
+		for _, item := range v {
+			raw = append(raw, asString(item))
+		}
+	case string:
+		raw = strings.Split(strings.ReplaceAll(v, "\r\n", "\n"), "\n")
+	}
+	var items []string
+	for _, item := range raw {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -19,6 +19,7 @@ var (
 	ErrFileNotProvided = errors.New("dosya sağlanmadı")
 	ErrInvalidFileType = errors.New("geçersiz dosya türü veya uzantısı")
 	ErrFileTooLarge    = errors.New("dosya boyutu çok büyük")
+	ErrInvalidFileName = errors.New("geçersiz dosya adı")
 )
 
 const (
@@ -57,7 +58,13 @@ func UploadFile(c *fiber.Ctx, formFieldName, contentType string) (string, error)
 		}
 		return "", err
 	}
-	if err := validateFile(file, contentType); err != nil {
+	return SaveFormFile(c, file, contentType, DefaultMaxFileSize)
+}
+
+// SaveFormFile, formdan alınmış dosyayı verilen boyut sınırıyla doğrulayıp uploads dizinine
+// benzersiz bir adla kaydeder. Aynı alanda birden fazla dosya gönderilen formlar içindir.
+func SaveFormFile(c *fiber.Ctx, file *multipart.FileHeader, contentType string, maxSize int64) (string, error) {
+	if err := validateFileSize(file, contentType, maxSize); err != nil {
 		return "", err
 	}
 	newFileName, err := generateUniqueFileName(file.Filename)
@@ -72,7 +79,11 @@ func UploadFile(c *fiber.Ctx, formFieldName, contentType string) (string, error)
 }
 
 func validateFile(file *multipart.FileHeader, contentType string) error {
-	if file.Size > DefaultMaxFileSize { return ErrFileTooLarge }
+	return validateFileSize(file, contentType, DefaultMaxFileSize)
+}
+
+func validateFileSize(file *multipart.FileHeader, contentType string, maxSize int64) error {
+	if file.Size > maxSize { return ErrFileTooLarge }
 	ext := filepath.Ext(file.Filename)
 	if !fileconfig.Config.IsExtensionAllowed(contentType, ext) { return ErrInvalidFileType }
 	return nil
@@ -86,4 +97,59 @@ func generateUniqueFileName(originalName string) (string, error) {
 	safeBaseName := regexp.MustCompile(`[^a-zA-Z0-9_-]+`).ReplaceAllString(strings.TrimSuffix(originalName, ext), "")
 	if safeBaseName == "" { safeBaseName = "file" }
 	return fmt.Sprintf("%s-%s%s", randomStr, safeBaseName, ext), nil
-}
\ No newline at end of file
+}
+
+var tempFileNamePattern = regexp.MustCompile(`^[a-f0-9]{16}-[A-Za-z0-9_-]+\.[A-Za-z0-9]+$`)
+
+// UploadTempFile, dosyayı herkese açık uploads dizini yerine sunucunun geçici dizinine kaydeder.
+// İçe aktarma gibi iki adımlı işlemlerde dosyanın adımlar arasında saklanması için kullanılır.
+func UploadTempFile(c *fiber.Ctx, formFieldName, contentType string) (string, error) {
+	file, err := c.FormFile(formFieldName)
+	if err != nil {
+		if err == http.ErrMissingFile {
+			return "", ErrFileNotProvided
+		}
+		return "", err
+	}
+	if err := validateFile(file, contentType); err != nil {
+		return "", err
+	}
+	newFileName, err := generateUniqueFileName(file.Filename)
+	if err != nil {
+		return "", err
+	}
+	dir := tempDir(contentType)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	if err := c.SaveFile(file, filepath.Join(dir, newFileName)); err != nil {
+		return "", err
+	}
+	return newFileName, nil
+}
+
+// TempFilePath, UploadTempFile ile kaydedilmiş dosyanın tam yolunu döner.
+// Dosya adı kullanıcıdan geldiği için dizin dışına çıkılmasına izin verilmez.
+func TempFilePath(contentType, fileName string) (string, error) {
+	if !tempFileNamePattern.MatchString(fileName) {
+		return "", ErrInvalidFileName
+	}
+	path := filepath.Join(tempDir(contentType), fileName)
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// DeleteTempFile, geçici dizindeki dosyayı siler.
+func DeleteTempFile(contentType, fileName string) {
+	path, err := TempFilePath(contentType, fileName)
+	if err != nil {
+		return
+	}
+	_ = os.Remove(path)
+}
+
+func tempDir(contentType string) string {
+	return filepath.Join(os.TempDir(), "davet.link", filepath.Base(contentType))
+}
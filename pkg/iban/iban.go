@@ -0,0 +1,92 @@
+package iban
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidIBAN = errors.New("geçersiz IBAN")
+
+// countryLengths, IBAN kullanan ülkelerin ISO 3166 kodlarına göre toplam IBAN uzunluklarıdır.
+var countryLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22,
+	"DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18, "FO": 18, "FR": 27,
+	"GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24,
+	"ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24, "SC": 31,
+	"SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28, "TL": 23, "TN": 24,
+	"TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// Normalize, kullanıcının girdiği IBAN'dan boşluk ve tireleri atar ve harfleri büyütür.
+// "tr12 0006 ..." gibi gruplanmış girişler "TR120006..." biçimine gelir.
+func Normalize(raw string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(raw) {
+		if r == ' ' || r == '-' || r == '\t' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Validate, normalize edilmiş IBAN'ın ülke uzunluğunu ve mod-97 kontrol basamaklarını doğrular.
+func Validate(normalized string) error {
+	if len(normalized) < 5 {
+		return ErrInvalidIBAN
+	}
+	length, ok := countryLengths[normalized[:2]]
+	if !ok || len(normalized) != length {
+		return ErrInvalidIBAN
+	}
+	for i, r := range normalized {
+		isDigit := r >= '0' && r <= '9'
+		isLetter := r >= 'A' && r <= 'Z'
+		if (i >= 2 && i < 4 && !isDigit) || (!isDigit && !isLetter) {
+			return ErrInvalidIBAN
+		}
+	}
+
+	// İlk dört karakter sona taşınır, harfler 10-35 arası sayılara çevrilir ve
+	// sonuç 97'ye bölündüğünde 1 kalmalıdır.
+	rearranged := normalized[4:] + normalized[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		if r >= 'A' && r <= 'Z' {
+			value := int(r-'A') + 10
+			remainder = (remainder*100 + value) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	if remainder != 1 {
+		return ErrInvalidIBAN
+	}
+	return nil
+}
+
+// Format, IBAN'ı dörderli gruplar halinde gösterir: "TR12 0006 2000 ...".
+func Format(raw string) string {
+	normalized := Normalize(raw)
+	var b strings.Builder
+	for i, r := range normalized {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// BankCodeTR, TR IBAN'ının 5-9. karakterlerindeki beş haneli banka (EFT) kodunu döner.
+// IBAN Türkiye'ye ait değilse boş döner.
+func BankCodeTR(normalized string) string {
+	if len(normalized) != countryLengths["TR"] || !strings.HasPrefix(normalized, "TR") {
+		return ""
+	}
+	return normalized[4:9]
+}
@@ -0,0 +1,115 @@
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+)
+
+// Event, takvim dosyasına yazılacak tek bir etkinliği tanımlar.
+// AllDay true ise yalnızca Start'ın tarihi kullanılır.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+	Location    string
+	// Latitude ve Longitude birlikte verilirse GEO özelliği yazılır.
+	Latitude  *float64
+	Longitude *float64
+	URL       string
+}
+
+// Write, etkinlikleri her biri ayrı bir VEVENT olacak şekilde RFC 5545 uyumlu tek bir
+// VCALENDAR olarak yazar.
+func Write(w io.Writer, events ...Event) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//davet.link//Davetiye//TR",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	stamp := time.Now().UTC().Format(dateTimeLayout)
+	for _, event := range events {
+		lines = append(lines, eventLines(event, stamp)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprint(w, fold(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eventLines(event Event, stamp string) []string {
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + escape(event.UID),
+		"DTSTAMP:" + stamp,
+	}
+	if event.AllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+event.Start.Format(dateLayout),
+			"DTEND;VALUE=DATE:"+event.Start.AddDate(0, 0, 1).Format(dateLayout),
+		)
+	} else {
+		end := event.End
+		if end.IsZero() || !end.After(event.Start) {
+			end = event.Start.Add(2 * time.Hour)
+		}
+		lines = append(lines,
+			"DTSTART:"+event.Start.UTC().Format(dateTimeLayout),
+			"DTEND:"+end.UTC().Format(dateTimeLayout),
+		)
+	}
+	lines = append(lines, "SUMMARY:"+escape(event.Summary))
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escape(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escape(event.Location))
+	}
+	if event.Latitude != nil && event.Longitude != nil {
+		lines = append(lines, "GEO:"+strconv.FormatFloat(*event.Latitude, 'f', -1, 64)+";"+strconv.FormatFloat(*event.Longitude, 'f', -1, 64))
+	}
+	if event.URL != "" {
+		lines = append(lines, "URL:"+event.URL)
+	}
+	return append(lines, "END:VEVENT")
+}
+
+func escape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(s)
+}
+
+// fold, 75 oktetten uzun satırları RFC 5545'e göre böler; UTF-8 karakterleri bölünmez.
+func fold(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,369 @@
+package invitationtheme
+
+import (
+	"davet.link/pkg/detailfields"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// Davetiye kategorilerinin Template alanında saklanan türlerdir. Tür, kullanılabilecek
+// şablonları ve kendi alan şeması tanımlanmamış kategorilerin varsayılan detay alanlarını
+// belirler.
+const (
+	KindTitle        = "title"
+	KindPerson       = "person"
+	KindPersonFamily = "person-family"
+	KindWedding      = "wedding"
+	KindOnline       = "online"
+)
+
+// DefaultTheme, şablonu seçilmemiş ya da kayıtta bulunmayan davetiyeler için kullanılır; tüm
+// türleri destekler.
+const DefaultTheme = "classic"
+
+// Kind, kategori türüdür.
+type Kind struct {
+	Name  string
+	Label string
+	// Schema, bu türdeki kategorilerin varsayılan detay alanlarıdır.
+	Schema detailfields.Schema
+}
+
+// Field, temanın zorunlu tuttuğu bir detay değeridir. Key, detailfields.Values.Flatten
+// çıktısındaki anahtardır (ör: kişi alanları için bride_surname).
+type Field struct {
+	Key   string
+	Label string
+}
+
+// OptionType, özelleştirilebilir seçeneğin türüdür.
+type OptionType string
+
+const (
+	OptionColor      OptionType = "color"
+	OptionFont       OptionType = "font"
+	OptionBackground OptionType = "background"
+)
+
+// Choice, yazı tipi ve arka plan seçeneklerinin değerlerinden biridir. CSS, şablonun CSS
+// değişkenine yazılan karşılığıdır.
+type Choice struct {
+	Value string
+	Label string
+	CSS   string
+}
+
+// Option, davetiye sahibinin değiştirebildiği bir görünüm ayarıdır. Her seçenek sayfada
+// --invitation-<key> CSS değişkeni olarak kullanılır (ör: primary_color → --invitation-primary-color).
+type Option struct {
+	Key     string
+	Label   string
+	Type    OptionType
+	Default string
+	// Choices, yazı tipi ve arka plan seçenekleri için izin verilen değerlerdir.
+	Choices []Choice
+}
+
+// Theme, davetiye sayfasının görünümüdür.
+type Theme struct {
+	Name        string
+	Label       string
+	Description string
+	// Kinds, temanın kullanılabileceği kategori türleridir.
+	Kinds []string
+	// Preview, tema seçiminde gösterilen örnek görselin adresidir.
+	Preview string
+	// View, davetiyenin render edildiği görünüm dosyasıdır.
+	View string
+	// RequiredFields, kategori şemasının zorunlu alanlarına ek olarak temanın gösterdiği
+	// zorunlu alanlardır.
+	RequiredFields []Field
+	Options        []Option
+}
+
+var (
+	fontChoices = []Choice{
+		{Value: "serif", Label: "Klasik", CSS: "Georgia, 'Times New Roman', serif"},
+		{Value: "sans", Label: "Modern", CSS: "system-ui, -apple-system, 'Segoe UI', Roboto, sans-serif"},
+		{Value: "script", Label: "El Yazısı", CSS: "'Brush Script MT', 'Segoe Script', 'Apple Chancery', cursive"},
+	}
+	backgroundChoices = []Choice{
+		{Value: "plain", Label: "Düz", CSS: "none"},
+		{Value: "gradient", Label: "Geçişli", CSS: "linear-gradient(180deg, rgba(255, 255, 255, 0.5), rgba(0, 0, 0, 0.06))"},
+		{Value: "dots", Label: "Noktalı", CSS: "radial-gradient(rgba(0, 0, 0, 0.08) 1px, transparent 1.5px) 0 0 / 18px 18px"},
+		{Value: "stripes", Label: "Çizgili", CSS: "repeating-linear-gradient(45deg, rgba(0, 0, 0, 0.04) 0 12px, transparent 12px 24px)"},
+	}
+)
+
+var titleSchema = detailfields.Schema{
+	{Key: "title", Label: "Başlık", Type: detailfields.TypeText, Required: true},
+}
+
+// parentFields, kişinin (prefix boşsa) ya da gelin/damadın anne ve baba alanlarını merhum
+// işaretleriyle birlikte üretir; eski tablodaki sütunlarla aynı anahtarları kullanır.
+func parentFields(prefix, motherLabel, fatherLabel string) detailfields.Schema {
+	mother, father := prefix+"mother", prefix+"father"
+	return detailfields.Schema{
+		{Key: mother, Label: motherLabel, Type: detailfields.TypePerson},
+		{Key: mother + "_deceased", Label: motherLabel + " Merhum", Type: detailfields.TypeDeceased, For: mother},
+		{Key: father, Label: fatherLabel, Type: detailfields.TypePerson},
+		{Key: father + "_deceased", Label: fatherLabel + " Merhum", Type: detailfields.TypeDeceased, For: father},
+	}
+}
+
+func weddingSchema() detailfields.Schema {
+	schema := detailfields.Schema{{Key: "bride", Label: "Gelin", Type: detailfields.TypePerson, Required: true}}
+	schema = append(schema, parentFields("bride_", "Gelinin Annesi", "Gelinin Babası")...)
+	schema = append(schema, detailfields.Field{Key: "groom", Label: "Damat", Type: detailfields.TypePerson, Required: true})
+	return append(schema, parentFields("groom_", "Damadın Annesi", "Damadın Babası")...)
+}
+
+var personField = detailfields.Field{Key: "person", Label: "Kimin Adına", Type: detailfields.TypePerson, Required: true}
+
+var kinds = []Kind{
+	{Name: KindTitle, Label: "Başlıklı Etkinlik", Schema: titleSchema},
+	{Name: KindPerson, Label: "Kişiye Özel", Schema: detailfields.Schema{personField}},
+	{Name: KindPersonFamily, Label: "Kişi ve Aile", Schema: append(detailfields.Schema{personField}, parentFields("", "Anne", "Baba")...)},
+	{Name: KindWedding, Label: "Düğün / Nişan", Schema: weddingSchema()},
+	{Name: KindOnline, Label: "Online Etkinlik", Schema: titleSchema},
+}
+
+var themes = []Theme{
+	{
+		Name:        "classic",
+		Label:       "Klasik",
+		Description: "Ortalanmış kart, sade tipografi. Tüm etkinlik türleri için uygundur.",
+		Kinds:       []string{KindTitle, KindPerson, KindPersonFamily, KindWedding, KindOnline},
+		Preview:     "/themes/classic.svg",
+		View:        "website/themes/classic",
+		Options: []Option{
+			{Key: "primary_color", Label: "Vurgu Rengi", Type: OptionColor, Default: "#65a30d"},
+			{Key: "background_color", Label: "Arka Plan Rengi", Type: OptionColor, Default: "#fafaf9"},
+			{Key: "font", Label: "Yazı Tipi", Type: OptionFont, Default: "serif", Choices: fontChoices},
+			{Key: "background", Label: "Arka Plan Deseni", Type: OptionBackground, Default: "plain", Choices: backgroundChoices},
+		},
+	},
+	{
+		Name:        "elegant",
+		Label:       "Zarif",
+		Description: "Gelin ve damadın tam adları, aileleriyle birlikte el yazısı başlıkla gösterilir.",
+		Kinds:       []string{KindWedding},
+		Preview:     "/themes/elegant.svg",
+		View:        "website/themes/elegant",
+		RequiredFields: []Field{
+			{Key: "bride_surname", Label: "Gelin Soyadı"},
+			{Key: "groom_surname", Label: "Damat Soyadı"},
+		},
+		Options: []Option{
+			{Key: "primary_color", Label: "Vurgu Rengi", Type: OptionColor, Default: "#b08d57"},
+			{Key: "background_color", Label: "Arka Plan Rengi", Type: OptionColor, Default: "#fffaf3"},
+			{Key: "font", Label: "Yazı Tipi", Type: OptionFont, Default: "script", Choices: fontChoices},
+			{Key: "background", Label: "Arka Plan Deseni", Type: OptionBackground, Default: "gradient", Choices: backgroundChoices},
+		},
+	},
+	{
+		Name:        "modern",
+		Label:       "Modern",
+		Description: "Tam genişlikte görsel ve kalın başlıkla etkinlik, doğum günü ve online davetler için.",
+		Kinds:       []string{KindTitle, KindPerson, KindOnline},
+		Preview:     "/themes/modern.svg",
+		View:        "website/themes/modern",
+		Options: []Option{
+			{Key: "primary_color", Label: "Vurgu Rengi", Type: OptionColor, Default: "#4f46e5"},
+			{Key: "background_color", Label: "Arka Plan Rengi", Type: OptionColor, Default: "#ffffff"},
+			{Key: "font", Label: "Yazı Tipi", Type: OptionFont, Default: "sans", Choices: fontChoices},
+			{Key: "background", Label: "Arka Plan Deseni", Type: OptionBackground, Default: "dots", Choices: backgroundChoices},
+		},
+	},
+}
+
+// Kinds, kategori formunda seçilebilen türleri döner.
+func Kinds() []Kind {
+	return kinds
+}
+
+// GetKind, adı verilen türü döner.
+func GetKind(name string) (Kind, bool) {
+	for _, kind := range kinds {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// SchemaFor, kategorinin kendi alan şemasını, tanımlanmamışsa türünün varsayılan şemasını döner.
+func SchemaFor(kind string, schema detailfields.Schema) detailfields.Schema {
+	if len(schema) > 0 {
+		return schema
+	}
+	k, _ := GetKind(kind)
+	return k.Schema
+}
+
+// All, kayıtlı tüm temaları döner.
+func All() []Theme {
+	return themes
+}
+
+// Get, adı verilen temayı döner.
+func Get(name string) (Theme, bool) {
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Supports, temanın kategori türünü destekleyip desteklemediğini bildirir.
+func (t Theme) Supports(kind string) bool {
+	for _, k := range t.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing, temanın zorunlu tuttuğu alanlardan values içinde boş bırakılanların etiketlerini
+// döner; values, detailfields.Values.Flatten çıktısıdır.
+func (t Theme) Missing(values map[string]string) []string {
+	var missing []string
+	for _, field := range t.RequiredFields {
+		if strings.TrimSpace(values[field.Key]) == "" {
+			missing = append(missing, field.Label)
+		}
+	}
+	return missing
+}
+
+// Option, anahtarı verilen seçeneği döner.
+func (t Theme) Option(key string) (Option, bool) {
+	for _, option := range t.Options {
+		if option.Key == key {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
+
+// Options, temanın seçenek değerleridir (anahtar → değer).
+type Options map[string]string
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ResolveOptions, formdan gelen değerleri doğrular ve boş bırakılanları temanın
+// varsayılanlarıyla doldurur. Temada tanımlı olmayan anahtarlar yok sayılır.
+func (t Theme) ResolveOptions(raw map[string]string) (Options, error) {
+	resolved := Options{}
+	for _, option := range t.Options {
+		value := strings.TrimSpace(raw[option.Key])
+		if value == "" {
+			resolved[option.Key] = option.Default
+			continue
+		}
+		if !option.accepts(value) {
+			return nil, fmt.Errorf("%s için geçersiz değer: %s", strings.ToLower(option.Label), value)
+		}
+		if option.Type == OptionColor {
+			value = strings.ToLower(value)
+		}
+		resolved[option.Key] = value
+	}
+	return resolved, nil
+}
+
+func (o Option) accepts(value string) bool {
+	if o.Type == OptionColor {
+		return hexColor.MatchString(value)
+	}
+	_, ok := o.choice(value)
+	return ok
+}
+
+func (o Option) choice(value string) (Choice, bool) {
+	for _, choice := range o.Choices {
+		if choice.Value == value {
+			return choice, true
+		}
+	}
+	return Choice{}, false
+}
+
+// Appearance, davetiyenin görünümünü oluşturan tema ve seçenek değerleridir.
+type Appearance struct {
+	Theme   Theme
+	Options Options
+}
+
+// Resolve, kayıtlı tema adını ve JSON olarak saklanan seçenekleri görünüme çevirir. Tema
+// bulunamazsa varsayılan tema, geçersiz seçeneklerin yerine varsayılan değerleri kullanılır;
+// böylece eski kayıtlar ve tema değiştirilmiş davetiyeler de her zaman gösterilebilir.
+func Resolve(name, rawOptions string) Appearance {
+	return ResolveValues(name, DecodeOptions(rawOptions))
+}
+
+// ResolveValues, Resolve gibidir ancak seçenekleri formdan gelen haliyle alır.
+func ResolveValues(name string, values map[string]string) Appearance {
+	theme, ok := Get(name)
+	if !ok {
+		theme, _ = Get(DefaultTheme)
+	}
+	valid := map[string]string{}
+	for _, option := range theme.Options {
+		if value := strings.TrimSpace(values[option.Key]); value != "" && option.accepts(value) {
+			valid[option.Key] = value
+		}
+	}
+	options, _ := theme.ResolveOptions(valid)
+	return Appearance{Theme: theme, Options: options}
+}
+
+// Style, seçenekleri şablonun style özniteliğine yazılacak CSS değişkenlerine çevirir.
+// Değerler ResolveOptions ile doğrulandığından yalnızca renk kodları ve kayıtlı CSS
+// karşılıkları yazılır.
+func (a Appearance) Style() template.CSS {
+	var b strings.Builder
+	for _, option := range a.Theme.Options {
+		value := a.Options[option.Key]
+		if option.Type != OptionColor {
+			choice, ok := option.choice(value)
+			if !ok {
+				continue
+			}
+			value = choice.CSS
+		} else if !hexColor.MatchString(value) {
+			continue
+		}
+		fmt.Fprintf(&b, "--invitation-%s: %s; ", strings.ReplaceAll(option.Key, "_", "-"), value)
+	}
+	return template.CSS(strings.TrimSpace(b.String()))
+}
+
+// DecodeOptions, JSON olarak saklanan seçenekleri çözer; boş ya da bozuk veride nil döner.
+func DecodeOptions(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
+// EncodeOptions, seçenekleri veritabanında saklanacak JSON biçimine çevirir.
+func EncodeOptions(options Options) string {
+	if len(options) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,248 @@
+package maplink
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNoCoordinates = errors.New("bağlantıda koordinat bulunamadı")
+
+// Coordinates, WGS84 enlem ve boylamıdır.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// String, koordinatı harita servislerinin beklediği "enlem,boylam" biçiminde döner.
+func (c Coordinates) String() string {
+	return formatFloat(c.Latitude) + "," + formatFloat(c.Longitude)
+}
+
+var (
+	// Google Maps yer bağlantılarında işaretçinin konumu "!3d<enlem>!4d<boylam>" olarak geçer;
+	// "@<enlem>,<boylam>" ise yalnızca harita görünümünün merkezidir.
+	googlePinPattern    = regexp.MustCompile(`!3d(-?\d+(?:\.\d+)?)!4d(-?\d+(?:\.\d+)?)`)
+	googleCenterPattern = regexp.MustCompile(`@(-?\d+(?:\.\d+)?),(-?\d+(?:\.\d+)?)`)
+	pairPattern         = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*[,;\s]\s*(-?\d+(?:\.\d+)?)\s*$`)
+)
+
+// latLngParams, enlem,boylam sırasıyla koordinat taşıyan Google Maps ve Apple Maps
+// sorgu parametreleridir; öncelik sırasına göre denenir.
+var latLngParams = []string{"ll", "coordinate", "q", "query", "destination", "daddr", "center", "sll"}
+
+// Parse, yapıştırılan Google Maps, Apple Maps veya Yandex Haritalar bağlantısından ya da
+// "41.0082, 28.9784" biçimindeki düz metinden koordinatı çıkarır.
+func Parse(raw string) (Coordinates, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Coordinates{}, false
+	}
+	if c, ok := parsePair(raw, false); ok {
+		return c, true
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return Coordinates{}, false
+	}
+	query := u.Query()
+
+	if isYandexHost(u.Host) {
+		// Yandex, ll/pt/whatshere parametrelerinde boylamı önce yazar; rtext ise
+		// "enlem,boylam~enlem,boylam" biçiminde rota noktalarıdır ve son nokta hedeftir.
+		for _, key := range []string{"pt", "whatshere[point]", "ll"} {
+			if c, ok := parsePair(query.Get(key), true); ok {
+				return c, true
+			}
+		}
+		if rtext := query.Get("rtext"); rtext != "" {
+			points := strings.Split(rtext, "~")
+			if c, ok := parsePair(points[len(points)-1], false); ok {
+				return c, true
+			}
+		}
+		return Coordinates{}, false
+	}
+
+	decoded, _ := url.PathUnescape(u.Path)
+	if m := googlePinPattern.FindStringSubmatch(raw); m != nil {
+		if c, ok := buildCoordinates(m[1], m[2], false); ok {
+			return c, true
+		}
+	}
+	for _, key := range latLngParams {
+		if c, ok := parsePair(query.Get(key), false); ok {
+			return c, true
+		}
+	}
+	if m := googleCenterPattern.FindStringSubmatch(decoded); m != nil {
+		if c, ok := buildCoordinates(m[1], m[2], false); ok {
+			return c, true
+		}
+	}
+	// Google'ın onay sayfasına yönlendirilen bağlantılarda asıl adres continue parametresindedir.
+	if next := query.Get("continue"); next != "" && next != raw {
+		return Parse(next)
+	}
+	return Coordinates{}, false
+}
+
+// IsMapLink, bağlantının desteklenen harita servislerinden birine ait olup olmadığını döner.
+func IsMapLink(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case isYandexHost(host), host == "maps.apple.com", host == "maps.app.goo.gl", host == "goo.gl":
+		return true
+	case strings.HasPrefix(host, "maps.google."), strings.Contains(host, "google.") && strings.HasPrefix(u.Path, "/maps"):
+		return true
+	}
+	return false
+}
+
+// IsShortLink, koordinatı ancak yönlendirme izlenerek öğrenilebilen kısa paylaşım
+// bağlantılarını (maps.app.goo.gl, goo.gl/maps, yandex.com.tr/harita/-/...) tanır.
+func IsShortLink(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "maps.app.goo.gl":
+		return true
+	case host == "goo.gl":
+		return strings.HasPrefix(u.Path, "/maps")
+	case isYandexHost(host):
+		return strings.Contains(u.Path, "/-/")
+	}
+	return false
+}
+
+const maxRedirects = 5
+
+var shortLinkClient = &http.Client{
+	Timeout: 5 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Expand, kısa bağlantının yönlendirmelerini tek tek izler ve koordinat içeren ilk adreste
+// durarak koordinatı döner. Yanıt gövdeleri okunmaz.
+func Expand(ctx context.Context, raw string) (Coordinates, error) {
+	current := strings.TrimSpace(raw)
+	for i := 0; i < maxRedirects; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, current, nil)
+		if err != nil {
+			return Coordinates{}, err
+		}
+		resp, err := shortLinkClient.Do(req)
+		if err != nil {
+			return Coordinates{}, err
+		}
+		resp.Body.Close()
+
+		location, err := resp.Location()
+		if err != nil {
+			break
+		}
+		current = location.String()
+		if c, ok := Parse(current); ok {
+			return c, nil
+		}
+	}
+	return Coordinates{}, ErrNoCoordinates
+}
+
+// Links, bir mekân için harita uygulamalarında açılan bağlantılardır. Alanlar template.URL
+// türündedir; html/template yandexnavi:// şemasını aksi halde güvensiz sayar.
+type Links struct {
+	Google template.URL
+	Apple  template.URL
+	Yandex template.URL
+}
+
+// Build, koordinat varsa doğrudan o noktaya, yoksa mekân adı ve adresiyle yapılan aramaya
+// giden Google Maps, Apple Maps ve Yandex Navigasyon bağlantılarını üretir.
+// Koordinat ve arama metni ikisi de yoksa sıfır değer döner.
+func Build(name, address string, c *Coordinates) Links {
+	text := strings.TrimSpace(strings.Join(nonEmpty(name, address), ", "))
+	if c == nil {
+		if text == "" {
+			return Links{}
+		}
+		return Links{
+			Google: template.URL("https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(text)),
+			Apple:  template.URL("https://maps.apple.com/?q=" + url.QueryEscape(text)),
+			Yandex: template.URL("https://yandex.com.tr/harita/?text=" + url.QueryEscape(text)),
+		}
+	}
+
+	apple := url.Values{"ll": {c.String()}}
+	if text != "" {
+		apple.Set("q", text)
+	}
+	return Links{
+		Google: template.URL("https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(c.String())),
+		Apple:  template.URL("https://maps.apple.com/?" + apple.Encode()),
+		Yandex: template.URL("yandexnavi://build_route_on_map?lat_to=" + formatFloat(c.Latitude) + "&lon_to=" + formatFloat(c.Longitude)),
+	}
+}
+
+func parsePair(s string, lonFirst bool) (Coordinates, bool) {
+	m := pairPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Coordinates{}, false
+	}
+	return buildCoordinates(m[1], m[2], lonFirst)
+}
+
+func buildCoordinates(a, b string, lonFirst bool) (Coordinates, bool) {
+	first, err1 := strconv.ParseFloat(a, 64)
+	second, err2 := strconv.ParseFloat(b, 64)
+	if err1 != nil || err2 != nil {
+		return Coordinates{}, false
+	}
+	c := Coordinates{Latitude: first, Longitude: second}
+	if lonFirst {
+		c = Coordinates{Latitude: second, Longitude: first}
+	}
+	if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+		return Coordinates{}, false
+	}
+	// 0,0 okyanusun ortasıdır; boş bırakılmış alanların sonucu olarak kabul edilir.
+	if c.Latitude == 0 && c.Longitude == 0 {
+		return Coordinates{}, false
+	}
+	return c, true
+}
+
+func isYandexHost(host string) bool {
+	host = strings.ToLower(host)
+	return strings.HasPrefix(host, "yandex.") || strings.Contains(host, ".yandex.")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func nonEmpty(values ...string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Render, yönetim panelinden girilen Markdown metnini HTML'e çevirir. Desteklenen alt küme:
+// başlıklar (#), paragraflar, madde ve numaralı listeler, alıntılar (>), yatay çizgi (---),
+// kod blokları (```), kalın, italik, satır içi kod ve bağlantılar. Ham HTML desteklenmez;
+// tüm metin kaçışlanır ve yalnızca http(s), mailto ve site içi bağlantılara izin verilir.
+func Render(src string) template.HTML {
+	src = strings.NewReplacer("\r\n", "\n", "\x00", "").Replace(src)
+	lines := strings.Split(src, "\n")
+	var b strings.Builder
+	renderBlocks(&b, lines)
+	return template.HTML(b.String())
+}
+
+// PlainText, Markdown metnini biçimlendirme işaretlerinden arındırır; bağlantıların yalnızca
+// metni kalır. Meta açıklaması gibi düz metin gereken yerlerde kullanılır.
+func PlainText(src string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		switch {
+		case fenceLine.MatchString(line), ruleLine.MatchString(line):
+			continue
+		case headingLine.MatchString(line):
+			line = headingLine.FindStringSubmatch(line)[2]
+		case quoteLine.MatchString(line):
+			line = quoteLine.FindStringSubmatch(line)[1]
+		case unorderedItem.MatchString(line):
+			line = unorderedItem.FindStringSubmatch(line)[1]
+		case orderedItem.MatchString(line):
+			line = orderedItem.FindStringSubmatch(line)[1]
+		}
+		line = link.ReplaceAllString(line, "$1")
+		lines = append(lines, strings.NewReplacer("**", "", "__", "", "`", "").Replace(line))
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
+
+var (
+	headingLine   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleLine      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	unorderedItem = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedItem   = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	quoteLine     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	fenceLine     = regexp.MustCompile("^\\s*```")
+)
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fenceLine.MatchString(line):
+			i++
+			var code []string
+			for ; i < len(lines) && !fenceLine.MatchString(lines[i]); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // kapanış çiti
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingLine.MatchString(line):
+			m := headingLine.FindStringSubmatch(line)
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", len(m[1]), renderInline(m[2]), len(m[1]))
+			i++
+
+		case ruleLine.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quoteLine.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quoteLine.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteLine.FindStringSubmatch(lines[i])[1])
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+
+		case unorderedItem.MatchString(line):
+			i = renderList(b, lines, i, "ul", unorderedItem)
+
+		case orderedItem.MatchString(line):
+			i = renderList(b, lines, i, "ol", orderedItem)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && !startsBlock(lines[i]); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			b.WriteString("<p>" + renderInline(strings.Join(paragraph, "\n")) + "</p>\n")
+		}
+	}
+}
+
+// renderList, aynı türdeki ardışık liste maddelerini tek bir liste olarak yazar ve
+// listeden sonraki satırın indeksini döner. Girintili devam satırları önceki maddeye eklenir.
+func renderList(b *strings.Builder, lines []string, i int, tag string, item *regexp.Regexp) int {
+	var items []string
+	for i < len(lines) {
+		line := lines[i]
+		if m := item.FindStringSubmatch(line); m != nil {
+			items = append(items, m[1])
+		} else if len(items) > 0 && strings.TrimSpace(line) != "" && strings.HasPrefix(line, " ") && !startsBlock(line) {
+			items[len(items)-1] += "\n" + strings.TrimSpace(line)
+		} else {
+			break
+		}
+		i++
+	}
+	b.WriteString("<" + tag + ">\n")
+	for _, text := range items {
+		b.WriteString("<li>" + renderInline(text) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func startsBlock(line string) bool {
+	return strings.TrimSpace(line) == "" ||
+		fenceLine.MatchString(line) ||
+		headingLine.MatchString(line) ||
+		ruleLine.MatchString(line) ||
+		quoteLine.MatchString(line) ||
+		unorderedItem.MatchString(line) ||
+		orderedItem.MatchString(line)
+}
+
+var (
+	codeSpan = regexp.MustCompile("`([^`]+)`")
+	link     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	bold     = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italic   = regexp.MustCompile(`(^|[^\w*])[*_]([^*_\s](?:[^*_]*[^*_\s])?)[*_]`)
+	token    = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// renderInline, satır içi biçimlendirmeyi uygular. Kod parçaları ve bağlantılar önce yer
+// tutuculara alınır; böylece içerikleri diğer kurallarla yeniden işlenmez.
+func renderInline(text string) string {
+	var held []string
+	hold := func(s string) string {
+		held = append(held, s)
+		return fmt.Sprintf("\x00%d\x00", len(held)-1)
+	}
+
+	text = codeSpan.ReplaceAllStringFunc(text, func(m string) string {
+		return hold("<code>" + html.EscapeString(codeSpan.FindStringSubmatch(m)[1]) + "</code>")
+	})
+	text = link.ReplaceAllStringFunc(text, func(m string) string {
+		parts := link.FindStringSubmatch(m)
+		label := formatEmphasis(html.EscapeString(parts[1]))
+		if !isSafeURL(parts[2]) {
+			return hold(label)
+		}
+		attrs := ""
+		if strings.HasPrefix(parts[2], "http") {
+			attrs = ` target="_blank" rel="noopener noreferrer"`
+		}
+		return hold(`<a href="` + html.EscapeString(parts[2]) + `"` + attrs + ">" + label + "</a>")
+	})
+
+	text = formatEmphasis(html.EscapeString(text))
+	text = strings.ReplaceAll(text, "\n", "<br>\n")
+
+	return token.ReplaceAllStringFunc(text, func(m string) string {
+		n, _ := strconv.Atoi(token.FindStringSubmatch(m)[1])
+		return held[n]
+	})
+}
+
+func formatEmphasis(escaped string) string {
+	escaped = bold.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+	return italic.ReplaceAllString(escaped, "$1<em>$2</em>")
+}
+
+// isSafeURL, javascript: gibi şemaları dışarıda bırakır.
+func isSafeURL(raw string) bool {
+	lower := strings.ToLower(raw)
+	for _, prefix := range []string{"https://", "http://", "mailto:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return (strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//")) || strings.HasPrefix(raw, "#")
+}
@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// fakeProviderHistory, sahte sağlayıcının bellekte tuttuğu en fazla mesaj sayısıdır.
+const fakeProviderHistory = 100
+
+// FakeProvider, mesajları göndermek yerine bellekte saklar. Yalnızca geliştirme ortamında
+// kullanılır; bellekte son fakeProviderHistory mesaj tutulur.
+type FakeProvider struct {
+	channel Channel
+	mu      sync.Mutex
+	sent    []Message
+	count   int
+}
+
+func NewFakeProvider(channel Channel) *FakeProvider {
+	return &FakeProvider{channel: channel}
+}
+
+func (p *FakeProvider) Name() string     { return "fake" }
+func (p *FakeProvider) Channel() Channel { return p.channel }
+
+func (p *FakeProvider) Send(ctx context.Context, message Message) (*Result, error) {
+	if message.To == "" {
+		return nil, ErrEmptyRecipient
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	p.sent = append(p.sent, message)
+	if len(p.sent) > fakeProviderHistory {
+		p.sent = append(p.sent[:0], p.sent[len(p.sent)-fakeProviderHistory:]...)
+	}
+	return &Result{ProviderMessageID: "fake-" + strconv.Itoa(p.count)}, nil
+}
+
+// Sent, bellekte tutulan son mesajların kopyasını döner.
+func (p *FakeProvider) Sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.sent...)
+}
+
+var _ Provider = (*FakeProvider)(nil)
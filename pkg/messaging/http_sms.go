@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPSMSConfig, JSON kabul eden genel bir HTTP SMS API'si için ayarlardır.
+type HTTPSMSConfig struct {
+	Endpoint string
+	APIKey   string
+	Sender   string
+	Timeout  time.Duration
+}
+
+// HTTPSMSProvider, mesajı {"to","from","message"} gövdesiyle Bearer yetkilendirmeli bir
+// HTTP uç noktasına gönderir. 2xx dışındaki yanıtlar hata kabul edilir.
+type HTTPSMSProvider struct {
+	config HTTPSMSConfig
+	client *http.Client
+}
+
+func NewHTTPSMSProvider(config HTTPSMSConfig) *HTTPSMSProvider {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &HTTPSMSProvider{config: config, client: &http.Client{Timeout: config.Timeout}}
+}
+
+func (p *HTTPSMSProvider) Name() string     { return "http_sms" }
+func (p *HTTPSMSProvider) Channel() Channel { return ChannelSMS }
+
+func (p *HTTPSMSProvider) Send(ctx context.Context, message Message) (*Result, error) {
+	if message.To == "" {
+		return nil, ErrEmptyRecipient
+	}
+	payload, err := json.Marshal(map[string]string{
+		"to":      message.To,
+		"from":    p.config.Sender,
+		"message": message.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("SMS sağlayıcısına ulaşılamadı: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("SMS sağlayıcısı %d döndü: %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		ID        string `json:"id"`
+		MessageID string `json:"message_id"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+	if parsed.ID == "" {
+		parsed.ID = parsed.MessageID
+	}
+	return &Result{ProviderMessageID: parsed.ID}, nil
+}
+
+var _ Provider = (*HTTPSMSProvider)(nil)
@@ -0,0 +1,34 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+)
+
+// Channel, mesajın iletildiği kanaldır.
+type Channel string
+
+const (
+	ChannelSMS      Channel = "sms"
+	ChannelWhatsApp Channel = "whatsapp"
+)
+
+var ErrEmptyRecipient = errors.New("alıcı telefon numarası boş olamaz")
+
+// Message, sağlayıcıya iletilecek tek bir mesajdır. To, E.164 biçiminde (+905xx...) olmalıdır.
+type Message struct {
+	To   string
+	Body string
+}
+
+// Result, sağlayıcının gönderim sonrasında döndürdüğü bilgidir.
+type Result struct {
+	ProviderMessageID string
+}
+
+// Provider, SMS veya WhatsApp gibi bir kanal üzerinden mesaj gönderen adaptördür.
+type Provider interface {
+	Name() string
+	Channel() Channel
+	Send(ctx context.Context, message Message) (*Result, error)
+}
@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultWhatsAppBaseURL = "https://graph.facebook.com/v19.0"
+
+// WhatsAppConfig, WhatsApp Business Cloud API ayarlarıdır. İşletme tarafından başlatılan
+// konuşmalar yalnızca onaylı şablonlarla yapılabildiğinden mesaj metni, tek gövde parametresi
+// alan TemplateName şablonuna parametre olarak gönderilir.
+type WhatsAppConfig struct {
+	BaseURL          string
+	AccessToken      string
+	PhoneNumberID    string
+	TemplateName     string
+	TemplateLanguage string
+	Timeout          time.Duration
+}
+
+type WhatsAppProvider struct {
+	config WhatsAppConfig
+	client *http.Client
+}
+
+func NewWhatsAppProvider(config WhatsAppConfig) *WhatsAppProvider {
+	if config.BaseURL == "" {
+		config.BaseURL = defaultWhatsAppBaseURL
+	}
+	if config.TemplateLanguage == "" {
+		config.TemplateLanguage = "tr"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &WhatsAppProvider{config: config, client: &http.Client{Timeout: config.Timeout}}
+}
+
+func (p *WhatsAppProvider) Name() string     { return "whatsapp_cloud" }
+func (p *WhatsAppProvider) Channel() Channel { return ChannelWhatsApp }
+
+func (p *WhatsAppProvider) Send(ctx context.Context, message Message) (*Result, error) {
+	if message.To == "" {
+		return nil, ErrEmptyRecipient
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                strings.TrimPrefix(message.To, "+"),
+		"type":              "template",
+		"template": map[string]interface{}{
+			"name":     p.config.TemplateName,
+			"language": map[string]string{"code": p.config.TemplateLanguage},
+			"components": []map[string]interface{}{{
+				"type": "body",
+				"parameters": []map[string]string{{
+					"type": "text",
+					// Şablon parametrelerinde satır sonuna izin verilmez.
+					"text": strings.Join(strings.Fields(message.Body), " "),
+				}},
+			}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimRight(p.config.BaseURL, "/") + "/" + p.config.PhoneNumberID + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.config.AccessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("WhatsApp API'sine ulaşılamadı: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("WhatsApp API %d döndü: %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+	result := &Result{}
+	if len(parsed.Messages) > 0 {
+		result.ProviderMessageID = parsed.Messages[0].ID
+	}
+	return result, nil
+}
+
+var _ Provider = (*WhatsAppProvider)(nil)
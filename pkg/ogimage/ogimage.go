@@ -0,0 +1,184 @@
+package ogimage
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	_ "image/jpeg"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp"
+)
+
+// Width ve Height, Open Graph önizlemeleri için önerilen 1.91:1 ölçüdür; WhatsApp, Facebook ve
+// X bu boyuttaki görseli kırpmadan gösterir.
+const (
+	Width  = 1200
+	Height = 630
+)
+
+const (
+	padding     = 72
+	photoSize   = 360
+	accentWidth = 16
+)
+
+// Preview, önizleme görselinde gösterilecek içeriktir. Photo boş değilse görselin sağında
+// kare olarak kırpılıp gösterilir; okunamazsa yok sayılır.
+type Preview struct {
+	Title    string
+	Subtitle string
+	Footer   string
+	Photo    string
+	Accent   color.Color
+}
+
+var (
+	background = color.RGBA{R: 0xfa, G: 0xfa, B: 0xf9, A: 0xff}
+	titleColor = color.RGBA{R: 0x1f, G: 0x29, B: 0x37, A: 0xff}
+	textColor  = color.RGBA{R: 0x4b, G: 0x55, B: 0x63, A: 0xff}
+	// DefaultAccent, organizasyon rengi olmayan önizlemelerde kullanılan marka rengidir.
+	DefaultAccent = color.RGBA{R: 0x84, G: 0xcc, B: 0x16, A: 0xff}
+)
+
+// Yazı tipleri bir kez ayrıştırılır; font.Face eşzamanlı kullanıma uygun olmadığından yüzler her
+// çizimde yeniden oluşturulur.
+var (
+	fontsOnce   sync.Once
+	fontsErr    error
+	boldFont    *opentype.Font
+	regularFont *opentype.Font
+)
+
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		if boldFont, fontsErr = opentype.Parse(gobold.TTF); fontsErr != nil {
+			return
+		}
+		regularFont, fontsErr = opentype.Parse(goregular.TTF)
+	})
+	return fontsErr
+}
+
+// Render, önizlemeyi PNG olarak yazar.
+func Render(w io.Writer, p Preview) error {
+	if err := loadFonts(); err != nil {
+		return err
+	}
+	titleFace, err := opentype.NewFace(boldFont, &opentype.FaceOptions{Size: 64, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer titleFace.Close()
+	textFace, err := opentype.NewFace(regularFont, &opentype.FaceOptions{Size: 34, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer textFace.Close()
+	accent := p.Accent
+	if accent == nil {
+		accent = DefaultAccent
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, accentWidth, Height), image.NewUniform(accent), image.Point{}, draw.Src)
+
+	textWidth := Width - 2*padding - accentWidth
+	if photo := loadPhoto(p.Photo); photo != nil {
+		x := Width - padding - photoSize
+		y := (Height - photoSize) / 2
+		draw.CatmullRom.Scale(img, image.Rect(x, y, x+photoSize, y+photoSize), photo, squareCrop(photo.Bounds()), draw.Over, nil)
+		textWidth -= photoSize + padding/2
+	}
+
+	left := padding + accentWidth
+	y := padding + 64
+	for _, line := range wrap(titleFace, p.Title, textWidth, 3) {
+		drawText(img, titleFace, titleColor, left, y, line)
+		y += 78
+	}
+	if p.Subtitle != "" {
+		y += 12
+		for _, line := range wrap(textFace, p.Subtitle, textWidth, 3) {
+			drawText(img, textFace, textColor, left, y, line)
+			y += 46
+		}
+	}
+	if p.Footer != "" {
+		drawText(img, textFace, accent, left, Height-padding, p.Footer)
+	}
+
+	return png.Encode(w, img)
+}
+
+func drawText(dst draw.Image, face font.Face, c color.Color, x, y int, text string) {
+	d := &font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(text)
+}
+
+// wrap, metni kelime sınırlarından verilen genişliğe böler; maxLines aşılırsa son satır
+// üç noktayla kısaltılır.
+func wrap(face font.Face, text string, width, maxLines int) []string {
+	limit := fixed.I(width)
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		candidate := strings.TrimSpace(current + " " + word)
+		if current != "" && font.MeasureString(face, candidate) > limit {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current = candidate
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		for len(last) > 0 && font.MeasureString(face, string(last)+"…") > limit {
+			last = last[:len(last)-1]
+		}
+		lines[maxLines-1] = strings.TrimSpace(string(last)) + "…"
+	}
+	return lines
+}
+
+func loadPhoto(path string) image.Image {
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	photo, _, err := image.Decode(f)
+	if err != nil {
+		return nil
+	}
+	return photo
+}
+
+// squareCrop, görselin ortasından kare bir alan seçer.
+func squareCrop(r image.Rectangle) image.Rectangle {
+	size := r.Dx()
+	if r.Dy() < size {
+		size = r.Dy()
+	}
+	x := r.Min.X + (r.Dx()-size)/2
+	y := r.Min.Y + (r.Dy()-size)/2
+	return image.Rect(x, y, x+size, y+size)
+}
@@ -0,0 +1,271 @@
+// Package pdf, A4 sayfalara yukarıdan aşağıya akan metin ve görsel yerleştiren küçük bir PDF
+// yazıcısıdır. Yazı tipi olarak her PDF okuyucuda bulunan Helvetica ailesi kullanılır; Türkçe
+// karakterler Windows-1254 düzenine karşılık gelen bir kodlamayla yazılır.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"strings"
+
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+	margin     = 56.0
+	lineFactor = 1.4
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+	Italic
+)
+
+var fontNames = [...]string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"}
+
+type Align int
+
+const (
+	Left Align = iota
+	Center
+)
+
+// Style, yazılan metnin yazı tipini, boyutunu, hizasını ve gri tonunu (0 siyah, 1 beyaz) belirler.
+type Style struct {
+	Font  Font
+	Size  float64
+	Align Align
+	Gray  float64
+}
+
+type pdfImage struct {
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+}
+
+// Document, oluşturulmakta olan belgedir; sıfır değeri kullanılamaz, New ile oluşturulur.
+type Document struct {
+	pages  []*bytes.Buffer
+	images []pdfImage
+	y      float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.AddPage()
+	return d
+}
+
+// AddPage, yeni bir sayfa açar ve yazma konumunu sayfanın başına taşır.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// EnsureSpace, kalan alan height'tan azsa yeni sayfaya geçer; birlikte kalması gereken
+// blokların sayfa sonunda bölünmemesi için kullanılır.
+func (d *Document) EnsureSpace(height float64) {
+	if d.y-height < margin {
+		d.AddPage()
+	}
+}
+
+func (d *Document) Space(height float64) {
+	d.y -= height
+	if d.y < margin {
+		d.AddPage()
+	}
+}
+
+// Rule, içerik genişliğinde ince yatay bir çizgi çizer.
+func (d *Document) Rule() {
+	d.EnsureSpace(8)
+	d.y -= 4
+	fmt.Fprintf(d.page(), "0.8 G 0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 4
+}
+
+// Text, metni içerik genişliğine sığacak şekilde satırlara bölerek yazar; satır sonları korunur.
+func (d *Document) Text(text string, style Style) {
+	if style.Size <= 0 {
+		style.Size = 11
+	}
+	lineHeight := style.Size * lineFactor
+	for _, line := range wrap(text, style, pageWidth-2*margin) {
+		d.EnsureSpace(lineHeight)
+		d.y -= lineHeight
+		x := margin
+		if style.Align == Center {
+			x = (pageWidth - textWidth(line, style)) / 2
+		}
+		fmt.Fprintf(d.page(), "BT %.2f g /F%d %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+			style.Gray, int(style.Font)+1, style.Size, x, d.y+style.Size*0.3, escape(encode(line)))
+	}
+}
+
+// Image, JPEG, PNG veya WebP görseli içerik genişliğini ve maxHeight'ı aşmayacak şekilde
+// ortalayarak yerleştirir. JPEG dosyaları yeniden sıkıştırılmadan gömülür.
+func (d *Document) Image(data []byte, maxHeight float64) error {
+	img, err := loadImage(data)
+	if err != nil {
+		return err
+	}
+	scale := min((pageWidth-2*margin)/float64(img.width), maxHeight/float64(img.height), 1)
+	width, height := float64(img.width)*scale, float64(img.height)*scale
+
+	d.images = append(d.images, img)
+	d.EnsureSpace(height)
+	d.y -= height
+	fmt.Fprintf(d.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, (pageWidth-width)/2, d.y, len(d.images))
+	return nil
+}
+
+// WriteTo, belgeyi PDF olarak yazar.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			out.WriteString("stream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream\n")
+		}
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Nesne sırası: 1 katalog, 2 sayfa ağacı, 3 kodlama, ardından yazı tipleri, görseller ve
+	// her sayfa için sayfa ile içerik nesneleri.
+	fontStart := 4
+	imageStart := fontStart + len(fontNames)
+	pageStart := imageStart + len(d.images)
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageStart+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)), nil)
+	object("<< /Type /Encoding /BaseEncoding /WinAnsiEncoding /Differences [208 /Gbreve 221 /Idotaccent 222 /Scedilla 240 /gbreve 253 /dotlessi 254 /scedilla] >>", nil)
+	for _, name := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding 3 0 R >>", name), nil)
+	}
+	for _, img := range d.images {
+		object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s /Length %d >>",
+			img.width, img.height, img.colorSpace, img.filter, len(img.data)), img.data)
+	}
+
+	var fonts, xobjects strings.Builder
+	for i := range fontNames {
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, fontStart+i)
+	}
+	for i := range d.images {
+		fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", i+1, imageStart+i)
+	}
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s>> /XObject << %s>> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fonts.String(), xobjects.String(), pageStart+2*i+1), nil)
+		compressed := deflate(content.Bytes())
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", len(compressed)), compressed)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.WriteTo(w)
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func loadImage(data []byte) (pdfImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return pdfImage{}, err
+	}
+	if format == "jpeg" && config.ColorModel != color.CMYKModel {
+		colorSpace := "DeviceRGB"
+		if config.ColorModel == color.GrayModel {
+			colorSpace = "DeviceGray"
+		}
+		return pdfImage{width: config.Width, height: config.Height, colorSpace: colorSpace, filter: "DCTDecode", data: data}, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return pdfImage{}, err
+	}
+	// Saydam alanlar beyaz zemine oturtulur; PDF'e yalnızca RGB örnekler yazılır.
+	bounds := decoded.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(canvas, canvas.Bounds(), decoded, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: 85}); err != nil {
+		return pdfImage{}, err
+	}
+	return pdfImage{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB", filter: "DCTDecode", data: buf.Bytes()}, nil
+}
+
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// wrap, metni verilen genişliğe sığan satırlara böler; genişliğe sığmayan uzun kelimeler
+// harf harf bölünür.
+func wrap(text string, style Style, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(candidate, style) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			for _, r := range word {
+				if line != "" && textWidth(line+string(r), style) > maxWidth {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
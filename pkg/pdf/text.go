@@ -0,0 +1,89 @@
+package pdf
+
+import "strings"
+
+// turkishCodes, WinAnsi'de bulunmayan Türkçe harflerin belgedeki kodlama farklarıyla eşlendiği
+// baytlardır (Windows-1254 ile aynı konumlar).
+var turkishCodes = map[rune]byte{
+	'Ğ': 208, 'İ': 221, 'Ş': 222, 'ğ': 240, 'ı': 253, 'ş': 254,
+}
+
+// winAnsiExtra, WinAnsi'nin 0x80-0x9F aralığındaki sık kullanılan noktalama işaretleridir.
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// helveticaWidths, Helvetica'nın 32-126 arasındaki karakter genişlikleridir (1000 birim).
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var accentWidths = map[rune]int{
+	'Ç': 722, 'Ğ': 778, 'İ': 278, 'Ö': 778, 'Ş': 667, 'Ü': 722, 'Â': 667, 'Î': 278, 'Û': 722,
+	'ç': 500, 'ğ': 556, 'ı': 278, 'ö': 556, 'ş': 500, 'ü': 556, 'â': 556, 'î': 278, 'û': 556,
+}
+
+// encode, metni belgedeki yazı tipi kodlamasına çevirir; karşılığı olmayan karakterler "?" olur.
+func encode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch code, ok := turkishCodes[r]; {
+		case ok:
+			out = append(out, code)
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r < 127:
+			out = append(out, byte(r))
+		case winAnsiExtra[r] != 0:
+			out = append(out, winAnsiExtra[r])
+		case r >= 0xA0 && r <= 0xFF && !isReplacedLatin1(r):
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// isReplacedLatin1, kodlama farklarıyla Türkçe harflere ayrılan Latin-1 konumlarını bildirir.
+func isReplacedLatin1(r rune) bool {
+	return r == 'Ð' || r == 'Ý' || r == 'Þ' || r == 'ð' || r == 'ý' || r == 'þ'
+}
+
+func escape(data []byte) string {
+	var b strings.Builder
+	for _, c := range data {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// textWidth, metnin punto cinsinden yaklaşık genişliğidir. Kalın yazı için genişlikler biraz
+// artırılır; satır kaydırma için bu yaklaşım yeterlidir.
+func textWidth(text string, style Style) float64 {
+	total := 0
+	for _, r := range text {
+		switch {
+		case r >= 32 && r < 127:
+			total += helveticaWidths[r-32]
+		case accentWidths[r] != 0:
+			total += accentWidths[r]
+		default:
+			total += 556
+		}
+	}
+	width := float64(total) * style.Size / 1000
+	if style.Font == Bold {
+		width *= 1.08
+	}
+	return width
+}
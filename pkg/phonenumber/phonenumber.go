@@ -0,0 +1,46 @@
+package phonenumber
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidPhoneNumber = errors.New("geçersiz telefon numarası")
+
+const turkeyCountryCode = "90"
+
+// NormalizeTR, farklı biçimlerde girilmiş Türkiye telefon numaralarını
+// (0532 123 45 67, +90 532 123 4567, 0090..., 5321234567 vb.)
+// E.164 biçimine (+905321234567) çevirir.
+func NormalizeTR(raw string) (string, error) {
+	var digits strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	number := digits.String()
+
+	switch {
+	case strings.HasPrefix(number, "00"+turkeyCountryCode) && len(number) == 14:
+		number = number[4:]
+	case strings.HasPrefix(number, turkeyCountryCode) && len(number) == 12:
+		number = number[2:]
+	case strings.HasPrefix(number, "0") && len(number) == 11:
+		number = number[1:]
+	}
+
+	if len(number) != 10 {
+		return "", ErrInvalidPhoneNumber
+	}
+	// Ulusal numaralar alan kodu (2xx, 3xx, 4xx) veya GSM (5xx) ile başlar.
+	if number[0] < '2' || number[0] > '5' {
+		return "", ErrInvalidPhoneNumber
+	}
+	return "+" + turkeyCountryCode + number, nil
+}
+
+// IsMobileTR, normalize edilmiş numaranın bir GSM numarası olup olmadığını döner.
+func IsMobileTR(normalized string) bool {
+	return strings.HasPrefix(normalized, "+"+turkeyCountryCode+"5") && len(normalized) == 13
+}
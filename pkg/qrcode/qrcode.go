@@ -0,0 +1,498 @@
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// QuietZone, okuyucuların kodu bulabilmesi için çevresinde bırakılan boş modül sayısıdır.
+const QuietZone = 4
+
+var ErrDataTooLong = errors.New("veri QR koda sığmayacak kadar uzun")
+
+// versionInfo, M hata düzeltme seviyesinde bir QR sürümünün blok yapısıdır. Groups her grup
+// için {blok sayısı, bloktaki veri kelimesi} çiftlerini tutar.
+type versionInfo struct {
+	ecPerBlock int
+	groups     [][2]int
+	alignment  []int
+}
+
+// versions, 1-10 arası sürümlerin M seviyesi tablolarıdır (ISO/IEC 18004 Tablo 9 ve Ek E).
+// Bilet bağlantıları gibi kısa veriler için 10. sürümün ~210 baytlık kapasitesi yeterlidir.
+var versions = []versionInfo{
+	1:  {10, [][2]int{{1, 16}}, nil},
+	2:  {16, [][2]int{{1, 28}}, []int{6, 18}},
+	3:  {26, [][2]int{{1, 44}}, []int{6, 22}},
+	4:  {18, [][2]int{{2, 32}}, []int{6, 26}},
+	5:  {24, [][2]int{{2, 43}}, []int{6, 30}},
+	6:  {16, [][2]int{{4, 27}}, []int{6, 34}},
+	7:  {18, [][2]int{{4, 31}}, []int{6, 22, 38}},
+	8:  {22, [][2]int{{2, 38}, {2, 39}}, []int{6, 24, 42}},
+	9:  {22, [][2]int{{3, 36}, {2, 37}}, []int{6, 26, 46}},
+	10: {26, [][2]int{{4, 43}, {1, 44}}, []int{6, 28, 50}},
+}
+
+func (v versionInfo) dataCodewords() int {
+	total := 0
+	for _, group := range v.groups {
+		total += group[0] * group[1]
+	}
+	return total
+}
+
+// Code, kodlanmış bir QR matrisidir. Modüller satır-sütun sırasıyla tutulur; true koyu modüldür.
+type Code struct {
+	Size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Dark, (x, y) konumundaki modülün koyu olup olmadığını döner.
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Encode, veriyi bayt kipinde ve M hata düzeltme seviyesinde sığdığı en küçük sürümle kodlar.
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for v := 1; v < len(versions); v++ {
+		if 4+countBits(v)+len(data)*8 <= versions[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	codewords := addErrorCorrection(version, encodeData(version, data))
+
+	size := 17 + 4*version
+	code := &Code{Size: size, modules: newGrid(size), isFunction: newGrid(size)}
+	code.drawFunctionPatterns(version)
+	code.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		code.applyMask(mask)
+		code.drawFormatBits(mask)
+		if penalty := code.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		code.applyMask(mask)
+	}
+	code.applyMask(bestMask)
+	code.drawFormatBits(bestMask)
+	return code, nil
+}
+
+// WritePNG, kodu her modül scale piksel olacak şekilde sessiz bölgesiyle birlikte PNG olarak yazar.
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			left, top := (x+QuietZone)*scale, (y+QuietZone)*scale
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(left+dx, top+dy, 1)
+				}
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// countBits, bayt kipinde karakter sayısı alanının bit uzunluğudur.
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData, kip göstergesi, uzunluk, veri, sonlandırıcı ve dolgu baytlarından oluşan veri
+// kelimelerini üretir.
+func encodeData(version int, data []byte) []byte {
+	capacity := versions[version].dataCodewords()
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	for i := 0; i < 4 && len(bits) < capacity*8; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+
+	result := bits.bytes()
+	for pad := 0; len(result) < capacity; pad++ {
+		if pad%2 == 0 {
+			result = append(result, 0xEC)
+		} else {
+			result = append(result, 0x11)
+		}
+	}
+	return result
+}
+
+// addErrorCorrection, veriyi bloklara böler, her bloğun Reed-Solomon kelimelerini hesaplar ve
+// blokları standarttaki sırayla iç içe geçirir.
+func addErrorCorrection(version int, data []byte) []byte {
+	info := versions[version]
+	divisor := reedSolomonDivisor(info.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, group := range info.groups {
+		for i := 0; i < group[0]; i++ {
+			block := data[offset : offset+group[1]]
+			offset += group[1]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+		}
+	}
+
+	maxData := info.groups[len(info.groups)-1][1]
+	result := make([]byte, 0, len(data)+len(ecBlocks)*info.ecPerBlock)
+	for i := 0; i < maxData; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns(version int) {
+	for i := 0; i < c.Size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	alignment := versions[version].alignment
+	last := len(alignment) - 1
+	for i, cx := range alignment {
+		for j, cy := range alignment {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(cx, cy)
+		}
+	}
+
+	// Biçim alanları maske seçilene kadar ayrılmış olarak işaretlenir.
+	c.drawFormatBits(0)
+	c.drawVersion(version)
+}
+
+// drawFinder, konum işaretini çevresindeki ayırıcı şeritle birlikte çizer.
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits, hata düzeltme seviyesi ve maskeyi taşıyan 15 bitlik biçim bilgisini iki
+// kopya halinde yazar.
+func (c *Code) drawFormatBits(mask int) {
+	// M seviyesinin biçim göstergesi 00'dır.
+	data := mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+// drawVersion, 7. ve üzeri sürümlerde sürüm numarasını iki köşeye yazar.
+func (c *Code) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords, kelimeleri sağ alttan başlayarak iki sütunluk şeritler halinde zikzak yerleştirir.
+// Kalan bitler açık modül olarak bırakılır.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if c.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask, maskeyi veri modüllerine XOR ile uygular; iki kez uygulamak maskeyi geri alır.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			default:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty, standarttaki dört kurala göre maskenin okunabilirlik cezasını hesaplar.
+func (c *Code) penalty() int {
+	score := 0
+	for _, horizontal := range []bool{true, false} {
+		for a := 0; a < c.Size; a++ {
+			line := make([]bool, c.Size)
+			for b := 0; b < c.Size; b++ {
+				if horizontal {
+					line[b] = c.modules[a][b]
+				} else {
+					line[b] = c.modules[b][a]
+				}
+			}
+			score += linePenalty(line)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x < c.Size-1 && y < c.Size-1 {
+				same := c.modules[y][x]
+				if same == c.modules[y][x+1] && same == c.modules[y+1][x] && same == c.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+	total := c.Size * c.Size
+	score += abs(dark*100/total-50) / 5 * 10
+	return score
+}
+
+var finderLike = []bool{true, false, true, true, true, false, true}
+
+// linePenalty, bir satır ya da sütundaki uzun tek renk dizilerini ve konum işaretine benzeyen
+// desenleri cezalandırır.
+func linePenalty(line []bool) int {
+	score := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+
+	for i := 0; i+len(finderLike) <= len(line); i++ {
+		matches := true
+		for j, dark := range finderLike {
+			if line[i+j] != dark {
+				matches = false
+				break
+			}
+		}
+		if matches && (lightRun(line, i-4, i) || lightRun(line, i+len(finderLike), i+len(finderLike)+4)) {
+			score += 40
+		}
+	}
+	return score
+}
+
+// lightRun, [from, to) aralığının açık modüllerden oluşup oluşmadığını döner; matris dışı
+// sessiz bölge sayıldığından açıktır.
+func lightRun(line []bool, from, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < len(line) && line[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+// reedSolomonDivisor, verilen dereceli Reed-Solomon üreteç polinomunun katsayılarını döner
+// (baştaki 1 katsayısı hariç).
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply, x^8 + x^4 + x^3 + x^2 + 1 indirgeme polinomlu GF(256) üzerinde çarpımdır.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
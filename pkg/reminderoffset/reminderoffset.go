@@ -0,0 +1,88 @@
+package reminderoffset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MaxOffsets = 5
+	MinOffset  = time.Hour
+	MaxOffset  = 60 * 24 * time.Hour
+)
+
+var ErrInvalidOffset = errors.New("geçersiz hatırlatma zamanı")
+
+// Parse, virgülle ayrılmış hatırlatma zamanlarını ("7d, 1d, 3h" veya Türkçe "7g, 1g, 3s")
+// etkinlikten önceki sürelere çevirir. Sonuç büyükten küçüğe sıralı ve tekrarsızdır.
+// Boş metin için boş liste döner.
+func Parse(raw string) ([]time.Duration, error) {
+	seen := make(map[time.Duration]bool)
+	var offsets []time.Duration
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		offset, err := parseOne(part)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	if len(offsets) > MaxOffsets {
+		return nil, fmt.Errorf("en fazla %d hatırlatma zamanı girilebilir", MaxOffsets)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return offsets, nil
+}
+
+func parseOne(part string) (time.Duration, error) {
+	unit := part[len(part)-1:]
+	value, err := strconv.Atoi(strings.TrimSpace(part[:len(part)-1]))
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffset, part)
+	}
+
+	var offset time.Duration
+	switch unit {
+	case "d", "g":
+		offset = time.Duration(value) * 24 * time.Hour
+	case "h", "s":
+		offset = time.Duration(value) * time.Hour
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffset, part)
+	}
+	if offset < MinOffset || offset > MaxOffset {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffset, part)
+	}
+	return offset, nil
+}
+
+// Format, süreleri veritabanında saklanan "7d,1d,3h" biçimine çevirir.
+func Format(offsets []time.Duration) string {
+	parts := make([]string, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset%(24*time.Hour) == 0 {
+			parts = append(parts, strconv.Itoa(int(offset/(24*time.Hour)))+"d")
+		} else {
+			parts = append(parts, strconv.Itoa(int(offset/time.Hour))+"h")
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Label, süreyi kullanıcıya gösterilecek biçimde ("7 gün", "3 saat") döner.
+func Label(offset time.Duration) string {
+	if offset%(24*time.Hour) == 0 {
+		return strconv.Itoa(int(offset/(24*time.Hour))) + " gün"
+	}
+	return strconv.Itoa(int(offset/time.Hour)) + " saat"
+}
@@ -15,12 +15,18 @@ const (
 	FlashSuccessKeyView = "Success"
 	FlashErrorKeyView   = "Error"
 	FormDataKey         = "FormData"
+
+	UnreadNotificationsKeyView  = "UnreadNotificationCount"
+	UnreadNotificationsLocalKey = "unreadNotificationCount"
 )
 
 func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	renderData := make(fiber.Map)
 
 	renderData[CsrfTokenKey] = c.Locals("csrf")
+	if count := c.Locals(UnreadNotificationsLocalKey); count != nil {
+		renderData[UnreadNotificationsKeyView] = count
+	}
 
 	flashData, flashErr := flashmessages.GetFlashMessages(c)
 	if flashErr != nil {
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"davet.link/configs/logconfig"
+
+	"go.uber.org/zap"
+)
+
+// Job, zamanlayıcının periyodik olarak çalıştırdığı iştir.
+type Job func(ctx context.Context) error
+
+// Every, job'u ilk olarak hemen, ardından her interval sonunda ctx iptal edilene kadar çalıştırır.
+// Hata ve panic durumları loglanır; zamanlayıcı çalışmaya devam eder. Engelleyici olduğundan
+// ayrı bir goroutine içinde çağrılmalıdır.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logconfig.Log.Info("Zamanlanmış iş başlatıldı", zap.String("job", name), zap.Duration("interval", interval))
+	for {
+		run(ctx, name, job)
+		select {
+		case <-ctx.Done():
+			logconfig.Log.Info("Zamanlanmış iş durduruldu", zap.String("job", name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func run(ctx context.Context, name string, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logconfig.Log.Error("Zamanlanmış iş panic ile sonlandı", zap.String("job", name), zap.Any("panic_info", r))
+		}
+	}()
+
+	start := time.Now()
+	if err := job(ctx); err != nil {
+		logconfig.Log.Error("Zamanlanmış iş başarısız oldu", zap.String("job", name), zap.Error(err))
+		return
+	}
+	logconfig.Log.Debug("Zamanlanmış iş tamamlandı", zap.String("job", name), zap.Duration("duration", time.Since(start)))
+}
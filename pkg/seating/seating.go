@@ -0,0 +1,50 @@
+package seating
+
+import "sort"
+
+// Table, yerleştirmede kullanılan masanın boş koltuk sayısıdır.
+type Table struct {
+	ID   uint
+	Free int
+}
+
+// Party, aynı masada oturması gereken gruptur: bir katılımcı ve yanında getirdiği kişiler.
+type Party struct {
+	ID   uint
+	Size int
+}
+
+// Plan, grupları bölmeden masalara yerleştirir ve grup ID'sinden masa ID'sine eşlemeyi döner.
+// Gruplar büyükten küçüğe sıralanır ve her grup sığdığı masalardan en az boş koltuğu kalana
+// oturtulur; böylece kalabalık aileler için geniş masalar açık kalır. Hiçbir masaya sığmayan
+// grupların ID'leri ayrıca döner. Eşit durumlarda masaların verilen sırası korunur.
+func Plan(tables []Table, parties []Party) (map[uint]uint, []uint) {
+	free := make([]int, len(tables))
+	for i, table := range tables {
+		free[i] = table.Free
+	}
+
+	sorted := make([]Party, len(parties))
+	copy(sorted, parties)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Size > sorted[j].Size
+	})
+
+	assignments := make(map[uint]uint, len(sorted))
+	var unplaced []uint
+	for _, party := range sorted {
+		best := -1
+		for i := range tables {
+			if free[i] >= party.Size && (best < 0 || free[i] < free[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			unplaced = append(unplaced, party.ID)
+			continue
+		}
+		free[best] -= party.Size
+		assignments[party.ID] = tables[best].ID
+	}
+	return assignments, unplaced
+}
@@ -0,0 +1,123 @@
+package seo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultTitle       = "davet.link | Dijital Davetiye ve Kartvizit Çözümleri"
+	defaultDescription = "davet.link: Modern Dijital Davetiyeler ve Profesyonel Dijital Kartvizitler. Etkinliklerinizi ve profesyonel kimliğinizi dijital dünyaya taşıyın."
+	defaultURL         = "https://davet.link"
+	defaultImage       = "/favicon.ico"
+)
+
+// Meta, website şablonunun <head> bölümüne yazılan başlık, açıklama, Open Graph/Twitter
+// etiketleri ve JSON-LD verisidir. Boş alanlar için site genelindeki varsayılanlar kullanılır.
+type Meta struct {
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	// Type, og:type değeridir (website, profile, article).
+	Type    string
+	NoIndex bool
+	JSONLD  template.JS
+}
+
+// WithDefaults, boş alanları site genelindeki varsayılanlarla doldurur.
+func (m Meta) WithDefaults() Meta {
+	if m.Title == "" {
+		m.Title = defaultTitle
+	}
+	if m.Description == "" {
+		m.Description = defaultDescription
+	}
+	if m.URL == "" {
+		m.URL = defaultURL
+	}
+	if m.Image == "" {
+		m.Image = defaultImage
+	}
+	if m.Type == "" {
+		m.Type = "website"
+	}
+	return m
+}
+
+// HasPreview, görselin sosyal medya önizlemesi için yeterli büyüklükte olup olmadığını
+// (varsayılan favicon dışında bir görsel) bildirir.
+func (m Meta) HasPreview() bool {
+	return m.Image != "" && m.Image != defaultImage
+}
+
+// JSONLD, şema verisini <script type="application/ld+json"> içine güvenle yazılabilecek
+// biçimde kodlar; encoding/json <, > ve & karakterlerini kaçışladığı için etiket kapatılamaz.
+func JSONLD(schema map[string]any) template.JS {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	return template.JS(data)
+}
+
+// Description, serbest metni tek satıra indirir ve arama sonuçlarında kesilmeyecek
+// uzunluğa (160 karakter) kısaltır.
+func Description(text string) string {
+	const maxLength = 160
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	runes := []rune(text)[:maxLength-1]
+	if i := strings.LastIndex(string(runes), " "); i > maxLength/2 {
+		return string(runes)[:i] + "…"
+	}
+	return string(runes) + "…"
+}
+
+// URL, site haritasındaki tek bir adrestir.
+type URL struct {
+	Loc      string
+	LastMod  time.Time
+	Priority float64
+}
+
+type urlset struct {
+	XMLName xml.Name   `xml:"urlset"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	URLs    []xmlEntry `xml:"url"`
+}
+
+type xmlEntry struct {
+	Loc      string `xml:"loc"`
+	LastMod  string `xml:"lastmod,omitempty"`
+	Priority string `xml:"priority,omitempty"`
+}
+
+// WriteSitemap, adresleri sitemaps.org 0.9 biçiminde yazar.
+func WriteSitemap(w io.Writer, urls []URL) error {
+	set := urlset{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, u := range urls {
+		entry := xmlEntry{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			entry.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		if u.Priority > 0 {
+			entry.Priority = strconv.FormatFloat(min(u.Priority, 1), 'f', 1, 64)
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(set)
+}
@@ -0,0 +1,169 @@
+package slugpolicy
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrTooShort          = errors.New("kullanıcı adı çok kısa")
+	ErrTooLong           = errors.New("kullanıcı adı çok uzun")
+	ErrInvalidCharacters = errors.New("kullanıcı adı yalnızca harf, rakam, tire, alt çizgi ve nokta içerebilir; harf veya rakamla başlayıp bitmelidir")
+	ErrReserved          = errors.New("bu kullanıcı adı sistem tarafından ayrılmış")
+	ErrBlocked           = errors.New("bu kullanıcı adı kullanılamaz")
+)
+
+var (
+	validSlug      = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9._-]*[a-z0-9])?$`)
+	invalidChars   = regexp.MustCompile(`[^a-z0-9._-]+`)
+	repeatedDashes = regexp.MustCompile(`-{2,}`)
+	separators     = regexp.MustCompile(`[._-]+`)
+)
+
+var transliterations = strings.NewReplacer(
+	"ç", "c", "Ç", "c", "ğ", "g", "Ğ", "g", "ı", "i", "I", "i", "İ", "i",
+	"ö", "o", "Ö", "o", "ş", "s", "Ş", "s", "ü", "u", "Ü", "u",
+	"â", "a", "Â", "a", "î", "i", "Î", "i", "û", "u", "Û", "u",
+)
+
+// defaultReserved, rotalardan türetilemeyen ama kullanıcıya verilmemesi gereken adlardır.
+var defaultReserved = []string{
+	"admin", "administrator", "api", "app", "assets", "auth", "dashboard", "davet", "davetlink",
+	"davet-link", "destek", "help", "login", "logout", "mail", "panel", "register", "root",
+	"settings", "signup", "static", "support", "system", "uploads", "www", "yardim", "yonetim",
+}
+
+// defaultBlocklist, kullanıcı adının herhangi bir parçası olarak kullanılamayacak kelimelerdir.
+// SLUG_BLOCKLIST ortam değişkeniyle genişletilir.
+var defaultBlocklist = []string{
+	"amk", "aq", "fuck", "orospu", "pezevenk", "shit", "siktir", "yarrak",
+}
+
+// Policy, kart kullanıcı adları için karakter, uzunluk, ayrılmış kelime ve yasaklı kelime
+// kurallarını tutar. Eşzamanlı kullanıma uygundur.
+type Policy struct {
+	mu        sync.RWMutex
+	minLength int
+	maxLength int
+	reserved  map[string]struct{}
+	blocklist []string
+}
+
+// Default, uygulama genelinde kullanılan politikadır; main içinde ortam değişkenleri ve
+// kayıtlı rotalarla yapılandırılır.
+var Default = New(3, 64)
+
+func New(minLength, maxLength int) *Policy {
+	p := &Policy{minLength: minLength, maxLength: maxLength, reserved: make(map[string]struct{})}
+	p.Reserve(defaultReserved...)
+	p.Block(defaultBlocklist...)
+	return p
+}
+
+func (p *Policy) SetMinLength(minLength int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.minLength = minLength
+}
+
+func (p *Policy) MinLength() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.minLength
+}
+
+// Reserve, verilen kelimeleri ayrılmış kelimelere ekler.
+func (p *Policy) Reserve(words ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, word := range words {
+		if word = Normalize(word); word != "" {
+			p.reserved[word] = struct{}{}
+		}
+	}
+}
+
+// Block, verilen kelimeleri yasaklı kelimelere ekler.
+func (p *Policy) Block(words ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, word := range words {
+		if word = separators.ReplaceAllString(Normalize(word), ""); word != "" {
+			p.blocklist = append(p.blocklist, word)
+		}
+	}
+}
+
+// Check, normalize edilmiş kullanıcı adını politikaya göre doğrular.
+func (p *Policy) Check(slug string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(slug) < p.minLength {
+		return ErrTooShort
+	}
+	if len(slug) > p.maxLength {
+		return ErrTooLong
+	}
+	if !validSlug.MatchString(slug) {
+		return ErrInvalidCharacters
+	}
+	if _, ok := p.reserved[slug]; ok {
+		return ErrReserved
+	}
+	if p.isBlocked(slug) {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// isBlocked, yasaklı kelimeyi ayraçlarla bölünmüş parçalarda tam eşleşme olarak arar; beş
+// harften uzun kelimeler ayraçlar atıldıktan sonra içerik olarak da aranır. Böylece kısa
+// kelimeler masum adları ("sikke", "aqua") engellemez.
+func (p *Policy) isBlocked(slug string) bool {
+	tokens := separators.Split(slug, -1)
+	compact := separators.ReplaceAllString(slug, "")
+	for _, word := range p.blocklist {
+		for _, token := range tokens {
+			if token == word {
+				return true
+			}
+		}
+		if len(word) >= 5 && strings.Contains(compact, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize, serbest metni kullanıcı adı biçimine getirir: Türkçe karakterler ASCII'ye çevrilir,
+// harfler küçültülür, boşluklar tireye dönüşür ve izin verilmeyen karakterler atılır.
+func Normalize(raw string) string {
+	slug := transliterations.Replace(strings.TrimSpace(raw))
+	slug = strings.ToLower(slug)
+	slug = strings.Join(strings.Fields(slug), "-")
+	slug = invalidChars.ReplaceAllString(slug, "")
+	slug = repeatedDashes.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "._-")
+}
+
+// ReservedFromPaths, kayıtlı rota yollarının ilk sabit parçalarını döner
+// ("/panel/cards" -> "panel"). Parametre ve joker içeren parçalar atlanır.
+func ReservedFromPaths(paths []string) []string {
+	seen := make(map[string]struct{})
+	var words []string
+	for _, path := range paths {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		if segment == "" || strings.ContainsAny(segment, ":*+") {
+			continue
+		}
+		if _, ok := seen[segment]; ok {
+			continue
+		}
+		seen[segment] = struct{}{}
+		words = append(words, segment)
+	}
+	return words
+}
@@ -0,0 +1,94 @@
+package socialprofile
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidURL     = errors.New("geçersiz bağlantı")
+	ErrDomainMismatch = errors.New("bağlantı bu platforma ait değil")
+	ErrInvalidHandle  = errors.New("geçersiz kullanıcı adı")
+)
+
+// patterns, derlenmiş kullanıcı adı desenlerini önbellekler.
+var patterns sync.Map
+
+// Normalize, "@kullanici", "kullanici" ya da tam profil adresi olarak girilen değeri platformun
+// kanonik profil adresine (baseURL + kullanıcı adı) çevirir. baseURL boşsa platform için kural
+// tanımlanmamıştır; değer yalnızca http(s) adresi olarak doğrulanır.
+func Normalize(raw, baseURL, handlePattern string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if baseURL == "" {
+		return normalizeURL(raw)
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", ErrInvalidURL
+	}
+
+	// Kullanıcı adları "/" içermez; "/" varsa değer bir profil adresidir.
+	handle := raw
+	if strings.Contains(raw, "/") {
+		link, err := normalizeURL(raw)
+		if err != nil {
+			return "", err
+		}
+		u, _ := url.Parse(link)
+		if hostKey(u.Host) != hostKey(base.Host) {
+			return "", ErrDomainMismatch
+		}
+		path := strings.TrimPrefix(u.Path, "/")
+		basePath := strings.TrimPrefix(base.Path, "/")
+		if !strings.HasPrefix(path, basePath) {
+			return "", ErrInvalidHandle
+		}
+		handle, _, _ = strings.Cut(strings.TrimPrefix(path, basePath), "/")
+	}
+	handle = strings.TrimPrefix(handle, "@")
+
+	if handle == "" || !matchHandle(handlePattern, handle) {
+		return "", ErrInvalidHandle
+	}
+	return baseURL + handle, nil
+}
+
+// normalizeURL, şemasız girilen adreslere https ekler ve adresin http(s) olduğunu doğrular.
+func normalizeURL(raw string) (string, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !strings.Contains(u.Host, ".") {
+		return "", ErrInvalidURL
+	}
+	return u.String(), nil
+}
+
+// hostKey, www., m. ve mobile. öneklerini atarak aynı sitenin alt alan adlarını eşitler.
+func hostKey(host string) string {
+	host = strings.ToLower(host)
+	for _, prefix := range []string{"www.", "m.", "mobile."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	return host
+}
+
+func matchHandle(pattern, handle string) bool {
+	if pattern == "" {
+		return true
+	}
+	cached, ok := patterns.Load(pattern)
+	if !ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patterns.LoadOrStore(pattern, re)
+	}
+	return cached.(*regexp.Regexp).MatchString(handle)
+}
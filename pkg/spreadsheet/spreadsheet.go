@@ -0,0 +1,189 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrUnsupportedFormat = errors.New("desteklenmeyen dosya biçimi")
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// formulaPrefixes, elektronik tablo uygulamalarının hücreyi formül olarak yorumlamasına yol açan
+// ilk karakterlerdir.
+const formulaPrefixes = "=+-@\t\r"
+
+// ReadFile, dosya uzantısına göre CSV veya XLSX dosyasının ilk sayfasını satırlar halinde okur.
+// Tamamen boş satırlar atlanır.
+func ReadFile(path string) ([][]string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")) {
+	case FormatCSV:
+		return readCSV(path)
+	case FormatXLSX:
+		return readXLSX(path)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func readCSV(path string) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return compactRows(rows), nil
+}
+
+func readXLSX(path string) ([][]string, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return [][]string{}, nil
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	return compactRows(rows), nil
+}
+
+// detectDelimiter, Türkçe Excel'in ürettiği ";" ayraçlı dosyaları "," ayraçlılardan ayırt eder.
+func detectDelimiter(data []byte) rune {
+	firstLine, _ := bufio.NewReader(bytes.NewReader(data)).ReadString('\n')
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func compactRows(rows [][]string) [][]string {
+	result := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		empty := true
+		for i := range row {
+			row[i] = unescapeCell(strings.TrimSpace(row[i]))
+			if row[i] != "" {
+				empty = false
+			}
+		}
+		if !empty {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// EscapeCell, formül olarak çalıştırılabilecek değerlerin başına ' ekler; misafirlerin girdiği
+// "=HYPERLINK(...)" gibi değerler dışa aktarılan dosya açıldığında metin olarak görünür.
+func EscapeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCell, EscapeCell'in eklediği ' işaretini kaldırır; dışa aktarılan dosya yeniden içe
+// aktarıldığında telefon numarası gibi "+" ile başlayan değerler bozulmaz.
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func escapeRow(row []string) []string {
+	escaped := make([]string, len(row))
+	for i, value := range row {
+		escaped[i] = EscapeCell(value)
+	}
+	return escaped
+}
+
+// WriteCSV, başlık ve satırları Excel'in UTF-8 olarak tanıyacağı şekilde (BOM ile) yazar.
+// Hücreler EscapeCell'den geçirilir.
+func WriteCSV(w io.Writer, header []string, rows [][]string) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	if err := writer.Write(escapeRow(header)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(escapeRow(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteXLSX, başlık ve satırları tek sayfalık bir XLSX dosyası olarak yazar. Hücreler
+// EscapeCell'den geçirilir.
+func WriteXLSX(w io.Writer, sheetName string, header []string, rows [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	defaultSheet := f.GetSheetName(0)
+	if sheetName != "" && sheetName != defaultSheet {
+		if err := f.SetSheetName(defaultSheet, sheetName); err != nil {
+			return err
+		}
+	} else {
+		sheetName = defaultSheet
+	}
+
+	if err := f.SetSheetRow(sheetName, "A1", toInterfaceSlice(header)); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(sheetName, cell, toInterfaceSlice(row)); err != nil {
+			return err
+		}
+	}
+	_, err := f.WriteTo(w)
+	return err
+}
+
+func toInterfaceSlice(values []string) *[]interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = EscapeCell(v)
+	}
+	return &result
+}
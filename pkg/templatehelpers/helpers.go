@@ -1,9 +1,18 @@
 package templatehelpers
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"text/template"
 	"time"
+
+	"davet.link/pkg/detailfields"
+	"davet.link/pkg/iban"
+	"davet.link/pkg/invitationtheme"
+	"davet.link/pkg/markdown"
+	"davet.link/pkg/seo"
+	"davet.link/pkg/socialprofile"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -72,9 +81,90 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format("02.01.2006 15:04")
 		},
 
+		// FormatInputDateTime, datetime-local girişinin beklediği biçimi üretir; nil veya sıfır zaman için boş döner.
+		"FormatInputDateTime": func(v interface{}) string {
+			switch t := v.(type) {
+			case time.Time:
+				if !t.IsZero() {
+					return t.Format("2006-01-02T15:04")
+				}
+			case *time.Time:
+				if t != nil && !t.IsZero() {
+					return t.Format("2006-01-02T15:04")
+				}
+			}
+			return ""
+		},
+
 		"hasPrefix": func(s, prefix string) bool {
 			return len(s) >= len(prefix) && s[:len(prefix)] == prefix
 		},
+
+		"formatIBAN": iban.Format,
+
+		// formatBytes, dosya boyutunu okunur birimle gösterir (ör: 1,5 MB).
+		"formatBytes": func(size int64) string {
+			const unit = 1024
+			if size < unit {
+				return fmt.Sprintf("%d B", size)
+			}
+			value, suffix := float64(size)/unit, "KB"
+			for _, next := range []string{"MB", "GB", "TB"} {
+				if value < unit {
+					break
+				}
+				value, suffix = value/unit, next
+			}
+			return strings.Replace(fmt.Sprintf("%.1f %s", value, suffix), ".", ",", 1)
+		},
+
+		// markdown, yönetim panelinde yazılan içeriği kaçışlanmış HTML olarak basar.
+		"markdown": markdown.Render,
+
+		// seoMeta, sayfanın Meta değerini site varsayılanlarıyla tamamlar; Meta verilmemiş
+		// sayfalarda yalnızca varsayılanlar döner.
+		"seoMeta": func(v interface{}) seo.Meta {
+			meta, _ := v.(seo.Meta)
+			return meta.WithDefaults()
+		},
+
+		// profileURL, kayıtlı sosyal medya bağlantısını platformun kanonik profil adresi olarak
+		// döner; kurala uymayan eski kayıtlar olduğu gibi gösterilir.
+		"profileURL": func(raw, baseURL, handlePattern string) string {
+			if normalized, err := socialprofile.Normalize(raw, baseURL, handlePattern); err == nil {
+				return normalized
+			}
+			return raw
+		},
+
+		"invitationThemes": invitationtheme.All,
+		"invitationKinds":  invitationtheme.Kinds,
+
+		// invitationAppearance, formdaki şablon ve seçenekleri görünüme çevirir; seçenekler
+		// kayıttan (JSON metni) ya da geri dönen formdan (eşleme) gelebilir.
+		"invitationAppearance": func(name string, options interface{}) invitationtheme.Appearance {
+			switch values := options.(type) {
+			case string:
+				return invitationtheme.Resolve(name, values)
+			case map[string]string:
+				return invitationtheme.ResolveValues(name, values)
+			}
+			return invitationtheme.ResolveValues(name, nil)
+		},
+
+		"detailFieldTypes": detailfields.Types,
+		"categorySchema":   invitationtheme.SchemaFor,
+
+		// detailValues, davetiye formunu dolduran kaydın ya da geri dönen isteğin detay
+		// alanlarını döner; form boşsa boş değerler döner.
+		"detailValues": func(formData interface{}) detailfields.Values {
+			if source, ok := formData.(interface{ DetailFields() detailfields.Values }); ok {
+				if values := source.DetailFields(); values != nil {
+					return values
+				}
+			}
+			return detailfields.Values{}
+		},
 	}
 	return fm
 }
@@ -0,0 +1,58 @@
+package ticketcode
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// signatureLength, koddaki imza karakteri sayısıdır; 16 base32 karakteri 80 bitlik imza taşır.
+const signatureLength = 16
+
+var ErrInvalidCode = errors.New("geçersiz bilet kodu")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate, katılımcı için "<katılımcı ID>-<imza>" biçiminde bilet kodu üretir. İmza gizli
+// anahtarla hesaplanan HMAC-SHA256 olduğundan kodlar tahmin edilemez ve veritabanında
+// saklanmaları gerekmez.
+func Generate(secret []byte, participantID uint) string {
+	id := strconv.FormatUint(uint64(participantID), 10)
+	return id + "-" + sign(secret, id)
+}
+
+// Parse, kodu doğrular ve katılımcı ID'sini döner. Kamerayla okunan bilet bağlantısının
+// tamamı veya elle yazılan küçük harfli, boşluklu kodlar da kabul edilir.
+func Parse(secret []byte, raw string) (uint, error) {
+	code := Normalize(raw)
+	id, signature, ok := strings.Cut(code, "-")
+	if !ok || len(signature) != signatureLength {
+		return 0, ErrInvalidCode
+	}
+	participantID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || participantID == 0 {
+		return 0, ErrInvalidCode
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(secret, id))) {
+		return 0, ErrInvalidCode
+	}
+	return uint(participantID), nil
+}
+
+// Normalize, bağlantıdan kodu ayıklar, boşlukları atar ve harfleri büyütür.
+func Normalize(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if i := strings.LastIndex(strings.TrimRight(raw, "/"), "/"); i >= 0 {
+		raw = strings.TrimRight(raw, "/")[i+1:]
+	}
+	return strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+}
+
+func sign(secret []byte, id string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("ticket:" + id))
+	return encoding.EncodeToString(mac.Sum(nil))[:signatureLength]
+}
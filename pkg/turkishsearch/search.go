@@ -41,3 +41,8 @@ func SQLFilter(columnName, search string) (string, []interface{}) {
 
 	return query, params
 }
+
+// Normalize, Türkçe karakterleri ASCII karşılıklarına çevirip metni küçük harfe dönüştürür.
+func Normalize(str string) string {
+	return normalize(str)
+}
@@ -79,4 +79,26 @@ document.addEventListener("DOMContentLoaded", function () {
             button.closest("form").submit();
         });
     });
+});
+
+// IBAN alanlarında TR IBAN'ının banka kodundan (5-9. karakterler) bankayı otomatik seç.
+// Banka seçeneklerinde data-code özniteliği bulunmalıdır.
+document.addEventListener('input', function (e) {
+    const input = e.target;
+    if (!input.name || !/\[iban\]$/.test(input.name)) {
+        return;
+    }
+    const group = input.closest('.iban-group');
+    const select = group ? group.querySelector('select') : null;
+    if (!select) {
+        return;
+    }
+    const iban = input.value.replace(/[\s-]/g, '').toUpperCase();
+    if (!iban.startsWith('TR') || iban.length < 9) {
+        return;
+    }
+    const option = select.querySelector('option[data-code="' + iban.substring(4, 9) + '"]');
+    if (option) {
+        select.value = option.value;
+    }
 });
\ No newline at end of file
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+// PeriodCount, bir zaman dilimi (gün, hafta) için kayıt sayısıdır.
+type PeriodCount struct {
+	Period time.Time
+	Count  int64
+}
+
+// IAdminStatsRepository, yönetim paneli istatistiklerini satırları belleğe almadan, doğrudan
+// veritabanında toplayarak döner. Tüm sorgularda aralık [from, to) şeklindedir.
+type IAdminStatsRepository interface {
+	GetSignupsPerDay(from, to time.Time) ([]PeriodCount, error)
+	GetRSVPsPerWeek(from, to time.Time) ([]PeriodCount, error)
+	GetInvitationsByCategory(from, to time.Time) ([]LabelCount, error)
+	GetInvitationConfirmationCounts(from, to time.Time) ([]LabelCount, error)
+	GetUserVerificationCounts(from, to time.Time) ([]LabelCount, error)
+	GetUserProviderCounts(from, to time.Time) ([]LabelCount, error)
+}
+
+type AdminStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminStatsRepository() IAdminStatsRepository {
+	return &AdminStatsRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *AdminStatsRepository) GetSignupsPerDay(from, to time.Time) ([]PeriodCount, error) {
+	var rows []PeriodCount
+	err := r.db.Model(&models.User{}).
+		Select("date_trunc('day', created_at) AS period, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("period").
+		Order("period asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetRSVPsPerWeek, misafirlerden gelen katılım bildirimlerini haftalara (pazartesi başlangıçlı)
+// göre sayar. Panelden eklenen veya içe aktarılan kayıtlar sayılmaz.
+func (r *AdminStatsRepository) GetRSVPsPerWeek(from, to time.Time) ([]PeriodCount, error) {
+	var rows []PeriodCount
+	err := r.db.Model(&models.InvitationParticipant{}).
+		Select("date_trunc('week', created_at) AS period, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ? AND created_by = 0", from, to).
+		Group("period").
+		Order("period asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *AdminStatsRepository) GetInvitationsByCategory(from, to time.Time) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.Invitation{}).
+		Select("COALESCE(invitation_categories.name, '') AS label, COUNT(*) AS count").
+		Joins("LEFT JOIN invitation_categories ON invitation_categories.id = invitations.category_id").
+		Where("invitations.created_at >= ? AND invitations.created_at < ?", from, to).
+		Group("invitation_categories.name").
+		Order("count desc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetInvitationConfirmationCounts, davetiyeleri onay durumuna göre sayar ("confirmed"/"pending").
+func (r *AdminStatsRepository) GetInvitationConfirmationCounts(from, to time.Time) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.Invitation{}).
+		Select("CASE WHEN is_confirmed THEN 'confirmed' ELSE 'pending' END AS label, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("label").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetUserVerificationCounts, kullanıcıları e-posta doğrulama durumuna göre sayar ("verified"/"unverified").
+func (r *AdminStatsRepository) GetUserVerificationCounts(from, to time.Time) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.User{}).
+		Select("CASE WHEN email_verified THEN 'verified' ELSE 'unverified' END AS label, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("label").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetUserProviderCounts, kullanıcıları kayıt yöntemine göre sayar. Şifreyle kayıt olanlar boş
+// etiketle döner.
+func (r *AdminStatsRepository) GetUserProviderCounts(from, to time.Time) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.User{}).
+		Select("COALESCE(provider, '') AS label, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("COALESCE(provider, '')").
+		Order("count desc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+var _ IAdminStatsRepository = (*AdminStatsRepository)(nil)
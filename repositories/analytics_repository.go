@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DailyViewCount, bir günün görüntülenme ve tekil ziyaretçi sayısıdır.
+type DailyViewCount struct {
+	Day      time.Time
+	Views    int64
+	Visitors int64
+}
+
+// LabelCount, bir etiket (yönlendiren alan adı, cihaz sınıfı vb.) için tekil ziyaretçi sayısıdır.
+type LabelCount struct {
+	Label string
+	Count int64
+}
+
+type IAnalyticsRepository interface {
+	CreatePageViews(ctx context.Context, views []models.PageView) error
+	GetOrCreateSalt(ctx context.Context, day time.Time, candidate string) (string, error)
+	DeleteSaltsBefore(ctx context.Context, day time.Time) error
+	GetDailyViews(subjectType models.PageViewSubject, subjectID uint, from time.Time) ([]DailyViewCount, error)
+	GetReferrerCounts(subjectType models.PageViewSubject, subjectID uint, from time.Time, limit int) ([]LabelCount, error)
+	GetDeviceCounts(subjectType models.PageViewSubject, subjectID uint, from time.Time) ([]LabelCount, error)
+	CountRSVPsSince(invitationID uint, from time.Time) (int64, error)
+}
+
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository() IAnalyticsRepository {
+	return &AnalyticsRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *AnalyticsRepository) CreatePageViews(ctx context.Context, views []models.PageView) error {
+	if len(views) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(&views, 500).Error
+}
+
+// GetOrCreateSalt, günün tuzunu döner; yoksa candidate değerini kaydeder. Eş zamanlı
+// çağrılarda tüm örnekler aynı tuzu kullanır.
+func (r *AnalyticsRepository) GetOrCreateSalt(ctx context.Context, day time.Time, candidate string) (string, error) {
+	salt := models.AnalyticsSalt{Day: day, Salt: candidate}
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "day"}}, DoNothing: true}).
+		Create(&salt).Error; err != nil {
+		return "", err
+	}
+
+	var stored models.AnalyticsSalt
+	err := r.db.WithContext(ctx).Where("day = ?", day).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrNotFound
+	}
+	return stored.Salt, err
+}
+
+// DeleteSaltsBefore, verilen günden önceki tuzları kalıcı olarak siler.
+func (r *AnalyticsRepository) DeleteSaltsBefore(ctx context.Context, day time.Time) error {
+	return r.db.WithContext(ctx).Unscoped().Where("day < ?", day).Delete(&models.AnalyticsSalt{}).Error
+}
+
+func (r *AnalyticsRepository) GetDailyViews(subjectType models.PageViewSubject, subjectID uint, from time.Time) ([]DailyViewCount, error) {
+	var rows []DailyViewCount
+	err := r.db.Model(&models.PageView{}).
+		Select("day, COUNT(*) AS views, COUNT(DISTINCT visitor_hash) AS visitors").
+		Where("subject_type = ? AND subject_id = ? AND day >= ?", subjectType, subjectID, from).
+		Group("day").
+		Order("day asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetReferrerCounts, tekil ziyaretçilerin geldiği alan adlarını çoktan aza döner. Doğrudan
+// gelen ziyaretçiler boş etiketle sayılır.
+func (r *AnalyticsRepository) GetReferrerCounts(subjectType models.PageViewSubject, subjectID uint, from time.Time, limit int) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.PageView{}).
+		Select("COALESCE(referrer_domain, '') AS label, COUNT(DISTINCT (day, visitor_hash)) AS count").
+		Where("subject_type = ? AND subject_id = ? AND day >= ?", subjectType, subjectID, from).
+		Group("COALESCE(referrer_domain, '')").
+		Order("count desc").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *AnalyticsRepository) GetDeviceCounts(subjectType models.PageViewSubject, subjectID uint, from time.Time) ([]LabelCount, error) {
+	var rows []LabelCount
+	err := r.db.Model(&models.PageView{}).
+		Select("device_class AS label, COUNT(DISTINCT (day, visitor_hash)) AS count").
+		Where("subject_type = ? AND subject_id = ? AND day >= ?", subjectType, subjectID, from).
+		Group("device_class").
+		Order("count desc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CountRSVPsSince, davetiyeye verilen tarihten bu yana misafirlerden gelen katılım bildirimi
+// sayısını döner. Panelden içe aktarılan kayıtlar (created_by dolu) sayılmaz.
+func (r *AnalyticsRepository) CountRSVPsSince(invitationID uint, from time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.InvitationParticipant{}).
+		Where("invitation_id = ? AND created_at >= ? AND created_by = 0", invitationID, from).
+		Count(&count).Error
+	return count, err
+}
+
+var _ IAnalyticsRepository = (*AnalyticsRepository)(nil)
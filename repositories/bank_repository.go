@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"davet.link/configs/databaseconfig"
 	"davet.link/models"
@@ -13,6 +14,7 @@ import (
 type IBankRepository interface {
 	GetAllBanks(params queryparams.ListParams) ([]models.Bank, int64, error)
 	GetBankByID(id uint) (*models.Bank, error)
+	GetBankByCode(code string) (*models.Bank, error)
 	CreateBank(ctx context.Context, bank *models.Bank) error
 	BulkCreateBanks(ctx context.Context, banks []models.Bank) error
 	UpdateBank(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
@@ -41,6 +43,17 @@ func (r *BankRepository) GetBankByID(id uint) (*models.Bank, error) {
 	return r.base.GetByID(id)
 }
 
+func (r *BankRepository) GetBankByCode(code string) (*models.Bank, error) {
+	var bank models.Bank
+	if err := r.db.Where("code = ?", code).First(&bank).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &bank, nil
+}
+
 func (r *BankRepository) CreateBank(ctx context.Context, bank *models.Bank) error {
 	return r.base.Create(ctx, bank)
 }
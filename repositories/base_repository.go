@@ -20,6 +20,7 @@ var (
 // IBaseRepository, herhangi bir T tipi için jenerik veritabanı operasyonlarını tanımlar.
 type IBaseRepository[T any] interface {
 	GetAll(params queryparams.ListParams) ([]T, int64, error)
+	GetAllByCondition(params queryparams.ListParams, condition map[string]interface{}) ([]T, int64, error)
 	GetByID(id uint) (*T, error)
 	Create(ctx context.Context, entity *T) error
 	CreateWithRelations(ctx context.Context, entity *T) error
@@ -67,6 +68,12 @@ func (r *BaseRepository[T]) SetPreloads(preloads ...string) {
 }
 
 func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, error) {
+	return r.GetAllByCondition(params, nil)
+}
+
+// GetAllByCondition, GetAll ile aynı filtreleme ve sayfalamayı ek bir eşitlik koşuluyla
+// (ör: yalnızca belirli kullanıcının kayıtları) uygular.
+func (r *BaseRepository[T]) GetAllByCondition(params queryparams.ListParams, condition map[string]interface{}) ([]T, int64, error) {
 	var results []T
 	var totalCount int64
 	var t T
@@ -75,6 +82,9 @@ func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, e
 	for _, preload := range r.preloads {
 		query = query.Preload(preload)
 	}
+	if len(condition) > 0 {
+		query = query.Where(condition)
+	}
 
 	if params.Name != "" {
 		sqlFragment, args := turkishsearch.SQLFilter("name", params.Name)
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"davet.link/configs/databaseconfig"
 	"davet.link/models"
 	"davet.link/pkg/queryparams"
@@ -10,7 +11,9 @@ import (
 
 type ICardRepository interface {
 	GetAllCards(params queryparams.ListParams) ([]models.Card, int64, error)
+	GetCardsByUserID(userID uint, params queryparams.ListParams) ([]models.Card, int64, error)
 	GetCardByID(id uint) (*models.Card, error)
+	GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error)
 	CreateCardWithRelations(ctx context.Context, card *models.Card) error
 	UpdateCardWithRelations(ctx context.Context, card *models.Card) error
 	DeleteCardWithRelations(ctx context.Context, id uint) error
@@ -26,7 +29,7 @@ type CardRepository struct {
 func NewCardRepository() ICardRepository {
 	base := NewBaseRepository[models.Card](databaseconfig.GetDB())
 	base.SetAllowedSortColumns([]string{"id", "name", "slug", "is_active", "created_at"})
-	base.SetPreloads("CardBanks.Bank", "CardSocialMedia.SocialMedia")
+	base.SetPreloads("CardBanks.Bank", "CardSocialMedia.SocialMedia", "Organization.Banks.Bank", "Organization.SocialMedia.SocialMedia")
 	return &CardRepository{base: base, db: databaseconfig.GetDB()}
 }
 
@@ -34,10 +37,31 @@ func (r *CardRepository) GetAllCards(params queryparams.ListParams) ([]models.Ca
 	return r.base.GetAll(params)
 }
 
+func (r *CardRepository) GetCardsByUserID(userID uint, params queryparams.ListParams) ([]models.Card, int64, error) {
+	return r.base.GetAllByCondition(params, map[string]interface{}{"user_id": userID})
+}
+
 func (r *CardRepository) GetCardByID(id uint) (*models.Card, error) {
 	return r.base.GetByID(id)
 }
 
+func (r *CardRepository) GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error) {
+	var result models.Card
+	query := r.db.WithContext(ctx)
+	for _, preload := range r.base.(*BaseRepository[models.Card]).preloads {
+		query = query.Preload(preload)
+	}
+
+	err := query.Where("slug = ? AND is_active = ?", slug, true).First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 func (r *CardRepository) CreateCardWithRelations(ctx context.Context, card *models.Card) error {
 	return r.base.CreateWithRelations(ctx, card)
 }
@@ -91,7 +115,8 @@ func (r *CardRepository) UpdateCardWithRelations(ctx context.Context, card *mode
 		}
 	}
 
-	if err := tx.Save(card).Error; err != nil {
+	// Organizasyon bilgileri kart formundan düzenlenmez
+	if err := tx.Omit("Organization").Save(card).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrCheckInRejected = errors.New("giriş kaydedilemedi")
+
+// CheckInStats, davetiyenin kapı sayacıdır. Beklenen değerler katılımı onaylı katılımcılardan
+// hesaplanır.
+type CheckInStats struct {
+	ExpectedGuests  int64
+	ArrivedGuests   int64
+	ExpectedParties int64
+	ArrivedParties  int64
+}
+
+type IInvitationCheckInRepository interface {
+	CheckIn(ctx context.Context, participantID uint, count int, checkIn *models.InvitationCheckIn) (*models.InvitationParticipant, error)
+	GetStats(invitationID uint) (*CheckInStats, error)
+	GetRecentCheckIns(invitationID uint, limit int) ([]models.InvitationCheckIn, error)
+}
+
+type InvitationCheckInRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationCheckInRepository() IInvitationCheckInRepository {
+	return &InvitationCheckInRepository{db: databaseconfig.GetDB()}
+}
+
+// CheckIn, katılımcının giriş sayısını tek bir koşullu UPDATE ile artırır ve giriş kaydını aynı
+// işlemde oluşturur. Koşul veritabanında değerlendirildiğinden farklı cihazlardan aynı anda
+// okutulan biletler kişi sayısını aşamaz; koşul sağlanmazsa ErrCheckInRejected döner.
+func (r *InvitationCheckInRepository) CheckIn(ctx context.Context, participantID uint, count int, checkIn *models.InvitationCheckIn) (*models.InvitationParticipant, error) {
+	var participant models.InvitationParticipant
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.InvitationParticipant{}).
+			Where("id = ? AND status = ? AND checked_in_count + ? <= guest_count", participantID, models.RSVPAttending, count).
+			Updates(map[string]interface{}{
+				"checked_in_count": gorm.Expr("checked_in_count + ?", count),
+				"checked_in_at":    gorm.Expr("COALESCE(checked_in_at, ?)", time.Now()),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCheckInRejected
+		}
+		if err := tx.Create(checkIn).Error; err != nil {
+			return err
+		}
+		return tx.First(&participant, participantID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (r *InvitationCheckInRepository) GetStats(invitationID uint) (*CheckInStats, error) {
+	var stats CheckInStats
+	err := r.db.Model(&models.InvitationParticipant{}).
+		Select(`COALESCE(SUM(guest_count), 0) AS expected_guests,
+			COALESCE(SUM(checked_in_count), 0) AS arrived_guests,
+			COUNT(*) AS expected_parties,
+			COUNT(*) FILTER (WHERE checked_in_count > 0) AS arrived_parties`).
+		Where("invitation_id = ? AND status = ?", invitationID, models.RSVPAttending).
+		Scan(&stats).Error
+	return &stats, err
+}
+
+func (r *InvitationCheckInRepository) GetRecentCheckIns(invitationID uint, limit int) ([]models.InvitationCheckIn, error) {
+	var checkIns []models.InvitationCheckIn
+	err := r.db.Preload("Participant").Preload("CheckedInBy").
+		Where("invitation_id = ?", invitationID).
+		Order("id desc").Limit(limit).
+		Find(&checkIns).Error
+	return checkIns, err
+}
+
+var _ IInvitationCheckInRepository = (*InvitationCheckInRepository)(nil)
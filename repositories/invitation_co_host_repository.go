@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationCoHostRepository interface {
+	GetCoHostsByInvitationID(invitationID uint) ([]models.InvitationCoHost, error)
+	GetCoHostByID(id uint) (*models.InvitationCoHost, error)
+	GetCoHostByToken(ctx context.Context, token string) (*models.InvitationCoHost, error)
+	GetCoHostByEmail(invitationID uint, email string) (*models.InvitationCoHost, error)
+	GetAcceptedCoHost(invitationID, userID uint) (*models.InvitationCoHost, error)
+	TokenExists(ctx context.Context, token string) (bool, error)
+	CreateCoHost(ctx context.Context, coHost *models.InvitationCoHost, log *models.InvitationCoHostLog) error
+	UpdateCoHost(ctx context.Context, coHost *models.InvitationCoHost, data map[string]interface{}, log *models.InvitationCoHostLog) error
+	DeleteCoHost(ctx context.Context, coHost *models.InvitationCoHost, log *models.InvitationCoHostLog) error
+	GetLogsByInvitationID(invitationID uint, limit int) ([]models.InvitationCoHostLog, error)
+}
+
+type InvitationCoHostRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationCoHostRepository() IInvitationCoHostRepository {
+	return &InvitationCoHostRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationCoHostRepository) GetCoHostsByInvitationID(invitationID uint) ([]models.InvitationCoHost, error) {
+	var coHosts []models.InvitationCoHost
+	err := r.db.Preload("User").Where("invitation_id = ?", invitationID).Order("id asc").Find(&coHosts).Error
+	return coHosts, err
+}
+
+func (r *InvitationCoHostRepository) GetCoHostByID(id uint) (*models.InvitationCoHost, error) {
+	var coHost models.InvitationCoHost
+	if err := r.db.First(&coHost, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &coHost, nil
+}
+
+func (r *InvitationCoHostRepository) GetCoHostByToken(ctx context.Context, token string) (*models.InvitationCoHost, error) {
+	var coHost models.InvitationCoHost
+	err := r.db.WithContext(ctx).Preload("Invitation").Preload("InvitedBy").Where("token = ?", token).First(&coHost).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &coHost, nil
+}
+
+func (r *InvitationCoHostRepository) GetCoHostByEmail(invitationID uint, email string) (*models.InvitationCoHost, error) {
+	var coHost models.InvitationCoHost
+	err := r.db.Where("invitation_id = ? AND email = ?", invitationID, email).First(&coHost).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &coHost, nil
+}
+
+// GetAcceptedCoHost, kullanıcının davetiyede kabul edilmiş ortak ev sahipliği kaydını döner.
+func (r *InvitationCoHostRepository) GetAcceptedCoHost(invitationID, userID uint) (*models.InvitationCoHost, error) {
+	var coHost models.InvitationCoHost
+	err := r.db.Where("invitation_id = ? AND user_id = ? AND accepted_at IS NOT NULL", invitationID, userID).First(&coHost).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &coHost, nil
+}
+
+func (r *InvitationCoHostRepository) TokenExists(ctx context.Context, token string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.InvitationCoHost{}).Where("token = ?", token).Count(&count).Error
+	return count > 0, err
+}
+
+// CreateCoHost, ortak ev sahibini değişiklik kaydıyla birlikte aynı işlemde oluşturur.
+func (r *InvitationCoHostRepository) CreateCoHost(ctx context.Context, coHost *models.InvitationCoHost, log *models.InvitationCoHostLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(coHost).Error; err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+}
+
+func (r *InvitationCoHostRepository) UpdateCoHost(ctx context.Context, coHost *models.InvitationCoHost, data map[string]interface{}, log *models.InvitationCoHostLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(coHost).Updates(data).Error; err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+}
+
+// DeleteCoHost, kaydı kalıcı olarak siler; böylece aynı e-posta adresi daha sonra yeniden
+// davet edilebilir.
+func (r *InvitationCoHostRepository) DeleteCoHost(ctx context.Context, coHost *models.InvitationCoHost, log *models.InvitationCoHostLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&models.InvitationCoHost{}, coHost.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(log).Error
+	})
+}
+
+func (r *InvitationCoHostRepository) GetLogsByInvitationID(invitationID uint, limit int) ([]models.InvitationCoHostLog, error) {
+	var logs []models.InvitationCoHostLog
+	err := r.db.Preload("Actor").Where("invitation_id = ?", invitationID).Order("id desc").Limit(limit).Find(&logs).Error
+	return logs, err
+}
+
+var _ IInvitationCoHostRepository = (*InvitationCoHostRepository)(nil)
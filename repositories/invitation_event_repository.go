@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+// EventAttendance, bir program bölümüne katılacağını bildiren katılımcı ve kişi sayısını tutar.
+type EventAttendance struct {
+	EventID      uint
+	Participants int64
+	Guests       int64
+}
+
+type IInvitationEventRepository interface {
+	GetEventsByInvitationID(invitationID uint) ([]models.InvitationEvent, error)
+	GetEventByID(id uint) (*models.InvitationEvent, error)
+	CreateEvent(ctx context.Context, event *models.InvitationEvent) error
+	UpdateEvent(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	DeleteEvent(ctx context.Context, id uint) error
+	GetResponsesByParticipantID(participantID uint) ([]models.InvitationEventResponse, error)
+	CountAttendance(invitationID uint) ([]EventAttendance, error)
+}
+
+type InvitationEventRepository struct {
+	base IBaseRepository[models.InvitationEvent]
+	db   *gorm.DB
+}
+
+func NewInvitationEventRepository() IInvitationEventRepository {
+	base := NewBaseRepository[models.InvitationEvent](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "title", "starts_at", "created_at"})
+	return &InvitationEventRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationEventRepository) GetEventsByInvitationID(invitationID uint) ([]models.InvitationEvent, error) {
+	var events []models.InvitationEvent
+	err := r.db.Where("invitation_id = ?", invitationID).Scopes(orderEvents).Find(&events).Error
+	return events, err
+}
+
+func (r *InvitationEventRepository) GetEventByID(id uint) (*models.InvitationEvent, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *InvitationEventRepository) CreateEvent(ctx context.Context, event *models.InvitationEvent) error {
+	return r.base.Create(ctx, event)
+}
+
+func (r *InvitationEventRepository) UpdateEvent(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.base.Update(ctx, id, data, updatedBy)
+}
+
+func (r *InvitationEventRepository) DeleteEvent(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+func (r *InvitationEventRepository) GetResponsesByParticipantID(participantID uint) ([]models.InvitationEventResponse, error) {
+	var responses []models.InvitationEventResponse
+	err := r.db.Where("participant_id = ?", participantID).Find(&responses).Error
+	return responses, err
+}
+
+// CountAttendance, katılmayacağını bildirenler hariç, her program bölümüne katılacağını
+// bildiren katılımcıları ve toplam kişi sayılarını döner.
+func (r *InvitationEventRepository) CountAttendance(invitationID uint) ([]EventAttendance, error) {
+	var rows []EventAttendance
+	err := r.db.Table("invitation_event_responses AS er").
+		Joins("JOIN invitation_participants AS p ON p.id = er.participant_id AND p.deleted_at IS NULL").
+		Where("p.invitation_id = ? AND p.status <> ? AND er.is_attending AND er.deleted_at IS NULL", invitationID, models.RSVPNotAttending).
+		Select("er.event_id, COUNT(*) AS participants, COALESCE(SUM(p.guest_count), 0) AS guests").
+		Group("er.event_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// orderEvents, program bölümlerini başlangıç zamanına göre sıralar.
+func orderEvents(db *gorm.DB) *gorm.DB {
+	return db.Order("starts_at asc, id asc")
+}
+
+var _ IInvitationEventRepository = (*InvitationEventRepository)(nil)
+var _ IBaseRepository[models.InvitationEvent] = (*BaseRepository[models.InvitationEvent])(nil)
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAlbumQuotaExceeded = errors.New("albüm depolama kotası doldu")
+
+type IInvitationGalleryRepository interface {
+	GetAlbumByInvitationID(invitationID uint) (*models.InvitationAlbum, error)
+	CreateAlbum(ctx context.Context, album *models.InvitationAlbum) error
+	UpdateAlbum(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	GetPhotos(albumID uint, status models.PhotoStatus) ([]models.InvitationPhoto, error)
+	GetApprovedPhotos(albumID uint, limit int) ([]models.InvitationPhoto, error)
+	GetPhotoByID(id uint) (*models.InvitationPhoto, error)
+	CountByStatus(albumID uint) (map[models.PhotoStatus]int64, error)
+	AddPhoto(ctx context.Context, photo *models.InvitationPhoto) error
+	UpdatePhotoStatus(ctx context.Context, id uint, status models.PhotoStatus, updatedBy uint) error
+	DeletePhoto(ctx context.Context, photo *models.InvitationPhoto) error
+	EachPhoto(albumID uint, fn func(photo models.InvitationPhoto) error) error
+}
+
+type InvitationGalleryRepository struct {
+	albumBase IBaseRepository[models.InvitationAlbum]
+	photoBase IBaseRepository[models.InvitationPhoto]
+	db        *gorm.DB
+}
+
+func NewInvitationGalleryRepository() IInvitationGalleryRepository {
+	db := databaseconfig.GetDB()
+	return &InvitationGalleryRepository{
+		albumBase: NewBaseRepository[models.InvitationAlbum](db),
+		photoBase: NewBaseRepository[models.InvitationPhoto](db),
+		db:        db,
+	}
+}
+
+func (r *InvitationGalleryRepository) GetAlbumByInvitationID(invitationID uint) (*models.InvitationAlbum, error) {
+	var album models.InvitationAlbum
+	if err := r.db.Where("invitation_id = ?", invitationID).First(&album).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &album, nil
+}
+
+func (r *InvitationGalleryRepository) CreateAlbum(ctx context.Context, album *models.InvitationAlbum) error {
+	return r.albumBase.Create(ctx, album)
+}
+
+func (r *InvitationGalleryRepository) UpdateAlbum(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.albumBase.Update(ctx, id, data, updatedBy)
+}
+
+// GetPhotos, albümdeki fotoğrafları en yeniden eskiye döner; status boşsa tüm durumlar gelir.
+func (r *InvitationGalleryRepository) GetPhotos(albumID uint, status models.PhotoStatus) ([]models.InvitationPhoto, error) {
+	var photos []models.InvitationPhoto
+	query := r.db.Preload("Guest").Where("album_id = ?", albumID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id desc").Find(&photos).Error
+	return photos, err
+}
+
+func (r *InvitationGalleryRepository) GetApprovedPhotos(albumID uint, limit int) ([]models.InvitationPhoto, error) {
+	var photos []models.InvitationPhoto
+	query := r.db.Where("album_id = ? AND status = ?", albumID, models.PhotoApproved).Order("id desc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&photos).Error
+	return photos, err
+}
+
+func (r *InvitationGalleryRepository) GetPhotoByID(id uint) (*models.InvitationPhoto, error) {
+	return r.photoBase.GetByID(id)
+}
+
+func (r *InvitationGalleryRepository) CountByStatus(albumID uint) (map[models.PhotoStatus]int64, error) {
+	var rows []struct {
+		Status models.PhotoStatus
+		Count  int64
+	}
+	err := r.db.Model(&models.InvitationPhoto{}).
+		Select("status, COUNT(*) AS count").
+		Where("album_id = ?", albumID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[models.PhotoStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// AddPhoto, fotoğrafı kaydeder ve albümün kullanılan alanını artırır. Albüm satırı işlem boyunca
+// kilitlendiğinden aynı anda yapılan yüklemeler kotayı aşamaz.
+func (r *InvitationGalleryRepository) AddPhoto(ctx context.Context, photo *models.InvitationPhoto) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var album models.InvitationAlbum
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&album, photo.AlbumID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if album.UsedBytes+photo.Size > album.QuotaBytes {
+			return ErrAlbumQuotaExceeded
+		}
+		if err := tx.Create(photo).Error; err != nil {
+			return err
+		}
+		return tx.Model(&album).UpdateColumn("used_bytes", gorm.Expr("used_bytes + ?", photo.Size)).Error
+	})
+}
+
+func (r *InvitationGalleryRepository) UpdatePhotoStatus(ctx context.Context, id uint, status models.PhotoStatus, updatedBy uint) error {
+	return r.photoBase.Update(ctx, id, map[string]interface{}{"status": status}, updatedBy)
+}
+
+// DeletePhoto, fotoğrafı siler ve dosyasının kapladığı alanı albümün kotasına geri verir.
+func (r *InvitationGalleryRepository) DeletePhoto(ctx context.Context, photo *models.InvitationPhoto) error {
+	userID, ok := ctx.Value(userIDKey).(uint)
+	if !ok || userID == 0 {
+		return ErrMissingUserID
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var album models.InvitationAlbum
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&album, photo.AlbumID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.InvitationPhoto{}).Where("id = ?", photo.ID).Update("deleted_by", userID).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.InvitationPhoto{}, photo.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Model(&album).UpdateColumn("used_bytes", gorm.Expr("GREATEST(used_bytes - ?, 0)", photo.Size)).Error
+	})
+}
+
+// EachPhoto, albümdeki fotoğrafları yüklenme sırasıyla parça parça okuyup fn'e verir; toplu
+// indirmede tüm kayıtların aynı anda belleğe alınmaması içindir.
+func (r *InvitationGalleryRepository) EachPhoto(albumID uint, fn func(photo models.InvitationPhoto) error) error {
+	var batch []models.InvitationPhoto
+	return r.db.Where("album_id = ?", albumID).Order("id asc").FindInBatches(&batch, 100, func(tx *gorm.DB, _ int) error {
+		for _, photo := range batch {
+			if err := fn(photo); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+var _ IInvitationGalleryRepository = (*InvitationGalleryRepository)(nil)
+var _ IBaseRepository[models.InvitationAlbum] = (*BaseRepository[models.InvitationAlbum])(nil)
+var _ IBaseRepository[models.InvitationPhoto] = (*BaseRepository[models.InvitationPhoto])(nil)
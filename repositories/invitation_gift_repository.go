@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrGiftItemFullyReserved = errors.New("ürünün tüm adetleri ayrılmış")
+	ErrGiftQuantityReserved  = errors.New("adet, ayrılmış adetten az olamaz")
+)
+
+type IInvitationGiftRepository interface {
+	GetBanks(invitationID uint) ([]models.InvitationBank, error)
+	GetBankByID(id uint) (*models.InvitationBank, error)
+	CreateBank(ctx context.Context, bank *models.InvitationBank) error
+	DeleteBank(ctx context.Context, id uint) error
+	GetItems(invitationID uint, withReservations bool) ([]models.InvitationGiftItem, error)
+	GetItemByID(id uint) (*models.InvitationGiftItem, error)
+	CreateItem(ctx context.Context, item *models.InvitationGiftItem) error
+	UpdateItem(ctx context.Context, id uint, quantity int, data map[string]interface{}, updatedBy uint) error
+	DeleteItem(ctx context.Context, id uint) error
+	GetReservationByID(id uint) (*models.InvitationGiftReservation, error)
+	GetReservationsByTokens(tokens []string) ([]models.InvitationGiftReservation, error)
+	Reserve(ctx context.Context, reservation *models.InvitationGiftReservation) error
+	CancelReservation(ctx context.Context, reservation *models.InvitationGiftReservation) error
+}
+
+type InvitationGiftRepository struct {
+	bankBase        IBaseRepository[models.InvitationBank]
+	itemBase        IBaseRepository[models.InvitationGiftItem]
+	reservationBase IBaseRepository[models.InvitationGiftReservation]
+	db              *gorm.DB
+}
+
+func NewInvitationGiftRepository() IInvitationGiftRepository {
+	db := databaseconfig.GetDB()
+	bankBase := NewBaseRepository[models.InvitationBank](db)
+	bankBase.SetPreloads("Bank")
+	return &InvitationGiftRepository{
+		bankBase:        bankBase,
+		itemBase:        NewBaseRepository[models.InvitationGiftItem](db),
+		reservationBase: NewBaseRepository[models.InvitationGiftReservation](db),
+		db:              db,
+	}
+}
+
+func (r *InvitationGiftRepository) GetBanks(invitationID uint) ([]models.InvitationBank, error) {
+	var banks []models.InvitationBank
+	err := r.db.Preload("Bank").Where("invitation_id = ?", invitationID).Order("id asc").Find(&banks).Error
+	return banks, err
+}
+
+func (r *InvitationGiftRepository) GetBankByID(id uint) (*models.InvitationBank, error) {
+	return r.bankBase.GetByID(id)
+}
+
+func (r *InvitationGiftRepository) CreateBank(ctx context.Context, bank *models.InvitationBank) error {
+	return r.bankBase.Create(ctx, bank)
+}
+
+func (r *InvitationGiftRepository) DeleteBank(ctx context.Context, id uint) error {
+	return r.bankBase.Delete(ctx, id)
+}
+
+// GetItems, hediye listesini eklenme sırasıyla döner. withReservations, davetiye sahibinin
+// görmesi için ayırmaları ve ayırmayı yapan misafirleri de yükler.
+func (r *InvitationGiftRepository) GetItems(invitationID uint, withReservations bool) ([]models.InvitationGiftItem, error) {
+	var items []models.InvitationGiftItem
+	query := r.db.Where("invitation_id = ?", invitationID)
+	if withReservations {
+		query = query.Preload("Reservations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).Preload("Reservations.Guest")
+	}
+	err := query.Order("id asc").Find(&items).Error
+	return items, err
+}
+
+func (r *InvitationGiftRepository) GetItemByID(id uint) (*models.InvitationGiftItem, error) {
+	return r.itemBase.GetByID(id)
+}
+
+func (r *InvitationGiftRepository) CreateItem(ctx context.Context, item *models.InvitationGiftItem) error {
+	return r.itemBase.Create(ctx, item)
+}
+
+// UpdateItem, ürünü quantity adediyle günceller. Ürün satırı Reserve'deki gibi kilitlenir; adet,
+// aynı anda yapılan ayırmalar dahil ayrılmış adetten azsa ErrGiftQuantityReserved döner.
+func (r *InvitationGiftRepository) UpdateItem(ctx context.Context, id uint, quantity int, data map[string]interface{}, updatedBy uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item models.InvitationGiftItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if quantity < item.ReservedCount {
+			return ErrGiftQuantityReserved
+		}
+		data["quantity"] = quantity
+		if updatedBy > 0 {
+			data["updated_by"] = updatedBy
+		}
+		return tx.Model(&item).Updates(data).Error
+	})
+}
+
+// DeleteItem, ürünü ve ürün için yapılmış ayırmaları birlikte siler.
+func (r *InvitationGiftRepository) DeleteItem(ctx context.Context, id uint) error {
+	userID, ok := ctx.Value(userIDKey).(uint)
+	if !ok || userID == 0 {
+		return ErrMissingUserID
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		reservations := tx.Model(&models.InvitationGiftReservation{}).Where("gift_item_id = ?", id)
+		if err := reservations.Update("deleted_by", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("gift_item_id = ?", id).Delete(&models.InvitationGiftReservation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.InvitationGiftItem{}).Where("id = ?", id).Update("deleted_by", userID).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.InvitationGiftItem{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *InvitationGiftRepository) GetReservationByID(id uint) (*models.InvitationGiftReservation, error) {
+	return r.reservationBase.GetByID(id)
+}
+
+func (r *InvitationGiftRepository) GetReservationsByTokens(tokens []string) ([]models.InvitationGiftReservation, error) {
+	var reservations []models.InvitationGiftReservation
+	if len(tokens) == 0 {
+		return reservations, nil
+	}
+	err := r.db.Where("token IN ?", tokens).Find(&reservations).Error
+	return reservations, err
+}
+
+// Reserve, ayırmayı kaydeder ve ürünün ayrılan adedini artırır. Ürün satırı işlem boyunca
+// kilitlendiğinden iki misafir aynı son adedi birlikte ayıramaz.
+func (r *InvitationGiftRepository) Reserve(ctx context.Context, reservation *models.InvitationGiftReservation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item models.InvitationGiftItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, reservation.GiftItemID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if item.ReservedCount >= item.Quantity {
+			return ErrGiftItemFullyReserved
+		}
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		return tx.Model(&item).UpdateColumn("reserved_count", gorm.Expr("reserved_count + 1")).Error
+	})
+}
+
+// CancelReservation, ayırmayı siler ve ürünün adedini yeniden ayrılabilir yapar. Davetiye
+// sahibi sildiğinde silen kullanıcı kaydedilir; misafir kendi ayırmasını oturumdaki anahtarıyla
+// geri alır.
+func (r *InvitationGiftRepository) CancelReservation(ctx context.Context, reservation *models.InvitationGiftReservation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item models.InvitationGiftItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, reservation.GiftItemID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if userID, ok := ctx.Value(userIDKey).(uint); ok && userID != 0 {
+			if err := tx.Model(&models.InvitationGiftReservation{}).Where("id = ?", reservation.ID).Update("deleted_by", userID).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Delete(&models.InvitationGiftReservation{}, reservation.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Model(&item).UpdateColumn("reserved_count", gorm.Expr("GREATEST(reserved_count - 1, 0)")).Error
+	})
+}
+
+var _ IInvitationGiftRepository = (*InvitationGiftRepository)(nil)
+var _ IBaseRepository[models.InvitationBank] = (*BaseRepository[models.InvitationBank])(nil)
+var _ IBaseRepository[models.InvitationGiftItem] = (*BaseRepository[models.InvitationGiftItem])(nil)
+var _ IBaseRepository[models.InvitationGiftReservation] = (*BaseRepository[models.InvitationGiftReservation])(nil)
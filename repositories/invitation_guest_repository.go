@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationGuestRepository interface {
+	GetGuestsByInvitationID(invitationID uint) ([]models.InvitationGuest, error)
+	GetGuestByID(id uint) (*models.InvitationGuest, error)
+	GetGuestByToken(ctx context.Context, token string) (*models.InvitationGuest, error)
+	CreateGuest(ctx context.Context, guest *models.InvitationGuest) error
+	DeleteGuest(ctx context.Context, id uint) error
+	TokenExists(ctx context.Context, token string) (bool, error)
+	MarkOpened(ctx context.Context, id uint, openedAt time.Time) error
+	CountByStatus(invitationID uint) (map[models.InvitationGuestStatus]int64, error)
+}
+
+type InvitationGuestRepository struct {
+	base IBaseRepository[models.InvitationGuest]
+	db   *gorm.DB
+}
+
+func NewInvitationGuestRepository() IInvitationGuestRepository {
+	base := NewBaseRepository[models.InvitationGuest](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "title", "status", "created_at"})
+	base.SetPreloads("Participant")
+	return &InvitationGuestRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationGuestRepository) GetGuestsByInvitationID(invitationID uint) ([]models.InvitationGuest, error) {
+	var guests []models.InvitationGuest
+	err := r.db.Preload("Participant").Where("invitation_id = ?", invitationID).Order("id asc").Find(&guests).Error
+	return guests, err
+}
+
+func (r *InvitationGuestRepository) GetGuestByID(id uint) (*models.InvitationGuest, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *InvitationGuestRepository) GetGuestByToken(ctx context.Context, token string) (*models.InvitationGuest, error) {
+	var guest models.InvitationGuest
+	err := r.db.WithContext(ctx).
+		Preload("Invitation").
+		Preload("Invitation.InvitationDetail").
+		Preload("Invitation.Category").
+		Preload("Invitation.Events", orderEvents).
+		Preload("Participant").
+		Preload("Participant.Table").
+		Where("token = ?", token).
+		First(&guest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &guest, nil
+}
+
+func (r *InvitationGuestRepository) CreateGuest(ctx context.Context, guest *models.InvitationGuest) error {
+	return r.base.Create(ctx, guest)
+}
+
+func (r *InvitationGuestRepository) DeleteGuest(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+func (r *InvitationGuestRepository) TokenExists(ctx context.Context, token string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.InvitationGuest{}).Where("token = ?", token).Count(&count).Error
+	return count > 0, err
+}
+
+// MarkOpened, bağlantının ilk açılış zamanını kaydeder. Daha önce açılmış bir bağlantı için
+// zaman değişmez; henüz yanıt vermemiş misafirin durumu "opened" olur.
+func (r *InvitationGuestRepository) MarkOpened(ctx context.Context, id uint, openedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.InvitationGuest{}).
+		Where("id = ? AND opened_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"opened_at": openedAt,
+			"status":    gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", models.GuestStatusInvited, models.GuestStatusOpened),
+		}).Error
+}
+
+func (r *InvitationGuestRepository) CountByStatus(invitationID uint) (map[models.InvitationGuestStatus]int64, error) {
+	var rows []struct {
+		Status models.InvitationGuestStatus
+		Count  int64
+	}
+	err := r.db.Model(&models.InvitationGuest{}).
+		Select("status, COUNT(*) AS count").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[models.InvitationGuestStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+var _ IInvitationGuestRepository = (*InvitationGuestRepository)(nil)
+var _ IBaseRepository[models.InvitationGuest] = (*BaseRepository[models.InvitationGuest])(nil)
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IInvitationGuestbookRepository interface {
+	GetEntriesByInvitationID(invitationID uint, status models.GuestbookEntryStatus) ([]models.InvitationGuestbookEntry, error)
+	GetApprovedEntries(invitationID uint, limit int) ([]models.InvitationGuestbookEntry, error)
+	GetEntryByID(id uint) (*models.InvitationGuestbookEntry, error)
+	CountByStatus(invitationID uint) (map[models.GuestbookEntryStatus]int64, error)
+	HasMessage(invitationID uint, message string) (bool, error)
+	CreateEntry(ctx context.Context, entry *models.InvitationGuestbookEntry) error
+	UpdateStatus(ctx context.Context, id uint, status models.GuestbookEntryStatus, updatedBy uint) error
+	DeleteEntry(ctx context.Context, id uint) error
+}
+
+type InvitationGuestbookRepository struct {
+	base IBaseRepository[models.InvitationGuestbookEntry]
+	db   *gorm.DB
+}
+
+func NewInvitationGuestbookRepository() IInvitationGuestbookRepository {
+	base := NewBaseRepository[models.InvitationGuestbookEntry](databaseconfig.GetDB())
+	return &InvitationGuestbookRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+// GetEntriesByInvitationID, mesajları en yeniden eskiye döner; status boşsa tüm durumlar gelir.
+func (r *InvitationGuestbookRepository) GetEntriesByInvitationID(invitationID uint, status models.GuestbookEntryStatus) ([]models.InvitationGuestbookEntry, error) {
+	var entries []models.InvitationGuestbookEntry
+	query := r.db.Preload("Guest").Where("invitation_id = ?", invitationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id desc").Find(&entries).Error
+	return entries, err
+}
+
+func (r *InvitationGuestbookRepository) GetApprovedEntries(invitationID uint, limit int) ([]models.InvitationGuestbookEntry, error) {
+	var entries []models.InvitationGuestbookEntry
+	err := r.db.
+		Where("invitation_id = ? AND status = ?", invitationID, models.GuestbookEntryApproved).
+		Order("id desc").Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *InvitationGuestbookRepository) GetEntryByID(id uint) (*models.InvitationGuestbookEntry, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *InvitationGuestbookRepository) CountByStatus(invitationID uint) (map[models.GuestbookEntryStatus]int64, error) {
+	var rows []struct {
+		Status models.GuestbookEntryStatus
+		Count  int64
+	}
+	err := r.db.Model(&models.InvitationGuestbookEntry{}).
+		Select("status, COUNT(*) AS count").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[models.GuestbookEntryStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// HasMessage, aynı mesajın davetiyenin anı defterinde zaten bulunup bulunmadığını bildirir.
+func (r *InvitationGuestbookRepository) HasMessage(invitationID uint, message string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.InvitationGuestbookEntry{}).
+		Where("invitation_id = ? AND message = ?", invitationID, message).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *InvitationGuestbookRepository) CreateEntry(ctx context.Context, entry *models.InvitationGuestbookEntry) error {
+	return r.base.Create(ctx, entry)
+}
+
+func (r *InvitationGuestbookRepository) UpdateStatus(ctx context.Context, id uint, status models.GuestbookEntryStatus, updatedBy uint) error {
+	return r.base.Update(ctx, id, map[string]interface{}{"status": status}, updatedBy)
+}
+
+func (r *InvitationGuestbookRepository) DeleteEntry(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+var _ IInvitationGuestbookRepository = (*InvitationGuestbookRepository)(nil)
+var _ IBaseRepository[models.InvitationGuestbookEntry] = (*BaseRepository[models.InvitationGuestbookEntry])(nil)
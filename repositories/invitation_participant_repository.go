@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+// ParticipantStatusCount, bir katılım durumundaki kayıt ve kişi sayısını tutar.
+type ParticipantStatusCount struct {
+	Status models.RSVPStatus
+	Count  int64
+	Guests int64
+}
+
+// ParticipantImportOutcome, içe aktarılan katılımcıların kapasite kurallarına göre nasıl kaydedildiğini taşır.
+type ParticipantImportOutcome struct {
+	Created    int
+	Updated    int
+	Waitlisted int
+	// Rejected, kalan kapasite yetmediği için güncellenmeyen satırların indeksini, o katılımcı için
+	// izin verilen en fazla kişi sayısına eşler.
+	Rejected map[int]int64
+	// OverPerParticipant, kişi sayısı davetiyenin katılımcı başına sınırını aştığı için kaydedilmeyen
+	// satırların indeksleridir.
+	OverPerParticipant []int
+	// Promoted, kişi sayısı azalan kayıtlar nedeniyle yedek listeden katılımcı listesine alınan kayıtlardır.
+	Promoted   []models.InvitationParticipant
+	Invitation *models.Invitation
+}
+
+type IInvitationParticipantRepository interface {
+	GetParticipantsByInvitationID(invitationID uint) ([]models.InvitationParticipant, error)
+	GetParticipantByID(id uint) (*models.InvitationParticipant, error)
+	CreateParticipant(ctx context.Context, participant *models.InvitationParticipant) error
+	UpdateParticipant(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	DeleteParticipant(ctx context.Context, id uint) error
+	UpsertParticipantsByPhone(ctx context.Context, invitationID uint, participants []models.InvitationParticipant) (*ParticipantImportOutcome, error)
+	CountByStatus(invitationID uint) ([]ParticipantStatusCount, error)
+	GetParticipantCount() (int64, error)
+}
+
+type InvitationParticipantRepository struct {
+	base IBaseRepository[models.InvitationParticipant]
+	db   *gorm.DB
+}
+
+func NewInvitationParticipantRepository() IInvitationParticipantRepository {
+	base := NewBaseRepository[models.InvitationParticipant](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "title", "guest_count", "created_at"})
+	base.SetPreloads("Invitation")
+	return &InvitationParticipantRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationParticipantRepository) GetParticipantsByInvitationID(invitationID uint) ([]models.InvitationParticipant, error) {
+	var participants []models.InvitationParticipant
+	err := r.db.Preload("Answers").Where("invitation_id = ?", invitationID).Order("id asc").Find(&participants).Error
+	return participants, err
+}
+
+func (r *InvitationParticipantRepository) GetParticipantByID(id uint) (*models.InvitationParticipant, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *InvitationParticipantRepository) CreateParticipant(ctx context.Context, participant *models.InvitationParticipant) error {
+	return r.base.Create(ctx, participant)
+}
+
+func (r *InvitationParticipantRepository) UpdateParticipant(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.base.Update(ctx, id, data, updatedBy)
+}
+
+func (r *InvitationParticipantRepository) DeleteParticipant(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+// UpsertParticipantsByPhone, katılımcıları tek bir transaction içinde kaydeder.
+// Aynı davetiyede aynı telefon numarasıyla kayıtlı bir katılımcı varsa yeni kayıt açılmaz, mevcut kayıt güncellenir.
+//
+// Kapasite kuralları SaveRSVP ile aynıdır: davetiye satırı kilitlenir, kapasiteye sığmayan yeni
+// katılımcılar yedek listeye alınır, katılacağını bildirmiş bir kaydın kişi sayısı kapasiteyi aşacaksa
+// satır güncellenmez; MaxGuestsPerParticipant sınırını aşan satırlar hiç kaydedilmez. Kişi sayısı
+// azalan kayıtların boşalttığı yerlere yedek listeden katılımcı alınır.
+func (r *InvitationParticipantRepository) UpsertParticipantsByPhone(ctx context.Context, invitationID uint, participants []models.InvitationParticipant) (*ParticipantImportOutcome, error) {
+	outcome := &ParticipantImportOutcome{Rejected: make(map[int]int64)}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := lockInvitation(tx, invitationID)
+		if err != nil {
+			return err
+		}
+		outcome.Invitation = invitation
+
+		for i := range participants {
+			participant := &participants[i]
+			participant.InvitationID = invitationID
+			if invitation.MaxGuestsPerParticipant > 0 && participant.GuestCount > invitation.MaxGuestsPerParticipant {
+				outcome.OverPerParticipant = append(outcome.OverPerParticipant, i)
+				continue
+			}
+
+			var existing models.InvitationParticipant
+			err := tx.Where("invitation_id = ? AND phone_number = ?", invitationID, participant.PhoneNumber).First(&existing).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				participant.Status = models.RSVPAttending
+				fits, _, err := fitsCapacity(tx, invitation, participant.GuestCount, 0)
+				if err != nil {
+					return err
+				}
+				if !fits {
+					now := time.Now()
+					participant.Status = models.RSVPWaitlisted
+					participant.WaitlistedAt = &now
+					outcome.Waitlisted++
+				}
+				if err := tx.Create(participant).Error; err != nil {
+					return err
+				}
+				outcome.Created++
+				continue
+			}
+
+			if existing.Status == models.RSVPAttending && participant.GuestCount > existing.GuestCount {
+				fits, available, err := fitsCapacity(tx, invitation, participant.GuestCount, existing.ID)
+				if err != nil {
+					return err
+				}
+				if !fits {
+					outcome.Rejected[i] = available
+					continue
+				}
+			}
+
+			if err := tx.Model(&existing).Updates(map[string]interface{}{
+				"title":       participant.Title,
+				"guest_count": participant.GuestCount,
+			}).Error; err != nil {
+				return err
+			}
+			participant.ID = existing.ID
+			outcome.Updated++
+		}
+
+		promoted, err := promoteWaitlist(tx, invitation)
+		if err != nil {
+			return err
+		}
+		outcome.Promoted = promoted
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+// fitsCapacity, guestCount kişilik bir katılımın davetiyenin kalan kapasitesine sığıp sığmadığını
+// ve kalan kişi sayısını döner. Kapasite sınırı yoksa her katılım sığar.
+func fitsCapacity(tx *gorm.DB, invitation *models.Invitation, guestCount int, excludeParticipantID uint) (bool, int64, error) {
+	if invitation.MaxGuests <= 0 {
+		return true, 0, nil
+	}
+	seats, err := attendingSeats(tx, invitation.ID, excludeParticipantID)
+	if err != nil {
+		return false, 0, err
+	}
+	available := int64(invitation.MaxGuests) - seats
+	return int64(guestCount) <= available, available, nil
+}
+
+func (r *InvitationParticipantRepository) CountByStatus(invitationID uint) ([]ParticipantStatusCount, error) {
+	var rows []ParticipantStatusCount
+	err := r.db.Model(&models.InvitationParticipant{}).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(guest_count), 0) AS guests").
+		Where("invitation_id = ?", invitationID).
+		Group("status").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *InvitationParticipantRepository) GetParticipantCount() (int64, error) {
+	return r.base.GetCount()
+}
+
+var _ IInvitationParticipantRepository = (*InvitationParticipantRepository)(nil)
+var _ IBaseRepository[models.InvitationParticipant] = (*BaseRepository[models.InvitationParticipant])(nil)
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+// AnswerValueCount, seçmeli ve evet/hayır sorularında her değer için yanıt sayısını tutar.
+type AnswerValueCount struct {
+	QuestionID  uint
+	ChoiceValue string
+	BoolValue   *bool
+	Count       int64
+	Guests      int64
+}
+
+// AnswerNumberStats, sayısal bir soruya verilen yanıtların toplamını ve ortalamasını tutar.
+type AnswerNumberStats struct {
+	QuestionID uint
+	Count      int64
+	Total      float64
+	Average    float64
+}
+
+// AnswerText, serbest metin yanıtını yanıtlayan katılımcının adıyla birlikte tutar.
+type AnswerText struct {
+	QuestionID       uint
+	ParticipantTitle string
+	TextValue        string
+}
+
+type IInvitationQuestionRepository interface {
+	GetQuestionsByInvitationID(invitationID uint) ([]models.InvitationQuestion, error)
+	GetQuestionByID(id uint) (*models.InvitationQuestion, error)
+	CreateQuestion(ctx context.Context, question *models.InvitationQuestion) error
+	DeleteQuestion(ctx context.Context, id uint) error
+	GetNextSortOrder(invitationID uint) (int, error)
+	GetAnswersByParticipantID(participantID uint) ([]models.InvitationAnswer, error)
+	CountAnswerValues(invitationID uint) ([]AnswerValueCount, error)
+	GetNumberStats(invitationID uint) ([]AnswerNumberStats, error)
+	GetTextAnswers(invitationID uint) ([]AnswerText, error)
+}
+
+type InvitationQuestionRepository struct {
+	base IBaseRepository[models.InvitationQuestion]
+	db   *gorm.DB
+}
+
+func NewInvitationQuestionRepository() IInvitationQuestionRepository {
+	base := NewBaseRepository[models.InvitationQuestion](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "label", "sort_order", "created_at"})
+	return &InvitationQuestionRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *InvitationQuestionRepository) GetQuestionsByInvitationID(invitationID uint) ([]models.InvitationQuestion, error) {
+	var questions []models.InvitationQuestion
+	err := r.db.Where("invitation_id = ?", invitationID).Order("sort_order asc, id asc").Find(&questions).Error
+	return questions, err
+}
+
+func (r *InvitationQuestionRepository) GetQuestionByID(id uint) (*models.InvitationQuestion, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *InvitationQuestionRepository) CreateQuestion(ctx context.Context, question *models.InvitationQuestion) error {
+	return r.base.Create(ctx, question)
+}
+
+func (r *InvitationQuestionRepository) DeleteQuestion(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+func (r *InvitationQuestionRepository) GetNextSortOrder(invitationID uint) (int, error) {
+	var maxOrder int
+	err := r.db.Model(&models.InvitationQuestion{}).
+		Where("invitation_id = ?", invitationID).
+		Select("COALESCE(MAX(sort_order), 0)").
+		Scan(&maxOrder).Error
+	return maxOrder + 1, err
+}
+
+func (r *InvitationQuestionRepository) GetAnswersByParticipantID(participantID uint) ([]models.InvitationAnswer, error) {
+	var answers []models.InvitationAnswer
+	err := r.db.Where("participant_id = ?", participantID).Find(&answers).Error
+	return answers, err
+}
+
+// answeredByGuests, katılmayacağını bildirenler hariç, davetiyenin katılımcılarına ait yanıtları seçer.
+func (r *InvitationQuestionRepository) answeredByGuests(invitationID uint) *gorm.DB {
+	return r.db.Table("invitation_answers AS a").
+		Joins("JOIN invitation_participants AS p ON p.id = a.participant_id AND p.deleted_at IS NULL").
+		Where("p.invitation_id = ? AND p.status <> ? AND a.deleted_at IS NULL", invitationID, models.RSVPNotAttending)
+}
+
+func (r *InvitationQuestionRepository) CountAnswerValues(invitationID uint) ([]AnswerValueCount, error) {
+	var rows []AnswerValueCount
+	err := r.answeredByGuests(invitationID).
+		Select("a.question_id, a.choice_value, a.bool_value, COUNT(*) AS count, COALESCE(SUM(p.guest_count), 0) AS guests").
+		Where("a.choice_value <> '' OR a.bool_value IS NOT NULL").
+		Group("a.question_id, a.choice_value, a.bool_value").
+		Order("a.question_id, count DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *InvitationQuestionRepository) GetNumberStats(invitationID uint) ([]AnswerNumberStats, error) {
+	var rows []AnswerNumberStats
+	err := r.answeredByGuests(invitationID).
+		Select("a.question_id, COUNT(a.number_value) AS count, COALESCE(SUM(a.number_value), 0) AS total, COALESCE(AVG(a.number_value), 0) AS average").
+		Where("a.number_value IS NOT NULL").
+		Group("a.question_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *InvitationQuestionRepository) GetTextAnswers(invitationID uint) ([]AnswerText, error) {
+	var rows []AnswerText
+	err := r.answeredByGuests(invitationID).
+		Select("a.question_id, p.title AS participant_title, a.text_value").
+		Where("a.text_value <> ''").
+		Order("a.id DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+var _ IInvitationQuestionRepository = (*InvitationQuestionRepository)(nil)
+var _ IBaseRepository[models.InvitationQuestion] = (*BaseRepository[models.InvitationQuestion])(nil)
@@ -11,10 +11,11 @@ import (
 
 type IInvitationRepository interface {
 	GetAllInvitations(params queryparams.ListParams) ([]models.Invitation, int64, error)
+	GetInvitationsForUser(userID uint, params queryparams.ListParams) ([]models.Invitation, int64, error)
 	GetInvitationByID(id uint) (*models.Invitation, error)
 	GetByInvitationKey(ctx context.Context, key string) (*models.Invitation, error) // YENİ METOT
 	CreateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
-	UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
+	UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) ([]models.InvitationParticipant, error)
 	DeleteInvitationWithRelations(ctx context.Context, id uint) error
 	GetInvitationCount() (int64, error)
 	KeyExists(ctx context.Context, key string) (bool, error)
@@ -40,6 +41,26 @@ func (r *InvitationRepository) GetAllInvitations(params queryparams.ListParams)
 	return r.base.GetAll(params)
 }
 
+// GetInvitationsForUser, kullanıcının sahibi olduğu ve ortak ev sahibi olarak davetini kabul
+// ettiği davetiyeleri listeler.
+func (r *InvitationRepository) GetInvitationsForUser(userID uint, params queryparams.ListParams) ([]models.Invitation, int64, error) {
+	var ids []uint
+	coHosted := r.db.Model(&models.InvitationCoHost{}).
+		Select("invitation_id").
+		Where("user_id = ? AND accepted_at IS NOT NULL", userID)
+	err := r.db.Model(&models.Invitation{}).
+		Where("user_id = ?", userID).
+		Or("id IN (?)", coHosted).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return []models.Invitation{}, 0, nil
+	}
+	return r.base.GetAllByCondition(params, map[string]interface{}{"id": ids})
+}
+
 func (r *InvitationRepository) GetInvitationByID(id uint) (*models.Invitation, error) {
 	return r.base.GetByID(id)
 }
@@ -50,6 +71,10 @@ func (r *InvitationRepository) GetByInvitationKey(ctx context.Context, key strin
 	for _, preload := range r.base.(*BaseRepository[models.Invitation]).preloads {
 		query = query.Preload(preload)
 	}
+	// Davetiye sayfası detay alanlarını kategorinin şemasına göre, programı da başlangıç
+	// zamanına göre gösterir. Kategori ve program yalnızca burada yüklenir; güncellemede
+	// ilişkilerle birlikte kaydedilip üzerine yazılmamaları için ortak preload listesine eklenmez.
+	query = query.Preload("Category").Preload("Events", orderEvents)
 
 	err := query.Where("invitation_key = ?", key).First(&result).Error
 	if err != nil {
@@ -65,8 +90,29 @@ func (r *InvitationRepository) CreateInvitationWithRelations(ctx context.Context
 	return r.base.CreateWithRelations(ctx, invitation)
 }
 
-func (r *InvitationRepository) UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error {
-	return r.base.UpdateWithRelations(ctx, invitation)
+// UpdateInvitationWithRelations, davetiyeyi ilişkileriyle kaydeder. Kapasite (MaxGuests) değiştiyse
+// davetiye satırı kilitliyken aynı transaction içinde yeni kapasiteye sığan yedek listedeki
+// katılımcılar katılımcı listesine alınır ve döner.
+func (r *InvitationRepository) UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) ([]models.InvitationParticipant, error) {
+	var promoted []models.InvitationParticipant
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockInvitation(tx, invitation.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(invitation).Error; err != nil {
+			return err
+		}
+		if current.MaxGuests == invitation.MaxGuests {
+			return nil
+		}
+		promoted, err = promoteWaitlist(tx, invitation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
 }
 
 func (r *InvitationRepository) DeleteInvitationWithRelations(ctx context.Context, id uint) error {
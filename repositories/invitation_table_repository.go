@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+	"davet.link/pkg/seating"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TableFullError, katılımcının grubunun masaya sığmadığını ve masada kalan boş koltuk sayısını bildirir.
+type TableFullError struct {
+	Available int
+}
+
+func (e *TableFullError) Error() string {
+	return "masada yeterli boş koltuk yok"
+}
+
+// AutoAssignOutcome, otomatik yerleştirmenin sonucudur.
+type AutoAssignOutcome struct {
+	Assigned int
+	Unplaced []models.InvitationParticipant
+}
+
+type IInvitationTableRepository interface {
+	GetTablesByInvitationID(invitationID uint) ([]models.InvitationTable, error)
+	GetTableByID(id uint) (*models.InvitationTable, error)
+	GetUnassignedParticipants(invitationID uint) ([]models.InvitationParticipant, error)
+	GetParticipantTable(participantID uint) (*models.InvitationTable, error)
+	CreateTable(ctx context.Context, table *models.InvitationTable) error
+	UpdateTable(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	DeleteTable(ctx context.Context, id uint) error
+	AssignParticipant(ctx context.Context, participant *models.InvitationParticipant, tableID *uint) error
+	AutoAssign(ctx context.Context, invitationID uint) (*AutoAssignOutcome, error)
+}
+
+type InvitationTableRepository struct {
+	base IBaseRepository[models.InvitationTable]
+	db   *gorm.DB
+}
+
+func NewInvitationTableRepository() IInvitationTableRepository {
+	base := NewBaseRepository[models.InvitationTable](databaseconfig.GetDB())
+	return &InvitationTableRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+// GetTablesByInvitationID, masaları katılımı onaylı katılımcılarıyla birlikte eklenme sırasıyla döner.
+func (r *InvitationTableRepository) GetTablesByInvitationID(invitationID uint) ([]models.InvitationTable, error) {
+	var tables []models.InvitationTable
+	err := r.db.
+		Preload("Participants", func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", models.RSVPAttending).Order("title asc")
+		}).
+		Where("invitation_id = ?", invitationID).
+		Order("id asc").
+		Find(&tables).Error
+	return tables, err
+}
+
+func (r *InvitationTableRepository) GetTableByID(id uint) (*models.InvitationTable, error) {
+	return r.base.GetByID(id)
+}
+
+// GetUnassignedParticipants, henüz masası olmayan ve katılımı onaylı katılımcıları döner.
+func (r *InvitationTableRepository) GetUnassignedParticipants(invitationID uint) ([]models.InvitationParticipant, error) {
+	var participants []models.InvitationParticipant
+	err := r.db.
+		Where("invitation_id = ? AND status = ? AND table_id IS NULL", invitationID, models.RSVPAttending).
+		Order("guest_count desc, title asc").
+		Find(&participants).Error
+	return participants, err
+}
+
+func (r *InvitationTableRepository) GetParticipantTable(participantID uint) (*models.InvitationTable, error) {
+	var table models.InvitationTable
+	err := r.db.
+		Joins("JOIN invitation_participants ON invitation_participants.table_id = invitation_tables.id").
+		Where("invitation_participants.id = ? AND invitation_participants.deleted_at IS NULL", participantID).
+		First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *InvitationTableRepository) CreateTable(ctx context.Context, table *models.InvitationTable) error {
+	return r.base.Create(ctx, table)
+}
+
+func (r *InvitationTableRepository) UpdateTable(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.base.Update(ctx, id, data, updatedBy)
+}
+
+// DeleteTable, masayı siler ve masadaki katılımcıları yerleştirilmemiş duruma getirir.
+func (r *InvitationTableRepository) DeleteTable(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.InvitationParticipant{}).Where("table_id = ?", id).Update("table_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.InvitationTable{}, id).Error
+	})
+}
+
+// AssignParticipant, katılımcıyı masaya yerleştirir; tableID nil ise masadan kaldırır. Masa satırı
+// işlem boyunca kilitlendiğinden aynı masaya eş zamanlı yapılan atamalar kapasiteyi aşamaz.
+func (r *InvitationTableRepository) AssignParticipant(ctx context.Context, participant *models.InvitationParticipant, tableID *uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tableID != nil {
+			var table models.InvitationTable
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&table, *tableID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrNotFound
+				}
+				return err
+			}
+			seated, err := seatedGuests(tx, table.ID, participant.ID)
+			if err != nil {
+				return err
+			}
+			if seated+participant.GuestCount > table.Capacity {
+				return &TableFullError{Available: max(table.Capacity-seated, 0)}
+			}
+		}
+		return tx.Model(&models.InvitationParticipant{}).Where("id = ?", participant.ID).Update("table_id", tableID).Error
+	})
+}
+
+// AutoAssign, masası olmayan katılımcıları gruplarını bölmeden boş koltuklara yerleştirir. Davetiyenin
+// tüm masaları işlem boyunca kilitlenir; böylece aynı anda yapılan elle atamalarla çakışmaz.
+func (r *InvitationTableRepository) AutoAssign(ctx context.Context, invitationID uint) (*AutoAssignOutcome, error) {
+	outcome := &AutoAssignOutcome{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tables []models.InvitationTable
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("invitation_id = ?", invitationID).
+			Order("id asc").
+			Find(&tables).Error; err != nil {
+			return err
+		}
+
+		var participants []models.InvitationParticipant
+		if err := tx.Where("invitation_id = ? AND status = ? AND table_id IS NULL", invitationID, models.RSVPAttending).
+			Order("id asc").
+			Find(&participants).Error; err != nil {
+			return err
+		}
+		if len(participants) == 0 {
+			return nil
+		}
+
+		seatingTables := make([]seating.Table, 0, len(tables))
+		for _, table := range tables {
+			seated, err := seatedGuests(tx, table.ID, 0)
+			if err != nil {
+				return err
+			}
+			seatingTables = append(seatingTables, seating.Table{ID: table.ID, Free: table.Capacity - seated})
+		}
+		parties := make([]seating.Party, 0, len(participants))
+		byID := make(map[uint]models.InvitationParticipant, len(participants))
+		for _, participant := range participants {
+			parties = append(parties, seating.Party{ID: participant.ID, Size: participant.GuestCount})
+			byID[participant.ID] = participant
+		}
+
+		assignments, unplaced := seating.Plan(seatingTables, parties)
+		for participantID, tableID := range assignments {
+			if err := tx.Model(&models.InvitationParticipant{}).Where("id = ?", participantID).Update("table_id", tableID).Error; err != nil {
+				return err
+			}
+		}
+		outcome.Assigned = len(assignments)
+		for _, participantID := range unplaced {
+			outcome.Unplaced = append(outcome.Unplaced, byID[participantID])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+// seatedGuests, masadaki katılımı onaylı katılımcıların toplam kişi sayısını döner; excludeID
+// yeniden yerleştirilen katılımcının kendi grubunu saymamak içindir.
+func seatedGuests(tx *gorm.DB, tableID, excludeID uint) (int, error) {
+	var seated int64
+	err := tx.Model(&models.InvitationParticipant{}).
+		Select("COALESCE(SUM(guest_count), 0)").
+		Where("table_id = ? AND status = ? AND id <> ?", tableID, models.RSVPAttending, excludeID).
+		Scan(&seated).Error
+	return int(seated), err
+}
+
+var _ IInvitationTableRepository = (*InvitationTableRepository)(nil)
+var _ IBaseRepository[models.InvitationTable] = (*BaseRepository[models.InvitationTable])(nil)
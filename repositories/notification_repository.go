@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type INotificationRepository interface {
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	GetNotificationsByUserID(userID uint, limit int) ([]models.Notification, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(ctx context.Context, userID uint, id uint, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID uint, readAt time.Time) error
+	MarkEmailed(ctx context.Context, ids []uint, emailedAt time.Time) error
+	GetUnemailedNotifications(userID uint, since time.Time) ([]models.Notification, error)
+
+	GetPreferenceByUserID(userID uint) (*models.NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *models.NotificationPreference) error
+	GetDigestPreferences() ([]models.NotificationPreference, error)
+	ClaimDigest(ctx context.Context, userID uint, since time.Time, sentAt time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID uint, sentAt time.Time, previous *time.Time) error
+}
+
+type NotificationRepository struct {
+	base IBaseRepository[models.Notification]
+	db   *gorm.DB
+}
+
+func NewNotificationRepository() INotificationRepository {
+	base := NewBaseRepository[models.Notification](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "created_at"})
+	return &NotificationRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.base.BulkCreate(ctx, notifications)
+}
+
+func (r *NotificationRepository) GetNotificationsByUserID(userID uint, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := r.db.Where("user_id = ?", userID).Order("created_at desc, id desc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead, yalnızca kullanıcıya ait bildirimi okundu olarak işaretler.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID uint, id uint, readAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uint, readAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt).Error
+}
+
+func (r *NotificationRepository) MarkEmailed(ctx context.Context, ids []uint, emailedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id IN ?", ids).
+		Update("emailed_at", emailedAt).Error
+}
+
+// GetUnemailedNotifications, verilen zamandan sonra oluşan ve henüz e-posta ile iletilmemiş bildirimleri döner.
+func (r *NotificationRepository) GetUnemailedNotifications(userID uint, since time.Time) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("user_id = ? AND emailed_at IS NULL AND created_at > ?", userID, since).
+		Order("created_at asc, id asc").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// GetPreferenceByUserID, kullanıcının tercihlerini döner; kaydı yoksa varsayılan tercihleri döner.
+func (r *NotificationRepository) GetPreferenceByUserID(userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&preference).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultNotificationPreference(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *NotificationRepository) SavePreference(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email_enabled", "frequency", "digest_hour", "updated_at", "updated_by"}),
+	}).Create(preference).Error
+}
+
+// GetDigestPreferences, günlük özet e-postası almak isteyen kullanıcıların tercihlerini döner.
+func (r *NotificationRepository) GetDigestPreferences() ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := r.db.Preload("User").
+		Where("email_enabled = ? AND frequency = ?", true, models.NotificationDailyDigest).
+		Find(&preferences).Error
+	return preferences, err
+}
+
+// ClaimDigest, kullanıcının since'ten beri özeti gönderilmemişse gönderim zamanını sentAt olarak
+// işaretler ve true döner. Koşullu güncelleme sayesinde birden fazla uygulama örneği aynı özeti
+// gönderemez; satırı yalnızca ilk güncelleyen örnek özeti gönderir.
+func (r *NotificationRepository) ClaimDigest(ctx context.Context, userID uint, since time.Time, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.NotificationPreference{}).
+		Where("user_id = ? AND (last_digest_at IS NULL OR last_digest_at < ?)", userID, since).
+		Update("last_digest_at", sentAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseDigest, gönderilemeyen özetin işaretini geri alır; özet bir sonraki çalışmada yeniden denenir.
+func (r *NotificationRepository) ReleaseDigest(ctx context.Context, userID uint, sentAt time.Time, previous *time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.NotificationPreference{}).
+		Where("user_id = ? AND last_digest_at = ?", userID, sentAt).
+		Update("last_digest_at", previous).Error
+}
+
+var _ INotificationRepository = (*NotificationRepository)(nil)
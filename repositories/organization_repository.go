@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IOrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organization *models.Organization, adminUserID uint) error
+	GetOrganizationByID(id uint) (*models.Organization, error)
+	UpdateOrganizationWithRelations(ctx context.Context, organization *models.Organization) error
+	GetMembershipsByUserID(userID uint) ([]models.OrganizationMember, error)
+	GetMembership(organizationID, userID uint) (*models.OrganizationMember, error)
+	AddMember(ctx context.Context, member *models.OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationID, userID uint) error
+	CountAdmins(organizationID uint) (int64, error)
+	GetCardsByOrganizationID(organizationID uint) ([]models.Card, error)
+	GetPanelUserByEmail(email string) (*models.User, error)
+}
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository() IOrganizationRepository {
+	return &OrganizationRepository{db: databaseconfig.GetDB()}
+}
+
+// CreateOrganization, organizasyonu oluşturur ve oluşturan kullanıcıyı yönetici olarak ekler.
+func (r *OrganizationRepository) CreateOrganization(ctx context.Context, organization *models.Organization, adminUserID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         adminUserID,
+			Role:           models.OrganizationRoleAdmin,
+		}).Error
+	})
+}
+
+func (r *OrganizationRepository) GetOrganizationByID(id uint) (*models.Organization, error) {
+	var organization models.Organization
+	err := r.db.
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("role asc, id asc") }).
+		Preload("Members.User").
+		Preload("Banks.Bank").
+		Preload("SocialMedia.SocialMedia").
+		First(&organization, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// UpdateOrganizationWithRelations, organizasyonu günceller; formdan gelmeyen banka ve sosyal
+// medya kayıtları silinir.
+func (r *OrganizationRepository) UpdateOrganizationWithRelations(ctx context.Context, organization *models.Organization) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bankIDs := []uint{0}
+		for _, bank := range organization.Banks {
+			if bank.ID != 0 {
+				bankIDs = append(bankIDs, bank.ID)
+			}
+		}
+		if err := tx.Where("organization_id = ? AND id NOT IN ?", organization.ID, bankIDs).Delete(&models.OrganizationBank{}).Error; err != nil {
+			return err
+		}
+
+		socialMediaIDs := []uint{0}
+		for _, sm := range organization.SocialMedia {
+			if sm.ID != 0 {
+				socialMediaIDs = append(socialMediaIDs, sm.ID)
+			}
+		}
+		if err := tx.Where("organization_id = ? AND id NOT IN ?", organization.ID, socialMediaIDs).Delete(&models.OrganizationSocialMedia{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Members").Save(organization).Error
+	})
+}
+
+func (r *OrganizationRepository) GetMembershipsByUserID(userID uint) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	err := r.db.Preload("Organization").Where("user_id = ?", userID).Order("id asc").Find(&members).Error
+	return members, err
+}
+
+func (r *OrganizationRepository) GetMembership(organizationID, userID uint) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *OrganizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+// RemoveMember, üyeliği kalıcı olarak siler; böylece kullanıcı daha sonra yeniden eklenebilir.
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uint) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&models.OrganizationMember{}).Error
+}
+
+func (r *OrganizationRepository) CountAdmins(organizationID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrganizationRoleAdmin).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *OrganizationRepository) GetCardsByOrganizationID(organizationID uint) ([]models.Card, error) {
+	var cards []models.Card
+	err := r.db.Preload("User").Where("organization_id = ?", organizationID).Order("id asc").Find(&cards).Error
+	return cards, err
+}
+
+func (r *OrganizationRepository) GetPanelUserByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("email = ? AND type = ?", email, models.Panel).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+var _ IOrganizationRepository = (*OrganizationRepository)(nil)
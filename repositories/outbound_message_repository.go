@@ -0,0 +1,250 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BroadcastStatusCount, bir duyurudaki mesajların durumlara göre sayısıdır.
+type BroadcastStatusCount struct {
+	BroadcastID uint
+	Status      models.OutboundMessageStatus
+	Count       int64
+}
+
+// MessageQuotaError, kuyruğa alınacak mesajların kullanıcının kalan günlük kotasını aştığını belirtir.
+type MessageQuotaError struct {
+	Remaining int64
+}
+
+func (e *MessageQuotaError) Error() string {
+	return "günlük mesaj kotası aşıldı"
+}
+
+type IOutboundMessageRepository interface {
+	EnqueueMessages(ctx context.Context, messages []models.OutboundMessage) (int64, error)
+	EnqueueMessagesWithinQuota(ctx context.Context, userID uint, messages []models.OutboundMessage, dailyQuota int64, since time.Time) (int64, error)
+	ClaimPending(ctx context.Context, limit int, staleAfter time.Duration) ([]models.OutboundMessage, error)
+	MarkSent(ctx context.Context, id uint, provider string, providerMessageID string, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, provider string, errText string, final bool) error
+	CountByUserSince(userID uint, since time.Time) (int64, error)
+
+	CreateBroadcast(ctx context.Context, broadcast *models.MessageBroadcast, messages []models.OutboundMessage, dailyQuota int64, since time.Time) error
+	GetBroadcastsByInvitationID(invitationID uint) ([]models.MessageBroadcast, error)
+	GetBroadcastWithMessages(id uint) (*models.MessageBroadcast, error)
+	CountBroadcastStatuses(broadcastIDs []uint) ([]BroadcastStatusCount, error)
+}
+
+type OutboundMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboundMessageRepository() IOutboundMessageRepository {
+	return &OutboundMessageRepository{db: databaseconfig.GetDB()}
+}
+
+// EnqueueMessages, mesajları kuyruğa ekler. Aynı DedupKey ile daha önce kuyruğa alınmış
+// mesajlar sessizce atlanır; dönen sayı gerçekten eklenen mesaj sayısıdır.
+func (r *OutboundMessageRepository) EnqueueMessages(ctx context.Context, messages []models.OutboundMessage) (int64, error) {
+	if len(messages) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedup_key"}}, DoNothing: true}).
+		Create(&messages)
+	return result.RowsAffected, result.Error
+}
+
+// EnqueueMessagesWithinQuota, mesajları kullanıcının kalan günlük kotasına sığıyorsa kuyruğa ekler.
+// Kota kontrolü ve ekleme, kullanıcı satırı kilitliyken aynı transaction içinde yapılır. Daha önce
+// kuyruğa alınmış (aynı tekilleştirme anahtarlı) mesajlar kotadan düşülmez ve tekrar eklenmez.
+func (r *OutboundMessageRepository) EnqueueMessagesWithinQuota(ctx context.Context, userID uint, messages []models.OutboundMessage, dailyQuota int64, since time.Time) (int64, error) {
+	if len(messages) == 0 {
+		return 0, nil
+	}
+	var queued int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		messages, err := withoutQueuedMessages(tx, messages)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		if err := reserveMessageQuota(tx, userID, int64(len(messages)), dailyQuota, since); err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedup_key"}}, DoNothing: true}).
+			Create(&messages)
+		queued = result.RowsAffected
+		return result.Error
+	})
+	return queued, err
+}
+
+// ClaimPending, gönderilecek mesajları tek bir UPDATE ile "sending" durumuna alıp döner.
+// FOR UPDATE SKIP LOCKED sayesinde birden fazla uygulama örneği aynı mesajı almaz.
+// staleAfter süresinden uzun süredir "sending" durumunda kalan mesajlar (ör: gönderim
+// sırasında uygulama kapandıysa) yeniden alınır.
+func (r *OutboundMessageRepository) ClaimPending(ctx context.Context, limit int, staleAfter time.Duration) ([]models.OutboundMessage, error) {
+	var messages []models.OutboundMessage
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE outbound_messages SET status = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM outbound_messages
+			WHERE deleted_at IS NULL AND (status = ? OR (status = ? AND updated_at < ?))
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.OutboundMessageSending, time.Now(),
+		models.OutboundMessagePending, models.OutboundMessageSending, time.Now().Add(-staleAfter),
+		limit,
+	).Scan(&messages).Error
+	return messages, err
+}
+
+func (r *OutboundMessageRepository) MarkSent(ctx context.Context, id uint, provider string, providerMessageID string, sentAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.OutboundMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":              models.OutboundMessageSent,
+		"provider":            provider,
+		"provider_message_id": providerMessageID,
+		"sent_at":             sentAt,
+		"attempts":            gorm.Expr("attempts + 1"),
+		"last_error":          "",
+	}).Error
+}
+
+// MarkFailed, başarısız denemeyi kaydeder. final false ise mesaj tekrar denenmek üzere kuyruğa döner.
+func (r *OutboundMessageRepository) MarkFailed(ctx context.Context, id uint, provider string, errText string, final bool) error {
+	status := models.OutboundMessagePending
+	if final {
+		status = models.OutboundMessageFailed
+	}
+	return r.db.WithContext(ctx).Model(&models.OutboundMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     status,
+		"provider":   provider,
+		"last_error": errText,
+		"attempts":   gorm.Expr("attempts + 1"),
+	}).Error
+}
+
+// CountByUserSince, kullanıcının verilen zamandan bu yana kuyruğa aldığı (başarısız olanlar hariç) mesaj sayısını döner.
+func (r *OutboundMessageRepository) CountByUserSince(userID uint, since time.Time) (int64, error) {
+	return countUserMessagesSince(r.db, userID, since)
+}
+
+// CreateBroadcast, duyuruyu ve mesajlarını kaydeder. Kota kontrolü ve kayıt aynı transaction
+// içinde, kullanıcı satırı kilitliyken yapılır; eş zamanlı gönderimler kotayı birlikte aşamaz.
+func (r *OutboundMessageRepository) CreateBroadcast(ctx context.Context, broadcast *models.MessageBroadcast, messages []models.OutboundMessage, dailyQuota int64, since time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reserveMessageQuota(tx, broadcast.UserID, int64(len(messages)), dailyQuota, since); err != nil {
+			return err
+		}
+		if err := tx.Create(broadcast).Error; err != nil {
+			return err
+		}
+		for i := range messages {
+			messages[i].BroadcastID = &broadcast.ID
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		return tx.Create(&messages).Error
+	})
+}
+
+func (r *OutboundMessageRepository) GetBroadcastsByInvitationID(invitationID uint) ([]models.MessageBroadcast, error) {
+	var broadcasts []models.MessageBroadcast
+	err := r.db.Where("invitation_id = ?", invitationID).Order("created_at desc, id desc").Find(&broadcasts).Error
+	return broadcasts, err
+}
+
+func (r *OutboundMessageRepository) GetBroadcastWithMessages(id uint) (*models.MessageBroadcast, error) {
+	var broadcast models.MessageBroadcast
+	err := r.db.
+		Preload("Messages", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Preload("Messages.Participant").
+		First(&broadcast, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+func (r *OutboundMessageRepository) CountBroadcastStatuses(broadcastIDs []uint) ([]BroadcastStatusCount, error) {
+	var rows []BroadcastStatusCount
+	if len(broadcastIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.Model(&models.OutboundMessage{}).
+		Select("broadcast_id, status, COUNT(*) AS count").
+		Where("broadcast_id IN ?", broadcastIDs).
+		Group("broadcast_id, status").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// withoutQueuedMessages, tekilleştirme anahtarı zaten kayıtlı olan mesajları listeden çıkarır.
+func withoutQueuedMessages(tx *gorm.DB, messages []models.OutboundMessage) ([]models.OutboundMessage, error) {
+	keys := make([]string, 0, len(messages))
+	for _, message := range messages {
+		keys = append(keys, message.DedupKey)
+	}
+	var existing []string
+	if err := tx.Unscoped().Model(&models.OutboundMessage{}).Where("dedup_key IN ?", keys).Pluck("dedup_key", &existing).Error; err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return messages, nil
+	}
+	queued := make(map[string]bool, len(existing))
+	for _, key := range existing {
+		queued[key] = true
+	}
+	result := make([]models.OutboundMessage, 0, len(messages))
+	for _, message := range messages {
+		if !queued[message.DedupKey] {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func countUserMessagesSince(db *gorm.DB, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := db.Model(&models.OutboundMessage{}).
+		Where("user_id = ? AND created_at >= ? AND status <> ?", userID, since, models.OutboundMessageFailed).
+		Count(&count).Error
+	return count, err
+}
+
+// reserveMessageQuota, kullanıcı satırını transaction sonuna kadar kilitler ve count mesajın
+// kalan günlük kotaya sığdığını doğrular. Sığmıyorsa MessageQuotaError döner.
+func reserveMessageQuota(tx *gorm.DB, userID uint, count int64, dailyQuota int64, since time.Time) error {
+	var user models.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+		return err
+	}
+	used, err := countUserMessagesSince(tx, userID, since)
+	if err != nil {
+		return err
+	}
+	if remaining := dailyQuota - used; count > remaining {
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &MessageQuotaError{Remaining: remaining}
+	}
+	return nil
+}
+
+var _ IOutboundMessageRepository = (*OutboundMessageRepository)(nil)
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+	"davet.link/pkg/queryparams"
+
+	"gorm.io/gorm"
+)
+
+type IPageRepository interface {
+	GetAllPages(params queryparams.ListParams) ([]models.Page, int64, error)
+	GetPageByID(id uint) (*models.Page, error)
+	GetPublishedPageBySlug(slug string) (*models.Page, error)
+	CreatePage(ctx context.Context, page *models.Page) error
+	UpdatePage(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	DeletePage(ctx context.Context, id uint) error
+	IsSlugAvailable(slug string, excludeID uint) (bool, error)
+}
+
+type PageRepository struct {
+	base IBaseRepository[models.Page]
+	db   *gorm.DB
+}
+
+func NewPageRepository() IPageRepository {
+	base := NewBaseRepository[models.Page](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "title", "slug", "is_published", "created_at", "updated_at"})
+	return &PageRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *PageRepository) GetAllPages(params queryparams.ListParams) ([]models.Page, int64, error) {
+	return r.base.GetAll(params)
+}
+
+func (r *PageRepository) GetPageByID(id uint) (*models.Page, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *PageRepository) GetPublishedPageBySlug(slug string) (*models.Page, error) {
+	var page models.Page
+	if err := r.db.Where("slug = ? AND is_published = ?", slug, true).First(&page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (r *PageRepository) CreatePage(ctx context.Context, page *models.Page) error {
+	return r.base.Create(ctx, page)
+}
+
+func (r *PageRepository) UpdatePage(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.base.Update(ctx, id, data, updatedBy)
+}
+
+func (r *PageRepository) DeletePage(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+// IsSlugAvailable, silinmiş sayfaları da sayar; slug üzerindeki benzersiz indeks soft delete
+// edilen kayıtları da kapsar.
+func (r *PageRepository) IsSlugAvailable(slug string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.Unscoped().Model(&models.Page{}).Where("slug = ?", slug)
+	if excludeID > 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+var _ IPageRepository = (*PageRepository)(nil)
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"errors"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IProvinceRepository interface {
+	GetProvinces() ([]models.Province, error)
+	GetProvinceByID(id uint) (*models.Province, error)
+	GetDistrictsByProvinceID(provinceID uint) ([]models.District, error)
+	GetDistrictByID(id uint) (*models.District, error)
+}
+
+type ProvinceRepository struct {
+	db *gorm.DB
+}
+
+func NewProvinceRepository() IProvinceRepository {
+	return &ProvinceRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *ProvinceRepository) GetProvinces() ([]models.Province, error) {
+	var provinces []models.Province
+	err := r.db.Order("code asc").Find(&provinces).Error
+	return provinces, err
+}
+
+func (r *ProvinceRepository) GetProvinceByID(id uint) (*models.Province, error) {
+	var province models.Province
+	if err := r.db.First(&province, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &province, nil
+}
+
+// GetDistrictsByProvinceID, ilin ilçelerini döner. Seeder ilçeleri Türkçe alfabetik sırayla
+// eklediği için kayıt sırası veritabanı harmanlamasından bağımsız olarak doğru sıralamayı verir.
+func (r *ProvinceRepository) GetDistrictsByProvinceID(provinceID uint) ([]models.District, error) {
+	var districts []models.District
+	err := r.db.Where("province_id = ?", provinceID).Order("id asc").Find(&districts).Error
+	return districts, err
+}
+
+func (r *ProvinceRepository) GetDistrictByID(id uint) (*models.District, error) {
+	var district models.District
+	if err := r.db.First(&district, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &district, nil
+}
+
+var _ IProvinceRepository = (*ProvinceRepository)(nil)
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+type IReminderRepository interface {
+	GetInvitationsWithReminders(from, to time.Time) ([]models.Invitation, error)
+	GetAttendingParticipants(invitationID uint) ([]models.InvitationParticipant, error)
+}
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository() IReminderRepository {
+	return &ReminderRepository{db: databaseconfig.GetDB()}
+}
+
+// GetInvitationsWithReminders, hatırlatma zamanı tanımlanmış ve etkinlik tarihi ya da program
+// bölümlerinden biri verilen aralıkta olan davetiyeleri program bölümleriyle birlikte döner.
+func (r *ReminderRepository) GetInvitationsWithReminders(from, to time.Time) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+	err := r.db.
+		Preload("Events", orderEvents).
+		Where("reminder_offsets IS NOT NULL AND reminder_offsets <> ''").
+		Where("(date BETWEEN ? AND ? OR EXISTS (?))", from, to,
+			r.db.Model(&models.InvitationEvent{}).
+				Select("1").
+				Where("invitation_events.invitation_id = invitations.id AND invitation_events.starts_at BETWEEN ? AND ?", from, to),
+		).
+		Order("date asc").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// GetAttendingParticipants, katılacağını bildirmiş ve telefon numarası olan katılımcıları program
+// bölümlerine verdikleri yanıtlarla birlikte döner.
+func (r *ReminderRepository) GetAttendingParticipants(invitationID uint) ([]models.InvitationParticipant, error) {
+	var participants []models.InvitationParticipant
+	err := r.db.
+		Preload("EventResponses").
+		Where("invitation_id = ? AND status = ? AND phone_number <> ''", invitationID, models.RSVPAttending).
+		Order("id asc").
+		Find(&participants).Error
+	return participants, err
+}
+
+var _ IReminderRepository = (*ReminderRepository)(nil)
@@ -0,0 +1,362 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRSVPDeadlinePassed = errors.New("katılım bildirimi için son tarih geçti")
+	// ErrRSVPPhoneRegistered, kişiye özel bağlantı dışından gelen bildirimin telefon numarasının
+	// davetiyede başka bir katılımcıya ait olduğunu belirtir; mevcut kayıt değiştirilmez.
+	ErrRSVPPhoneRegistered = errors.New("bu telefon numarasıyla daha önce katılım bildirilmiş")
+)
+
+// NotEnoughSeatsError, zaten katılacağını bildirmiş bir misafirin kişi sayısını artırmak
+// istediği ama kalan kapasitenin yetmediği durumu belirtir. Bu misafir yedek listeye düşürülmez.
+type NotEnoughSeatsError struct {
+	Available int64
+}
+
+func (e *NotEnoughSeatsError) Error() string {
+	return "kalan kapasite yetersiz"
+}
+
+// RSVPOutcome, bir katılım bildirimi veya iptal sonrasında oluşan durumu taşır.
+type RSVPOutcome struct {
+	// Waitlisted, kapasite dolduğu için bildirimin yedek listeye alındığını belirtir.
+	Waitlisted bool
+	// Promoted, boşalan yerler nedeniyle yedek listeden katılımcı listesine alınan kayıtlardır.
+	Promoted []models.InvitationParticipant
+	// Previous, güncellenen katılımcının önceki hâlidir; yeni kayıt oluşturulduysa nil'dir.
+	Previous *models.InvitationParticipant
+}
+
+type IRSVPRepository interface {
+	SaveRSVP(ctx context.Context, participant *models.InvitationParticipant, answers []models.InvitationAnswer, responses []models.InvitationEventResponse, guest *models.InvitationGuest, editToken string) (*RSVPOutcome, error)
+	GetParticipantByEditToken(invitationID uint, editToken string) (*models.InvitationParticipant, error)
+	DeleteParticipant(ctx context.Context, participant *models.InvitationParticipant) (*RSVPOutcome, error)
+}
+
+type RSVPRepository struct {
+	db *gorm.DB
+}
+
+func NewRSVPRepository() IRSVPRepository {
+	return &RSVPRepository{db: databaseconfig.GetDB()}
+}
+
+// SaveRSVP, katılım bildirimini, özel soru ve program bölümü yanıtlarını ve (varsa) misafir
+// kaydının durumunu tek bir transaction içinde kaydeder. Mevcut katılımcı yalnızca kişiye özel bağlantının
+// misafir kaydından ya da genel bağlantıdan yanıt verenin oturumundaki editToken'dan bulunur; telefon
+// numarası davetiyede zaten kayıtlıysa bildirim ErrRSVPPhoneRegistered ile reddedilir, böylece numarayı
+// bilen biri başkasının yanıtını değiştiremez.
+//
+// Davetiye satırı transaction boyunca kilitlenir (SELECT ... FOR UPDATE); aynı davetiyeye eş zamanlı
+// gelen bildirimler sırayla işlenir ve kapasite aşılamaz. Kapasite doluysa bildirim yedek listeye alınır.
+func (r *RSVPRepository) SaveRSVP(ctx context.Context, participant *models.InvitationParticipant, answers []models.InvitationAnswer, responses []models.InvitationEventResponse, guest *models.InvitationGuest, editToken string) (*RSVPOutcome, error) {
+	outcome := &RSVPOutcome{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := lockInvitation(tx, participant.InvitationID)
+		if err != nil {
+			return err
+		}
+		if invitation.IsRSVPClosed() {
+			return ErrRSVPDeadlinePassed
+		}
+
+		existing, err := findRSVPParticipant(tx, participant, guest, editToken)
+		if err != nil {
+			return err
+		}
+		outcome.Previous = existing
+
+		if participant.Status == models.RSVPAttending && invitation.MaxGuests > 0 {
+			excludeID := uint(0)
+			if existing != nil {
+				excludeID = existing.ID
+			}
+			seats, err := attendingSeats(tx, invitation.ID, excludeID)
+			if err != nil {
+				return err
+			}
+			if seats+int64(participant.GuestCount) > int64(invitation.MaxGuests) {
+				if existing != nil && existing.Status == models.RSVPAttending {
+					return &NotEnoughSeatsError{Available: int64(invitation.MaxGuests) - seats}
+				}
+				participant.Status = models.RSVPWaitlisted
+				outcome.Waitlisted = true
+			}
+		}
+		participant.WaitlistedAt = nil
+		if participant.Status == models.RSVPWaitlisted {
+			now := time.Now()
+			participant.WaitlistedAt = &now
+			// Yedek listede bekleyen misafir formu tekrar gönderdiğinde sırasını kaybetmez.
+			if existing != nil && existing.Status == models.RSVPWaitlisted && existing.WaitlistedAt != nil {
+				participant.WaitlistedAt = existing.WaitlistedAt
+			}
+		}
+
+		if existing == nil {
+			if err := tx.Create(participant).Error; err != nil {
+				return err
+			}
+		} else {
+			participant.ID = existing.ID
+			participant.EditToken = existing.EditToken
+			if err := tx.Model(existing).Updates(map[string]interface{}{
+				"title":         participant.Title,
+				"phone_number":  participant.PhoneNumber,
+				"guest_count":   participant.GuestCount,
+				"status":        participant.Status,
+				"message":       participant.Message,
+				"waitlisted_at": participant.WaitlistedAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("participant_id = ?", participant.ID).Delete(&models.InvitationAnswer{}).Error; err != nil {
+			return err
+		}
+		for i := range answers {
+			answers[i].ParticipantID = participant.ID
+		}
+		if len(answers) > 0 {
+			if err := tx.Create(&answers).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("participant_id = ?", participant.ID).Delete(&models.InvitationEventResponse{}).Error; err != nil {
+			return err
+		}
+		for i := range responses {
+			responses[i].ParticipantID = participant.ID
+		}
+		if len(responses) > 0 {
+			if err := tx.Create(&responses).Error; err != nil {
+				return err
+			}
+		}
+
+		if guest != nil {
+			status := guestStatusForRSVP(participant.Status)
+			now := time.Now()
+			if err := tx.Model(&models.InvitationGuest{}).Where("id = ?", guest.ID).Updates(map[string]interface{}{
+				"status":         status,
+				"participant_id": participant.ID,
+				"responded_at":   now,
+			}).Error; err != nil {
+				return err
+			}
+			guest.Status = status
+			guest.ParticipantID = &participant.ID
+			guest.RespondedAt = &now
+		}
+
+		// Koltuk sayısını azaltan veya iptal eden bildirim, bekleyenlere yer açabilir.
+		if existing != nil && existing.Status == models.RSVPAttending {
+			promoted, err := promoteWaitlist(tx, invitation)
+			if err != nil {
+				return err
+			}
+			outcome.Promoted = promoted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+// GetParticipantByEditToken, genel bağlantıdan yanıt veren misafirin kaydını oturumundaki anahtarla bulur.
+func (r *RSVPRepository) GetParticipantByEditToken(invitationID uint, editToken string) (*models.InvitationParticipant, error) {
+	var participant models.InvitationParticipant
+	err := r.db.Where("invitation_id = ? AND edit_token = ?", invitationID, editToken).First(&participant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// DeleteParticipant, katılımcıyı siler ve boşalan yerlere yedek listeden katılımcı alır.
+func (r *RSVPRepository) DeleteParticipant(ctx context.Context, participant *models.InvitationParticipant) (*RSVPOutcome, error) {
+	userID, ok := ctx.Value(userIDKey).(uint)
+	if !ok || userID == 0 {
+		return nil, ErrMissingUserID
+	}
+	outcome := &RSVPOutcome{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := lockInvitation(tx, participant.InvitationID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(participant).Update("deleted_by", userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(participant).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.InvitationGuest{}).
+			Where("participant_id = ?", participant.ID).
+			Updates(map[string]interface{}{"participant_id": nil, "status": models.GuestStatusDeclined}).Error; err != nil {
+			return err
+		}
+		if participant.Status != models.RSVPAttending {
+			return nil
+		}
+		promoted, err := promoteWaitlist(tx, invitation)
+		if err != nil {
+			return err
+		}
+		outcome.Promoted = promoted
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+func lockInvitation(tx *gorm.DB, invitationID uint) (*models.Invitation, error) {
+	var invitation models.Invitation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invitation, invitationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return &invitation, err
+}
+
+// attendingSeats, davetiyede katılacağını bildirenlerin toplam kişi sayısını döner.
+func attendingSeats(tx *gorm.DB, invitationID uint, excludeParticipantID uint) (int64, error) {
+	var seats int64
+	query := tx.Model(&models.InvitationParticipant{}).
+		Select("COALESCE(SUM(guest_count), 0)").
+		Where("invitation_id = ? AND status = ?", invitationID, models.RSVPAttending)
+	if excludeParticipantID != 0 {
+		query = query.Where("id <> ?", excludeParticipantID)
+	}
+	err := query.Scan(&seats).Error
+	return seats, err
+}
+
+// promoteWaitlist, boş kapasiteye sığan yedek listedeki katılımcıları bekleme sırasına göre
+// katılımcı listesine alır. Çağıran, davetiye satırını kilitlemiş olmalıdır.
+func promoteWaitlist(tx *gorm.DB, invitation *models.Invitation) ([]models.InvitationParticipant, error) {
+	var waiting []models.InvitationParticipant
+	if err := tx.Where("invitation_id = ? AND status = ?", invitation.ID, models.RSVPWaitlisted).
+		Order("waitlisted_at asc, id asc").
+		Find(&waiting).Error; err != nil {
+		return nil, err
+	}
+	if len(waiting) == 0 {
+		return nil, nil
+	}
+
+	free := int64(-1)
+	if invitation.MaxGuests > 0 {
+		seats, err := attendingSeats(tx, invitation.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		free = int64(invitation.MaxGuests) - seats
+	}
+
+	var promoted []models.InvitationParticipant
+	for _, participant := range waiting {
+		if free >= 0 && int64(participant.GuestCount) > free {
+			continue
+		}
+		if err := tx.Model(&participant).Updates(map[string]interface{}{
+			"status":        models.RSVPAttending,
+			"waitlisted_at": nil,
+		}).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Model(&models.InvitationGuest{}).
+			Where("participant_id = ?", participant.ID).
+			Update("status", models.GuestStatusAccepted).Error; err != nil {
+			return nil, err
+		}
+		participant.Status = models.RSVPAttending
+		participant.WaitlistedAt = nil
+		promoted = append(promoted, participant)
+		if free >= 0 {
+			free -= int64(participant.GuestCount)
+		}
+	}
+	return promoted, nil
+}
+
+// findRSVPParticipant, bildirimin güncelleyeceği katılımcıyı misafirin bağlı olduğu kayıttan ya da
+// genel bağlantıdan yanıt verenin editToken'ından bulur. Güncellenecek kayıt yoksa ve telefon numarası
+// davetiyede başka bir katılımcıya aitse ErrRSVPPhoneRegistered döner.
+func findRSVPParticipant(tx *gorm.DB, participant *models.InvitationParticipant, guest *models.InvitationGuest, editToken string) (*models.InvitationParticipant, error) {
+	var existing models.InvitationParticipant
+	var query *gorm.DB
+	switch {
+	case guest != nil && guest.ParticipantID != nil:
+		query = tx.Where("id = ? AND invitation_id = ?", *guest.ParticipantID, participant.InvitationID)
+	case guest == nil && editToken != "":
+		query = tx.Where("edit_token = ? AND invitation_id = ?", editToken, participant.InvitationID)
+	}
+	if query != nil {
+		err := query.First(&existing).Error
+		if err == nil {
+			if existing.PhoneNumber != participant.PhoneNumber {
+				if err := ensurePhoneAvailable(tx, participant, existing.ID); err != nil {
+					return nil, err
+				}
+			}
+			return &existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	return nil, ensurePhoneAvailable(tx, participant, 0)
+}
+
+func ensurePhoneAvailable(tx *gorm.DB, participant *models.InvitationParticipant, excludeID uint) error {
+	var count int64
+	query := tx.Model(&models.InvitationParticipant{}).
+		Where("invitation_id = ? AND phone_number = ?", participant.InvitationID, participant.PhoneNumber)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRSVPPhoneRegistered
+	}
+	return nil
+}
+
+func guestStatusForRSVP(status models.RSVPStatus) models.InvitationGuestStatus {
+	switch status {
+	case models.RSVPNotAttending:
+		return models.GuestStatusDeclined
+	case models.RSVPMaybe:
+		return models.GuestStatusMaybe
+	case models.RSVPWaitlisted:
+		return models.GuestStatusWaitlisted
+	default:
+		return models.GuestStatusAccepted
+	}
+}
+
+var _ IRSVPRepository = (*RSVPRepository)(nil)
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"time"
+
+	"davet.link/configs/databaseconfig"
+	"davet.link/models"
+
+	"gorm.io/gorm"
+)
+
+// SitemapEntry, site haritasına yazılacak herkese açık bir sayfanın adres anahtarı ve son
+// güncellenme zamanıdır.
+type SitemapEntry struct {
+	Key       string
+	UpdatedAt time.Time
+}
+
+// ISitemapRepository, site haritasına girecek kayıtları yalnızca gereken kolonlarla döner.
+type ISitemapRepository interface {
+	GetActiveCardSlugs() ([]SitemapEntry, error)
+	GetPublishedPageSlugs() ([]SitemapEntry, error)
+	GetPublicInvitationKeys() ([]SitemapEntry, error)
+}
+
+type SitemapRepository struct {
+	db *gorm.DB
+}
+
+func NewSitemapRepository() ISitemapRepository {
+	return &SitemapRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *SitemapRepository) GetActiveCardSlugs() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := r.db.Model(&models.Card{}).
+		Select("slug AS key, updated_at").
+		Where("is_active = ?", true).
+		Order("id asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SitemapRepository) GetPublishedPageSlugs() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := r.db.Model(&models.Page{}).
+		Select("slug AS key, updated_at").
+		Where("is_published = ?", true).
+		Order("id asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *SitemapRepository) GetPublicInvitationKeys() ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	err := r.db.Model(&models.Invitation{}).
+		Select("invitation_key AS key, updated_at").
+		Where("is_public = ?", true).
+		Order("id asc").
+		Scan(&rows).Error
+	return rows, err
+}
+
+var _ ISitemapRepository = (*SitemapRepository)(nil)
@@ -9,6 +9,7 @@ import (
 type BankRequest struct {
 	Name     string `form:"name" validate:"required,min=2"`
 	IsActive string `form:"is_active" validate:"required"`
+	Code     string `form:"code" validate:"omitempty,len=5,numeric"`
 }
 
 func validateBankRequest(c *fiber.Ctx, req interface{}, errorMessages map[string]string, redirectPath string) error {
@@ -36,6 +37,8 @@ func ValidateBankRequest(c *fiber.Ctx) error {
 		"Name_required": "Banka adı zorunludur",
 		"Name_min":      "Banka adı en az 2 karakter olmalıdır",
 		"IsActive_required": "Durum (Aktif/Pasif) seçilmelidir",
+		"Code_len":          "EFT kodu 5 haneli olmalıdır",
+		"Code_numeric":      "EFT kodu yalnızca rakamlardan oluşmalıdır",
 	}
 	if err := validateBankRequest(c, &req, errorMessages, "/dashboard/banks/create"); err != nil {
 		return err
@@ -2,12 +2,16 @@ package requests
 
 import (
 	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/iban"
+	"davet.link/pkg/slugpolicy"
+	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 type CardRequest struct {
@@ -17,24 +21,28 @@ type CardRequest struct {
 	Photo           string                   `form:"photo"`
 	Telephone       string                   `form:"telephone"`
 	Email           string                   `form:"email" validate:"omitempty,email"`
-	Location        string                   `form:"location" validate:"omitempty,url"`
 	WebsiteUrl      string                   `form:"website_url" validate:"omitempty,url"`
 	StoreUrl        string                   `form:"store_url" validate:"omitempty,url"`
 	IsActive        string                   `form:"is_active" validate:"required,boolean"`
+	Venue           VenueRequest             `form:"-"`
 	CardBanks       []CardBankRequest        `validate:"dive"`
 	CardSocialMedia []CardSocialMediaRequest `validate:"dive"`
 }
 
+// CardBankRequest, kart ve organizasyon formlarındaki banka satırıdır. IBAN normalize edilmiş
+// olarak tutulur; BankID boşsa TR IBAN'larında banka koddan belirlenir.
 type CardBankRequest struct {
 	ID     uint   `validate:"-"`
-	BankID uint   `validate:"required"`
-	IBAN   string `validate:"required"`
+	BankID uint   `validate:"-"`
+	IBAN   string `validate:"required,iban"`
 }
 
+// CardSocialMediaRequest, sosyal medya satırıdır. URL alanı "@kullanici" ya da tam profil adresi
+// olabilir; platform kurallarına göre servis katmanında profil adresine çevrilir.
 type CardSocialMediaRequest struct {
 	ID            uint   `validate:"-"`
 	SocialMediaID uint   `validate:"required"`
-	URL           string `validate:"required,url"`
+	URL           string `validate:"required,max=255"`
 }
 
 func parseBanksFromMap(formValues map[string][]string) []CardBankRequest {
@@ -57,7 +65,7 @@ func parseBanksFromMap(formValues map[string][]string) []CardBankRequest {
 				id, _ := strconv.ParseUint(value, 10, 64)
 				banksData[index].BankID = uint(id)
 			case "iban":
-				banksData[index].IBAN = value
+				banksData[index].IBAN = iban.Normalize(value)
 			case "id":
 				id, _ := strconv.ParseUint(value, 10, 64)
 				banksData[index].ID = uint(id)
@@ -72,7 +80,7 @@ func parseBanksFromMap(formValues map[string][]string) []CardBankRequest {
 	sort.Ints(indexes)
 	var cardBanks []CardBankRequest
 	for _, i := range indexes {
-		if banksData[i] != nil && banksData[i].BankID > 0 && banksData[i].IBAN != "" {
+		if banksData[i] != nil && banksData[i].IBAN != "" {
 			cardBanks = append(cardBanks, *banksData[i])
 		}
 	}
@@ -99,7 +107,7 @@ func parseSocialMediaFromMap(formValues map[string][]string) []CardSocialMediaRe
 				id, _ := strconv.ParseUint(value, 10, 64)
 				socialData[index].SocialMediaID = uint(id)
 			case "url":
-				socialData[index].URL = value
+				socialData[index].URL = strings.TrimSpace(value)
 			case "id":
 				id, _ := strconv.ParseUint(value, 10, 64)
 				socialData[index].ID = uint(id)
@@ -130,19 +138,19 @@ func mainValidateCardRequest(c *fiber.Ctx) (*CardRequest, error) {
 
 	req := CardRequest{}
 	if val, ok := formValues["name"]; ok && len(val) > 0 { req.Name = val[0] }
-	if val, ok := formValues["slug"]; ok && len(val) > 0 { req.Slug = val[0] }
+	if val, ok := formValues["slug"]; ok && len(val) > 0 { req.Slug = slugpolicy.Normalize(val[0]) }
 	if val, ok := formValues["title"]; ok && len(val) > 0 { req.Title = val[0] }
 	if val, ok := formValues["telephone"]; ok && len(val) > 0 { req.Telephone = val[0] }
 	if val, ok := formValues["email"]; ok && len(val) > 0 { req.Email = val[0] }
-	if val, ok := formValues["location"]; ok && len(val) > 0 { req.Location = val[0] }
 	if val, ok := formValues["website_url"]; ok && len(val) > 0 { req.WebsiteUrl = val[0] }
 	if val, ok := formValues["store_url"]; ok && len(val) > 0 { req.StoreUrl = val[0] }
 	if val, ok := formValues["is_active"]; ok && len(val) > 0 { req.IsActive = val[0] }
 
+	req.Venue = parseVenueRequest(firstFormValues(formValues))
 	req.CardBanks = parseBanksFromMap(formValues)
 	req.CardSocialMedia = parseSocialMediaFromMap(formValues)
 
-	validate := validator.New()
+	validate := newValidator()
 	if err := validate.Struct(req); err != nil {
 		return &req, fmt.Errorf("validation error: %w", err)
 	}
@@ -157,8 +165,15 @@ func ValidateCardRequest(c *fiber.Ctx) error {
 			c.Locals("cardRequest", *req)
 		}
 
-		if _, ok := err.(validator.ValidationErrors); ok {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen formdaki tüm zorunlu alanları doğru bir şekilde doldurun.")
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			if validationErrors[0].Tag() == "iban" {
+				_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz IBAN: "+iban.Format(validationErrors[0].Value().(string)))
+			} else if msg, ok := venueValidationMessage(err); ok {
+				_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+			} else {
+				_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen formdaki tüm zorunlu alanları doğru bir şekilde doldurun.")
+			}
 		} else {
 			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "İsteğiniz işlenirken bir sorun oluştu.")
 		}
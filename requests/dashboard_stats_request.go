@@ -0,0 +1,60 @@
+package requests
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	dashboardStatsDateLayout  = "2006-01-02"
+	dashboardStatsDefaultDays = 30
+	dashboardStatsMaxDays     = 366
+)
+
+// DashboardStatsRequest, yönetim paneli istatistikleri için tarih aralığıdır. Boş bırakılan
+// uçlar varsayılan olarak son 30 günü kapsar; iki uç da dahildir.
+type DashboardStatsRequest struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// ValidateDashboardStatsRequest, sorgu parametrelerinden tarih aralığını okur. Aralık geçersizse
+// hata mesajıyla birlikte varsayılan (boş) istek döner; sayfa yine de varsayılan aralıkla
+// gösterilebilir.
+func ValidateDashboardStatsRequest(c *fiber.Ctx) (DashboardStatsRequest, error) {
+	var req DashboardStatsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return DashboardStatsRequest{}, errors.New("Geçersiz tarih aralığı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return DashboardStatsRequest{}, errors.New("Tarihler YYYY-AA-GG biçiminde olmalıdır")
+	}
+
+	from, to := req.Range(time.Now())
+	if to.Before(from) {
+		return DashboardStatsRequest{}, errors.New("Başlangıç tarihi bitiş tarihinden sonra olamaz")
+	}
+	if to.Sub(from) > dashboardStatsMaxDays*24*time.Hour {
+		return DashboardStatsRequest{}, errors.New("Tarih aralığı en fazla 366 gün olabilir")
+	}
+	return req, nil
+}
+
+// Range, aralığın ilk ve son gününü yerel saatle gün başlangıcı olarak döner.
+func (r DashboardStatsRequest) Range(now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := today
+	if t, err := time.ParseInLocation(dashboardStatsDateLayout, r.To, now.Location()); err == nil {
+		to = t
+	}
+	from := to.AddDate(0, 0, -(dashboardStatsDefaultDays - 1))
+	if t, err := time.ParseInLocation(dashboardStatsDateLayout, r.From, now.Location()); err == nil {
+		from = t
+	}
+	return from, to
+}
@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// formTimeLayouts, HTML date, datetime-local ve time girişlerinin gönderdiği biçimlerdir.
+var formTimeLayouts = []string{
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"15:04",
+}
+
+var errInvalidFormTime = errors.New("geçersiz tarih veya saat")
+
+// parseFormTime, formdan ham metin olarak alınan tarih/saat değerini çözer. Boş değer sıfır
+// zaman döner; çözülemeyen değer hata döner ve isteğin doğrulamasında reddedilir.
+func parseFormTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range formTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errInvalidFormTime
+}
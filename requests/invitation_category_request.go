@@ -1,6 +1,9 @@
 package requests
 
 import (
+	"strings"
+
+	"davet.link/pkg/detailfields"
 	"davet.link/pkg/flashmessages"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -11,6 +14,38 @@ type InvitationCategoryRequest struct {
 	Icon     string `form:"icon" validate:"required"`
 	Template string `form:"template" validate:"required"`
 	IsActive string `form:"is_active" validate:"required"`
+	// Fields, formdaki fields[i][...] satırlarıdır; şemanın tutarlılığı serviste denetlenir.
+	Fields []InvitationCategoryFieldRequest `form:"fields"`
+}
+
+type InvitationCategoryFieldRequest struct {
+	Key      string `form:"key"`
+	Label    string `form:"label"`
+	Type     string `form:"type"`
+	Required bool   `form:"required"`
+	For      string `form:"for"`
+}
+
+// Schema, formdaki alan satırlarını şemaya çevirir; anahtarı boş bırakılan satırlar atılır.
+func (r InvitationCategoryRequest) Schema() detailfields.Schema {
+	var schema detailfields.Schema
+	for _, field := range r.Fields {
+		key := strings.TrimSpace(field.Key)
+		if key == "" {
+			continue
+		}
+		f := detailfields.Field{
+			Key:      key,
+			Label:    strings.TrimSpace(field.Label),
+			Type:     detailfields.FieldType(field.Type),
+			Required: field.Required,
+		}
+		if f.Type == detailfields.TypeDeceased {
+			f.For = strings.TrimSpace(field.For)
+		}
+		schema = append(schema, f)
+	}
+	return schema
 }
 
 func validateInvitationCategoryRequest(c *fiber.Ctx, req interface{}, errorMessages map[string]string, redirectPath string) error {
@@ -0,0 +1,71 @@
+package requests
+
+import (
+	"errors"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationCheckInRequest, kapıda okutulan ya da elle girilen bilet kodudur. Count boş
+// bırakılırsa biletin kalan hakkının tamamıyla giriş yapılır.
+type InvitationCheckInRequest struct {
+	Code  string `form:"code" json:"code" validate:"required,max=255"`
+	Count int    `form:"count" json:"count" validate:"min=0,max=100"`
+}
+
+type InvitationTicketSendRequest struct {
+	Channel string `form:"channel" validate:"required,oneof=sms whatsapp"`
+}
+
+// ValidateInvitationCheckInRequest, kapı ekranının JSON isteklerini doğrular. Hata mesajları
+// doğrudan ekranda gösterildiğinden flash mesajı kullanılmaz.
+func ValidateInvitationCheckInRequest(c *fiber.Ctx) (InvitationCheckInRequest, error) {
+	var req InvitationCheckInRequest
+	if err := c.BodyParser(&req); err != nil {
+		return InvitationCheckInRequest{}, errors.New("Geçersiz istek formatı")
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Code_required": "Bilet kodu zorunludur",
+			"Code_max":      "Bilet kodu geçersiz",
+			"Count_min":     "Kişi sayısı geçersiz",
+			"Count_max":     "Kişi sayısı en fazla 100 olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			return InvitationCheckInRequest{}, errors.New(msg)
+		}
+		return InvitationCheckInRequest{}, errors.New("Geçersiz giriş bilgileri")
+	}
+	return req, nil
+}
+
+func ValidateInvitationTicketSendRequest(c *fiber.Ctx) error {
+	var req InvitationTicketSendRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Channel_required": "Gönderim kanalı zorunludur",
+			"Channel_oneof":    "Geçersiz gönderim kanalı",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz gönderim bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("invitationTicketSendRequest", req)
+	return nil
+}
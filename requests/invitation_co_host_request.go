@@ -0,0 +1,69 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationCoHostRequest struct {
+	Email string `form:"email" validate:"required,email,max=255"`
+	Role  string `form:"role" validate:"required,oneof=editor guest_manager viewer"`
+}
+
+type InvitationCoHostRoleRequest struct {
+	Role string `form:"role" validate:"required,oneof=editor guest_manager viewer"`
+}
+
+var coHostErrorMessages = map[string]string{
+	"Email_required": "E-posta adresi zorunludur",
+	"Email_email":    "Geçerli bir e-posta adresi girin",
+	"Email_max":      "E-posta adresi en fazla 255 karakter olabilir",
+	"Role_required":  "Rol seçimi zorunludur",
+	"Role_oneof":     "Geçersiz rol",
+}
+
+func ValidateInvitationCoHostRequest(c *fiber.Ctx) error {
+	var req InvitationCoHostRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	if err := validateCoHostStruct(c, req); err != nil {
+		return err
+	}
+	c.Locals("invitationCoHostRequest", req)
+	return nil
+}
+
+func ValidateInvitationCoHostRoleRequest(c *fiber.Ctx) error {
+	var req InvitationCoHostRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	if err := validateCoHostStruct(c, req); err != nil {
+		return err
+	}
+	c.Locals("invitationCoHostRoleRequest", req)
+	return nil
+}
+
+func validateCoHostStruct(c *fiber.Ctx, req interface{}) error {
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		if msg, ok := coHostErrorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ortak ev sahibi bilgileri")
+		}
+		return err
+	}
+	return nil
+}
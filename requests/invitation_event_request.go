@@ -0,0 +1,83 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationEventRequest, davetiye programına eklenen bir bölümün formudur.
+type InvitationEventRequest struct {
+	Title string `form:"title" validate:"required,min=2,max=255"`
+	// StartsAtInput ve EndsAtInput formdaki ham değerlerdir; çözülmüş hâlleri StartsAt ve EndsAt
+	// alanlarındadır.
+	StartsAtInput string    `form:"starts_at"`
+	EndsAtInput   string    `form:"ends_at"`
+	StartsAt      time.Time `form:"-"`
+	EndsAt        time.Time `form:"-"`
+	IsRSVP        bool      `form:"is_rsvp"`
+	// Venue, formdaki venue_ önekli mekân alanlarıdır.
+	Venue VenueRequest `form:"-"`
+}
+
+func ValidateInvitationEventRequest(c *fiber.Ctx) error {
+	var req InvitationEventRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Venue = parseVenueRequest(formValues(c))
+
+	validate := newValidator()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Title_required": "Bölüm adı zorunludur",
+			"Title_min":      "Bölüm adı en az 2 karakter olmalıdır",
+			"Title_max":      "Bölüm adı en fazla 255 karakter olabilir",
+		}
+		if msg, ok := venueValidationMessage(err); ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz program bilgileri")
+		}
+		return err
+	}
+	startsAt, err := parseFormTime(req.StartsAtInput)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Başlangıç zamanı geçersiz")
+		return fmt.Errorf("validation error: starts_at: %w", err)
+	}
+	endsAt, err := parseFormTime(req.EndsAtInput)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bitiş zamanı geçersiz")
+		return fmt.Errorf("validation error: ends_at: %w", err)
+	}
+	req.StartsAt, req.EndsAt = startsAt, endsAt
+	if req.StartsAt.IsZero() {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Başlangıç zamanı zorunludur")
+		return errors.New("validation error: starts_at is required")
+	}
+	if !req.EndsAt.IsZero() && !req.EndsAt.After(req.StartsAt) {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bitiş zamanı başlangıçtan sonra olmalıdır")
+		return errors.New("validation error: ends_at must be after starts_at")
+	}
+
+	c.Locals("invitationEventRequest", req)
+	return nil
+}
+
+// EndsAtValue, formda bitiş zamanı girilmediyse nil döner.
+func (r InvitationEventRequest) EndsAtValue() *time.Time {
+	if r.EndsAt.IsZero() {
+		return nil
+	}
+	endsAt := r.EndsAt
+	return &endsAt
+}
@@ -0,0 +1,129 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationAlbumRequest, paneldeki galeri ayarları formudur. UploadPIN boş bırakılırsa
+// mevcut PIN korunur.
+type InvitationAlbumRequest struct {
+	Title           string `form:"title" validate:"max=255"`
+	GuestUpload     string `form:"guest_upload" validate:"required,oneof=off link pin"`
+	UploadPIN       string `form:"upload_pin" validate:"omitempty,numeric,min=4,max=8"`
+	RequireApproval bool   `form:"require_approval"`
+}
+
+// GalleryPINRequest, misafirin galeriye yükleme yapabilmek için girdiği PIN'dir.
+type GalleryPINRequest struct {
+	PIN string `form:"pin" validate:"required,max=8"`
+}
+
+// GalleryUploadRequest, misafir yüklemesindeki isteğe bağlı yükleyen adıdır; fotoğraflar
+// aynı formdaki photos alanından okunur.
+type GalleryUploadRequest struct {
+	Name string `form:"name" validate:"max=100"`
+}
+
+// PhotoStatusRequest, panelde fotoğrafın onaylanması veya gizlenmesi içindir.
+type PhotoStatusRequest struct {
+	Status string `form:"status" validate:"required,oneof=pending approved hidden"`
+}
+
+// AlbumQuotaRequest, yönetim panelinden albüme ayrılan depolama alanının değiştirilmesidir.
+type AlbumQuotaRequest struct {
+	QuotaMB int `form:"quota_mb" validate:"required,min=1,max=102400"`
+}
+
+func ValidateInvitationAlbumRequest(c *fiber.Ctx) error {
+	var req InvitationAlbumRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.UploadPIN = strings.TrimSpace(req.UploadPIN)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Title_max":            "Albüm adı en fazla 255 karakter olabilir",
+			"GuestUpload_required": "Misafir yükleme ayarı zorunludur",
+			"GuestUpload_oneof":    "Geçersiz misafir yükleme ayarı",
+			"UploadPIN_numeric":    "PIN yalnızca rakamlardan oluşmalıdır",
+			"UploadPIN_min":        "PIN en az 4 haneli olmalıdır",
+			"UploadPIN_max":        "PIN en fazla 8 haneli olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz galeri ayarları")
+		}
+		return err
+	}
+
+	c.Locals("invitationAlbumRequest", req)
+	return nil
+}
+
+func ValidateGalleryPINRequest(c *fiber.Ctx) error {
+	var req GalleryPINRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.PIN = strings.TrimSpace(req.PIN)
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen albüm PIN'ini girin")
+		return err
+	}
+	c.Locals("galleryPINRequest", req)
+	return nil
+}
+
+func ValidateGalleryUploadRequest(c *fiber.Ctx) error {
+	var req GalleryUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Adınız en fazla 100 karakter olabilir")
+		return err
+	}
+	c.Locals("galleryUploadRequest", req)
+	return nil
+}
+
+func ValidatePhotoStatusRequest(c *fiber.Ctx) error {
+	var req PhotoStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz fotoğraf durumu")
+		return err
+	}
+	c.Locals("photoStatusRequest", req)
+	return nil
+}
+
+func ValidateAlbumQuotaRequest(c *fiber.Ctx) error {
+	var req AlbumQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kota 1 MB ile 102400 MB arasında olmalıdır")
+		return err
+	}
+	c.Locals("albumQuotaRequest", req)
+	return nil
+}
@@ -0,0 +1,113 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/iban"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// InvitationBankRequest, davetiyeye eklenen hediye hesabıdır. IBAN normalize edilmiş olarak
+// tutulur; BankID boşsa TR IBAN'larında banka koddan belirlenir.
+type InvitationBankRequest struct {
+	BankID        uint   `form:"bank_id"`
+	IBAN          string `form:"iban" validate:"required,iban"`
+	AccountHolder string `form:"account_holder" validate:"max=100"`
+}
+
+// GiftItemRequest, hediye listesine eklenen ya da düzenlenen üründür.
+type GiftItemRequest struct {
+	Name        string `form:"name" validate:"required,max=150"`
+	Description string `form:"description" validate:"max=500"`
+	URL         string `form:"url" validate:"omitempty,url,max=500"`
+	Quantity    int    `form:"quantity" validate:"required,min=1,max=100"`
+}
+
+// GiftReservationRequest, misafirin ürünü ayırırken bıraktığı isteğe bağlı bilgilerdir; yalnızca
+// davetiye sahibine gösterilir.
+type GiftReservationRequest struct {
+	Name string `form:"name" validate:"max=100"`
+	Note string `form:"note" validate:"max=255"`
+}
+
+func ValidateInvitationBankRequest(c *fiber.Ctx) error {
+	var req InvitationBankRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.IBAN = iban.Normalize(req.IBAN)
+	req.AccountHolder = strings.TrimSpace(req.AccountHolder)
+
+	validate := newValidator()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"IBAN_required":     "IBAN zorunludur",
+			"IBAN_iban":         "Geçersiz IBAN: " + iban.Format(req.IBAN),
+			"AccountHolder_max": "Hesap sahibi en fazla 100 karakter olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz banka hesabı")
+		}
+		return err
+	}
+
+	c.Locals("invitationBankRequest", req)
+	return nil
+}
+
+func ValidateGiftItemRequest(c *fiber.Ctx) error {
+	var req GiftItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	req.URL = strings.TrimSpace(req.URL)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Name_required":     "Ürün adı zorunludur",
+			"Name_max":          "Ürün adı en fazla 150 karakter olabilir",
+			"Description_max":   "Açıklama en fazla 500 karakter olabilir",
+			"URL_url":           "Geçerli bir mağaza bağlantısı girin",
+			"URL_max":           "Mağaza bağlantısı en fazla 500 karakter olabilir",
+			"Quantity_required": "Adet en az 1 olmalıdır",
+			"Quantity_min":      "Adet en az 1 olmalıdır",
+			"Quantity_max":      "Adet en fazla 100 olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz ürün bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("giftItemRequest", req)
+	return nil
+}
+
+func ValidateGiftReservationRequest(c *fiber.Ctx) error {
+	var req GiftReservationRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Note = strings.TrimSpace(req.Note)
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Adınız en fazla 100, notunuz en fazla 255 karakter olabilir")
+		return err
+	}
+	c.Locals("giftReservationRequest", req)
+	return nil
+}
@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationGuestRequest struct {
+	Title       string `form:"title" validate:"required,min=2,max=255"`
+	PhoneNumber string `form:"phone_number" validate:"omitempty,min=10"`
+	SeatLimit   int    `form:"seat_limit" validate:"required,min=1,max=50"`
+}
+
+func ValidateInvitationGuestRequest(c *fiber.Ctx) error {
+	var req InvitationGuestRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Title_required":     "Misafir / hane adı zorunludur",
+			"Title_min":          "Misafir / hane adı en az 2 karakter olmalıdır",
+			"Title_max":          "Misafir / hane adı en fazla 255 karakter olabilir",
+			"PhoneNumber_min":    "Telefon numarası en az 10 karakter olmalıdır",
+			"SeatLimit_required": "Ayrılan kişi sayısı zorunludur",
+			"SeatLimit_min":      "Ayrılan kişi sayısı en az 1 olmalıdır",
+			"SeatLimit_max":      "Ayrılan kişi sayısı en fazla 50 olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz misafir bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("invitationGuestRequest", req)
+	return nil
+}
@@ -0,0 +1,73 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GuestbookEntryRequest, davetiye sayfasındaki anı defteri formudur. Website alanı formda
+// gizlidir; yalnızca formu otomatik dolduran botlar tarafından doldurulur.
+type GuestbookEntryRequest struct {
+	Name    string `form:"name" validate:"required,min=2,max=100"`
+	Message string `form:"message" validate:"required,min=2,max=1000"`
+	Website string `form:"website"`
+}
+
+// IsBot, gizli tuzak alanı doldurulmuş gönderimleri bildirir.
+func (r GuestbookEntryRequest) IsBot() bool {
+	return r.Website != ""
+}
+
+// GuestbookStatusRequest, panelde mesajın onaylanması veya gizlenmesi içindir.
+type GuestbookStatusRequest struct {
+	Status string `form:"status" validate:"required,oneof=pending approved hidden"`
+}
+
+func ValidateGuestbookEntryRequest(c *fiber.Ctx) error {
+	var req GuestbookEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Message = strings.TrimSpace(req.Message)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Name_required":    "Adınız zorunludur",
+			"Name_min":         "Adınız en az 2 karakter olmalıdır",
+			"Name_max":         "Adınız en fazla 100 karakter olabilir",
+			"Message_required": "Mesajınız zorunludur",
+			"Message_min":      "Mesajınız en az 2 karakter olmalıdır",
+			"Message_max":      "Mesajınız en fazla 1000 karakter olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz mesaj")
+		}
+		return err
+	}
+
+	c.Locals("guestbookEntryRequest", req)
+	return nil
+}
+
+func ValidateGuestbookStatusRequest(c *fiber.Ctx) error {
+	var req GuestbookStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	if err := validator.New().Struct(req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz mesaj durumu")
+		return err
+	}
+	c.Locals("guestbookStatusRequest", req)
+	return nil
+}
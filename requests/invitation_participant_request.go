@@ -1,6 +1,8 @@
 package requests
 
 import (
+	"errors"
+
 	"davet.link/pkg/flashmessages"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -12,6 +14,23 @@ type InvitationParticipantRequest struct {
 	GuestCount  int    `form:"guest_count" validate:"required,min=1"`
 }
 
+type InvitationParticipantImportRequest struct {
+	FileName          string `form:"file_name" validate:"required"`
+	TitleColumn       int    `form:"title_column" validate:"min=0"`
+	PhoneNumberColumn int    `form:"phone_number_column" validate:"min=0,nefield=TitleColumn"`
+	GuestCountColumn  int    `form:"guest_count_column" validate:"min=-1"`
+	HasHeader         bool   `form:"has_header"`
+}
+
+var invitationParticipantErrorMessages = map[string]string{
+	"Title_required":       "Ad Soyad zorunludur",
+	"Title_min":            "Ad Soyad en az 2 karakter olmalıdır",
+	"PhoneNumber_required": "Telefon numarası zorunludur",
+	"PhoneNumber_min":      "Telefon numarası en az 10 karakter olmalıdır",
+	"GuestCount_required":  "Kişi sayısı zorunludur",
+	"GuestCount_min":       "Kişi sayısı en az 1 olmalıdır",
+}
+
 func validateInvitationParticipantRequest(c *fiber.Ctx, req interface{}, errorMessages map[string]string, redirectPath string) error {
 	if err := c.BodyParser(req); err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
@@ -33,17 +52,52 @@ func validateInvitationParticipantRequest(c *fiber.Ctx, req interface{}, errorMe
 
 func ValidateInvitationParticipantRequest(c *fiber.Ctx) error {
 	var req InvitationParticipantRequest
-	errorMessages := map[string]string{
-		"Title_required":       "Ad Soyad zorunludur",
-		"Title_min":            "Ad Soyad en az 2 karakter olmalıdır",
-		"PhoneNumber_required": "Telefon numarası zorunludur",
-		"PhoneNumber_min":      "Telefon numarası en az 10 karakter olmalıdır",
-		"GuestCount_required":  "Kişi sayısı zorunludur",
-		"GuestCount_min":       "Kişi sayısı en az 1 olmalıdır",
-	}
-	if err := validateInvitationParticipantRequest(c, &req, errorMessages, ""); err != nil {
+	if err := validateInvitationParticipantRequest(c, &req, invitationParticipantErrorMessages, ""); err != nil {
 		return err
 	}
 	c.Locals("invitationParticipantRequest", req)
 	return nil
 }
+
+// ValidateInvitationParticipant, HTTP isteği dışında oluşturulmuş bir katılımcı kaydını
+// (ör. içe aktarılan bir tablo satırı) formdaki kurallarla doğrular ve ilk hatanın mesajını döner.
+func ValidateInvitationParticipant(req InvitationParticipantRequest) error {
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			fieldErr := validationErrors[0]
+			if msg, ok := invitationParticipantErrorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+				return errors.New(msg)
+			}
+		}
+		return errors.New("Geçersiz katılımcı bilgileri")
+	}
+	return nil
+}
+
+func ValidateInvitationParticipantImportRequest(c *fiber.Ctx) error {
+	var req InvitationParticipantImportRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"FileName_required":         "İçe aktarılacak dosya bulunamadı, lütfen tekrar yükleyin",
+			"PhoneNumberColumn_nefield": "Ad Soyad ve Telefon için farklı sütunlar seçilmelidir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz sütun eşleştirmesi")
+		}
+		return err
+	}
+
+	c.Locals("invitationParticipantImportRequest", req)
+	return nil
+}
@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationQuestionRequest struct {
+	Label      string `form:"label" validate:"required,min=2,max=255"`
+	Type       string `form:"type" validate:"required,oneof=text choice boolean number"`
+	Options    string `form:"options" validate:"required_if=Type choice"`
+	IsRequired bool   `form:"is_required"`
+}
+
+func ValidateInvitationQuestionRequest(c *fiber.Ctx) error {
+	var req InvitationQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Label_required":      "Soru metni zorunludur",
+			"Label_min":           "Soru metni en az 2 karakter olmalıdır",
+			"Label_max":           "Soru metni en fazla 255 karakter olabilir",
+			"Type_required":       "Soru tipi zorunludur",
+			"Type_oneof":          "Geçersiz soru tipi",
+			"Options_required_if": "Seçmeli sorular için seçenekleri her satıra bir tane olacak şekilde girin",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz soru bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("invitationQuestionRequest", req)
+	return nil
+}
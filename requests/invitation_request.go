@@ -1,72 +1,154 @@
 package requests
 
 import (
+	"davet.link/pkg/detailfields"
 	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/invitationtheme"
+	"davet.link/pkg/reminderoffset"
 	"fmt"
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"time"
 )
 
 type InvitationRequest struct {
-	Image       string    `form:"image"`
-	CategoryID  uint      `form:"category_id" validate:"required,gt=0"`
-	Title       string    `form:"title"`
-	Type        string    `form:"type"`
-	Template    string    `form:"template"`
-	Date        time.Time `form:"date"`
-	Time        string	  `form:"time"`
-	Venue       string    `form:"venue"`
-	Address     string    `form:"address"`
-	Location    string    `form:"location" validate:"omitempty,url"`
-	Telephone   string    `form:"telephone"`
-	IsConfirmed bool      `form:"is_confirmed"`
-	IsParticipant bool    `form:"is_participant"`
-	Detail        InvitationDetailRequest `form:"detail" validate:"required,dive"`
+	Image      string `form:"image"`
+	CategoryID uint   `form:"category_id" validate:"required,gt=0"`
+	Title      string `form:"title"`
+	Type       string `form:"type"`
+	Template   string `form:"template" validate:"max=100"`
+	// ThemeOptions, formdaki theme_options[...] alanlarıdır; BodyParser eşlemeleri çözemediği için
+	// ParseInvitationRequest tarafından doldurulur.
+	ThemeOptions map[string]string `form:"-"`
+	// DateInput ve RSVPDeadlineInput formdaki ham değerlerdir; çözülmüş hâlleri Date ve
+	// RSVPDeadline alanlarındadır.
+	DateInput string    `form:"date"`
+	Date      time.Time `form:"-"`
+	Time      string    `form:"time"`
+	// Venue, formdaki venue_ önekli mekân alanlarıdır; ParseInvitationRequest tarafından doldurulur.
+	Venue                   VenueRequest `form:"-"`
+	Telephone               string       `form:"telephone"`
+	IsConfirmed             bool         `form:"is_confirmed"`
+	IsParticipant           bool         `form:"is_participant"`
+	IsPublic                bool         `form:"is_public"`
+	IsGuestbook             bool         `form:"is_guestbook"`
+	RSVPDeadlineInput       string       `form:"rsvp_deadline"`
+	RSVPDeadline            time.Time    `form:"-"`
+	MaxGuests               int          `form:"max_guests" validate:"min=0"`
+	MaxGuestsPerParticipant int          `form:"max_guests_per_participant" validate:"min=0"`
+	ReminderOffsets         string       `form:"reminder_offsets" validate:"max=100"`
+	// Fields, formdaki fields[...] detay alanlarıdır; kategorinin şemasına göre serviste
+	// doğrulanır.
+	Fields detailfields.Values `form:"-"`
 }
 
-type InvitationDetailRequest struct {
-	Title              string `form:"title"`
-	BrideName          string `form:"bride_name"`
-	BrideSurname       string `form:"bride_surname"`
-	BrideMotherName    string `form:"bride_mother_name"`
-	BrideMotherSurname string `form:"bride_mother_surname"`
-	BrideFatherName    string `form:"bride_father_name"`
-	BrideFatherSurname string `form:"bride_father_surname"`
-	GroomName          string `form:"groom_name"`
-	GroomSurname       string `form:"groom_surname"`
-	GroomMotherName    string `form:"groom_mother_name"`
-	GroomMotherSurname string `form:"groom_mother_surname"`
-	GroomFatherName    string `form:"groom_father_name"`
-	GroomFatherSurname string `form:"groom_father_surname"`
-	Person             string `form:"person"`
-	MotherName         string `form:"mother_name"`
-	MotherSurname      string `form:"mother_surname"`
-	FatherName         string `form:"father_name"`
-	FatherSurname      string `form:"father_surname"`
-	IsMotherLive       bool   `form:"is_mother_live"`
-	IsFatherLive       bool   `form:"is_father_live"`
-	IsBrideMotherLive  bool   `form:"is_bride_mother_live"`
-	IsBrideFatherLive  bool   `form:"is_bride_father_live"`
-	IsGroomMotherLive  bool   `form:"is_groom_mother_live"`
-	IsGroomFatherLive  bool   `form:"is_groom_father_live"`
+// ParseInvitationRequest, davetiye formunu doğrulama yapmadan çözer; kaydedilmeyen canlı
+// önizleme isteklerinde de kullanılır.
+func ParseInvitationRequest(c *fiber.Ctx) (InvitationRequest, error) {
+	var req InvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return req, err
+	}
+	req.ThemeOptions = map[string]string{}
+	for _, theme := range invitationtheme.All() {
+		for _, option := range theme.Options {
+			if value := c.FormValue("theme_options[" + option.Key + "]"); value != "" {
+				req.ThemeOptions[option.Key] = value
+			}
+		}
+	}
+	// Önizlemede çözülemeyen tarihler boş bırakılır; kayıtta ValidateInvitationRequest reddeder.
+	req.Date, _ = parseFormTime(req.DateInput)
+	req.RSVPDeadline, _ = parseFormTime(req.RSVPDeadlineInput)
+	values := formValues(c)
+	req.Fields = detailfields.FromForm(values)
+	req.Venue = parseVenueRequest(values)
+	return req, nil
 }
 
-func ValidateInvitationRequest(c *fiber.Ctx) error {
-	var req InvitationRequest
+// formValues, çok parçalı ya da URL kodlu form gövdesindeki tüm alanları döner.
+func formValues(c *fiber.Ctx) map[string]string {
+	if form, err := c.MultipartForm(); err == nil {
+		return firstFormValues(form.Value)
+	}
+	values := map[string]string{}
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		values[string(key)] = string(value)
+	})
+	return values
+}
 
-	if err := c.BodyParser(&req); err != nil {
+func ValidateInvitationRequest(c *fiber.Ctx) error {
+	req, err := ParseInvitationRequest(c)
+	if err != nil {
 		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı.")
 		return fmt.Errorf("body parser error: %w", err)
 	}
 
-	validate := validator.New()
+	validate := newValidator()
 	if err := validate.Struct(req); err != nil {
 		c.Locals("invitationRequest", req)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen formdaki tüm zorunlu alanları doğru bir şekilde doldurun.")
+		if msg, ok := venueValidationMessage(err); ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen formdaki tüm zorunlu alanları doğru bir şekilde doldurun.")
+		}
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	if _, err := parseFormTime(req.DateInput); err != nil {
+		c.Locals("invitationRequest", req)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye tarihi geçersiz.")
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := parseFormTime(req.RSVPDeadlineInput); err != nil {
+		c.Locals("invitationRequest", req)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Son katılım tarihi geçersiz.")
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	if _, err := reminderoffset.Parse(req.ReminderOffsets); err != nil {
+		c.Locals("invitationRequest", req)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hatırlatma zamanları geçersiz: "+err.Error())
 		return fmt.Errorf("validation error: %w", err)
 	}
 
+	if req.Template != "" {
+		theme, ok := invitationtheme.Get(req.Template)
+		if !ok {
+			c.Locals("invitationRequest", req)
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Seçilen şablon bulunamadı.")
+			return fmt.Errorf("validation error: unknown template %q", req.Template)
+		}
+		if _, err := theme.ResolveOptions(req.ThemeOptions); err != nil {
+			c.Locals("invitationRequest", req)
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şablon ayarları geçersiz: "+err.Error())
+			return fmt.Errorf("validation error: %w", err)
+		}
+	}
+
 	c.Locals("invitationRequest", req)
 	return nil
-}
\ No newline at end of file
+}
+
+// DetailFields, formda girilen detay alanlarını döner; formun yeniden gösteriminde kullanılır.
+func (r InvitationRequest) DetailFields() detailfields.Values {
+	return r.Fields
+}
+
+// RSVPDeadlineValue, formda son katılım tarihi girilmediyse nil döner.
+func (r InvitationRequest) RSVPDeadlineValue() *time.Time {
+	if r.RSVPDeadline.IsZero() {
+		return nil
+	}
+	deadline := r.RSVPDeadline
+	return &deadline
+}
+
+// ReminderOffsetsValue, formdaki hatırlatma zamanlarını saklanacak biçime ("7d,1d") çevirir.
+func (r InvitationRequest) ReminderOffsetsValue() string {
+	offsets, err := reminderoffset.Parse(r.ReminderOffsets)
+	if err != nil {
+		return ""
+	}
+	return reminderoffset.Format(offsets)
+}
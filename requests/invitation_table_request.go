@@ -0,0 +1,59 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InvitationTableRequest struct {
+	Name     string `form:"name" validate:"required,max=100"`
+	Capacity int    `form:"capacity" validate:"required,min=1,max=100"`
+}
+
+// InvitationTableAssignRequest, katılımcının yerleştirileceği masayı taşır; 0 masadan kaldırır.
+type InvitationTableAssignRequest struct {
+	TableID uint `form:"table_id"`
+}
+
+func ValidateInvitationTableRequest(c *fiber.Ctx) error {
+	var req InvitationTableRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Name_required":     "Masa adı zorunludur",
+			"Name_max":          "Masa adı en fazla 100 karakter olabilir",
+			"Capacity_required": "Masa kapasitesi zorunludur",
+			"Capacity_min":      "Masa kapasitesi en az 1 olmalıdır",
+			"Capacity_max":      "Masa kapasitesi en fazla 100 olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz masa bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("invitationTableRequest", req)
+	return nil
+}
+
+func ValidateInvitationTableAssignRequest(c *fiber.Ctx) error {
+	var req InvitationTableAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz masa seçimi")
+		return err
+	}
+	c.Locals("invitationTableAssignRequest", req)
+	return nil
+}
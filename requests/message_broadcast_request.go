@@ -0,0 +1,41 @@
+package requests
+
+import (
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MessageBroadcastRequest struct {
+	Channel string `form:"channel" validate:"required,oneof=sms whatsapp"`
+	Body    string `form:"body" validate:"required,min=2,max=1000"`
+}
+
+func ValidateMessageBroadcastRequest(c *fiber.Ctx) error {
+	var req MessageBroadcastRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Channel_required": "Gönderim kanalı zorunludur",
+			"Channel_oneof":    "Geçersiz gönderim kanalı",
+			"Body_required":    "Mesaj metni zorunludur",
+			"Body_min":         "Mesaj metni en az 2 karakter olmalıdır",
+			"Body_max":         "Mesaj metni en fazla 1000 karakter olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz duyuru bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("messageBroadcastRequest", req)
+	return nil
+}
@@ -0,0 +1,41 @@
+package requests
+
+import (
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationPreferenceRequest struct {
+	EmailEnabled bool   `form:"email_enabled"`
+	Frequency    string `form:"frequency" validate:"required,oneof=instant daily_digest"`
+	DigestHour   int    `form:"digest_hour" validate:"min=0,max=23"`
+}
+
+func ValidateNotificationPreferenceRequest(c *fiber.Ctx) error {
+	var req NotificationPreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Frequency_required": "Bildirim sıklığı zorunludur",
+			"Frequency_oneof":    "Geçersiz bildirim sıklığı",
+			"DigestHour_min":     "Özet saati 0 ile 23 arasında olmalıdır",
+			"DigestHour_max":     "Özet saati 0 ile 23 arasında olmalıdır",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz bildirim tercihleri")
+		}
+		return err
+	}
+
+	c.Locals("notificationPreferenceRequest", req)
+	return nil
+}
@@ -0,0 +1,147 @@
+package requests
+
+import (
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"davet.link/pkg/slugpolicy"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OrganizationRequest, organizasyon oluşturma/güncelleme formudur. Banka ve sosyal medya satırları
+// kart formuyla aynı alan adlarını (card_banks[i][...], card_social_media[i][...]) kullanır.
+type OrganizationRequest struct {
+	Name        string                   `form:"name" validate:"required,min=2,max=255"`
+	BrandColor  string                   `form:"brand_color" validate:"omitempty,hexcolor,len=7"`
+	WebsiteUrl  string                   `form:"website_url" validate:"omitempty,url"`
+	Banks       []CardBankRequest        `validate:"dive"`
+	SocialMedia []CardSocialMediaRequest `validate:"dive"`
+}
+
+type OrganizationMemberRequest struct {
+	Email string `form:"email" validate:"required,email"`
+	Role  string `form:"role" validate:"required,oneof=admin member"`
+}
+
+// OrganizationCardRequest, organizasyon yöneticisinin bir üye adına oluşturduğu kartın bilgileridir.
+// Banka, sosyal medya ve logo organizasyondan gelir.
+type OrganizationCardRequest struct {
+	UserID    uint   `form:"user_id" validate:"required"`
+	Name      string `form:"name" validate:"required,min=2,max=100"`
+	Slug      string `form:"slug" validate:"required,max=255"`
+	Title     string `form:"title" validate:"omitempty,max=255"`
+	Telephone string `form:"telephone" validate:"omitempty,max=20"`
+	Email     string `form:"email" validate:"omitempty,email"`
+}
+
+func ValidateOrganizationRequest(c *fiber.Ctx) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	formValues := form.Value
+
+	req := OrganizationRequest{
+		Name:       strings.TrimSpace(firstFormValue(formValues, "name")),
+		BrandColor: strings.TrimSpace(firstFormValue(formValues, "brand_color")),
+		WebsiteUrl: strings.TrimSpace(firstFormValue(formValues, "website_url")),
+	}
+	req.Banks = parseBanksFromMap(formValues)
+	req.SocialMedia = parseSocialMediaFromMap(formValues)
+
+	validate := newValidator()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Name_required":       "Organizasyon adı zorunludur",
+			"Name_min":            "Organizasyon adı en az 2 karakter olmalıdır",
+			"Name_max":            "Organizasyon adı en fazla 255 karakter olabilir",
+			"BrandColor_hexcolor": "Marka rengi #RRGGBB biçiminde olmalıdır",
+			"BrandColor_len":      "Marka rengi #RRGGBB biçiminde olmalıdır",
+			"WebsiteUrl_url":      "Geçerli bir web sitesi adresi girin",
+			"IBAN_iban":           "Geçersiz IBAN numarası",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Banka ve sosyal medya bilgilerini kontrol edin")
+		}
+		return err
+	}
+
+	c.Locals("organizationRequest", req)
+	return nil
+}
+
+func ValidateOrganizationMemberRequest(c *fiber.Ctx) error {
+	var req OrganizationMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Email_required": "E-posta adresi zorunludur",
+			"Email_email":    "Geçerli bir e-posta adresi girin",
+			"Role_required":  "Rol seçimi zorunludur",
+			"Role_oneof":     "Geçersiz rol",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz üye bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("organizationMemberRequest", req)
+	return nil
+}
+
+func ValidateOrganizationCardRequest(c *fiber.Ctx) error {
+	var req OrganizationCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Slug = slugpolicy.Normalize(req.Slug)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"UserID_required": "Kartın oluşturulacağı üyeyi seçin",
+			"Name_required":   "Ad soyad zorunludur",
+			"Name_min":        "Ad soyad en az 2 karakter olmalıdır",
+			"Name_max":        "Ad soyad en fazla 100 karakter olabilir",
+			"Slug_required":   "Kart adresi zorunludur",
+			"Slug_max":        "Kart adresi en fazla 255 karakter olabilir",
+			"Title_max":       "Unvan en fazla 255 karakter olabilir",
+			"Telephone_max":   "Telefon en fazla 20 karakter olabilir",
+			"Email_email":     "Geçerli bir e-posta adresi girin",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kart bilgileri")
+		}
+		return err
+	}
+
+	c.Locals("organizationCardRequest", req)
+	return nil
+}
+
+func firstFormValue(values map[string][]string, key string) string {
+	if v, ok := values[key]; ok && len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package requests
+
+import (
+	"errors"
+	"strings"
+
+	"davet.link/pkg/slugpolicy"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PageRequest struct {
+	Title           string `form:"title" validate:"required,min=2,max=255"`
+	Slug            string `form:"slug" validate:"required,min=2,max=100"`
+	Body            string `form:"body" validate:"required"`
+	MetaTitle       string `form:"meta_title" validate:"max=255"`
+	MetaDescription string `form:"meta_description" validate:"max=500"`
+	IsPublished     string `form:"is_published" validate:"required,oneof=true false"`
+}
+
+// ValidatePageRequest, sayfa formunu doğrular. Markdown gövdesi kaybolmasın diye yönlendirme
+// yapılmaz; istek her durumda "pageRequest" olarak saklanır ve hata mesajı döner.
+func ValidatePageRequest(c *fiber.Ctx) error {
+	var req PageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.New("Geçersiz istek formatı")
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.Slug = slugpolicy.Normalize(req.Slug)
+	if req.Slug == "" {
+		req.Slug = slugpolicy.Normalize(req.Title)
+	}
+	req.MetaTitle = strings.TrimSpace(req.MetaTitle)
+	req.MetaDescription = strings.TrimSpace(req.MetaDescription)
+	c.Locals("pageRequest", req)
+
+	errorMessages := map[string]string{
+		"Title_required":       "Sayfa başlığı zorunludur",
+		"Title_min":            "Sayfa başlığı en az 2 karakter olmalıdır",
+		"Title_max":            "Sayfa başlığı en fazla 255 karakter olabilir",
+		"Slug_required":        "Sayfa adresi zorunludur",
+		"Slug_min":             "Sayfa adresi en az 2 karakter olmalıdır",
+		"Slug_max":             "Sayfa adresi en fazla 100 karakter olabilir",
+		"Body_required":        "Sayfa içeriği zorunludur",
+		"MetaTitle_max":        "SEO başlığı en fazla 255 karakter olabilir",
+		"MetaDescription_max":  "SEO açıklaması en fazla 500 karakter olabilir",
+		"IsPublished_required": "Yayın durumu seçilmelidir",
+		"IsPublished_oneof":    "Yayın durumu seçilmelidir",
+	}
+	if err := validator.New().Struct(req); err != nil {
+		err := err.(validator.ValidationErrors)[0]
+		if msg, ok := errorMessages[err.Field()+"_"+err.Tag()]; ok {
+			return errors.New(msg)
+		}
+		return errors.New("Geçersiz sayfa bilgileri")
+	}
+	return nil
+}
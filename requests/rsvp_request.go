@@ -0,0 +1,106 @@
+package requests
+
+import (
+	"strconv"
+	"strings"
+
+	"davet.link/pkg/flashmessages"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// rsvpAnswerFieldPrefix, özel soru yanıtlarının form alan adı önekidir (ör. answer_12).
+	rsvpAnswerFieldPrefix = "answer_"
+	// rsvpEventFieldPrefix, program bölümü katılım kutularının form alan adı önekidir (ör. event_3).
+	rsvpEventFieldPrefix = "event_"
+)
+
+// RSVPRequest, davetiye sayfasındaki katılım formudur. Katılmayacağını bildiren
+// misafirden kişi sayısı beklenmez.
+type RSVPRequest struct {
+	Title       string `form:"title" validate:"required,min=2,max=255"`
+	PhoneNumber string `form:"phone_number" validate:"required,min=10"`
+	GuestCount  int    `form:"guest_count" validate:"min=0"`
+	Status      string `form:"status" validate:"required,oneof=attending not_attending maybe"`
+	Message     string `form:"message" validate:"max=1000"`
+
+	// Answers, soru ID'sine göre ham yanıtlardır; form alanlarından ParseRSVPAnswers ile doldurulur.
+	Answers map[uint]string `form:"-"`
+	// Events, işaretlenen program bölümleridir; form alanlarından ParseRSVPEvents ile doldurulur.
+	Events map[uint]bool `form:"-"`
+}
+
+func ValidateRSVPRequest(c *fiber.Ctx) error {
+	var req RSVPRequest
+	if err := c.BodyParser(&req); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		return err
+	}
+	req.Message = strings.TrimSpace(req.Message)
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		fieldErr := err.(validator.ValidationErrors)[0]
+		errorMessages := map[string]string{
+			"Title_required":       "Ad Soyad zorunludur",
+			"Title_min":            "Ad Soyad en az 2 karakter olmalıdır",
+			"Title_max":            "Ad Soyad en fazla 255 karakter olabilir",
+			"PhoneNumber_required": "Telefon numarası zorunludur",
+			"PhoneNumber_min":      "Telefon numarası en az 10 karakter olmalıdır",
+			"GuestCount_min":       "Kişi sayısı negatif olamaz",
+			"Status_required":      "Lütfen katılım durumunuzu seçin",
+			"Status_oneof":         "Geçersiz katılım durumu",
+			"Message_max":          "Mesajınız en fazla 1000 karakter olabilir",
+		}
+		if msg, ok := errorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]; ok {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, msg)
+		} else {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz katılım bildirimi")
+		}
+		return err
+	}
+
+	req.Answers = ParseRSVPAnswers(c)
+	req.Events = ParseRSVPEvents(c)
+	c.Locals("rsvpRequest", req)
+	return nil
+}
+
+// ParseRSVPAnswers, "answer_<soruID>" biçimindeki form alanlarını toplar.
+// Çoklu değer gönderilen alanlarda son değer kullanılır.
+func ParseRSVPAnswers(c *fiber.Ctx) map[uint]string {
+	answers := make(map[uint]string)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		name := string(key)
+		if !strings.HasPrefix(name, rsvpAnswerFieldPrefix) {
+			return
+		}
+		questionID, err := strconv.ParseUint(strings.TrimPrefix(name, rsvpAnswerFieldPrefix), 10, 64)
+		if err != nil {
+			return
+		}
+		answers[uint(questionID)] = strings.TrimSpace(string(value))
+	})
+	return answers
+}
+
+// ParseRSVPEvents, "event_<bölümID>" biçimindeki işaret kutularını toplar. İşaretlenmeyen
+// kutular formda gönderilmediği için haritada yer almaz.
+func ParseRSVPEvents(c *fiber.Ctx) map[uint]bool {
+	events := make(map[uint]bool)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		name := string(key)
+		if !strings.HasPrefix(name, rsvpEventFieldPrefix) {
+			return
+		}
+		eventID, err := strconv.ParseUint(strings.TrimPrefix(name, rsvpEventFieldPrefix), 10, 64)
+		if err != nil {
+			return
+		}
+		if attending, err := strconv.ParseBool(string(value)); err == nil && attending {
+			events[uint(eventID)] = true
+		}
+	})
+	return events
+}
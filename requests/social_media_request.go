@@ -10,6 +10,9 @@ type SocialMediaRequest struct {
 	Name     string `form:"name" validate:"required,min=2"`
 	Icon     string `form:"icon" validate:"required"`
 	IsActive string `form:"is_active" validate:"required"`
+	// BaseURL ve HandlePattern, kart sahiplerinin girdiği kullanıcı adlarını profil adresine çevirmek için kullanılır.
+	BaseURL       string `form:"base_url" validate:"omitempty,url,startswith=https://"`
+	HandlePattern string `form:"handle_pattern" validate:"omitempty,regexp"`
 }
 
 func validateSocialMediaRequest(c *fiber.Ctx, req interface{}, errorMessages map[string]string, redirectPath string) error {
@@ -18,7 +21,7 @@ func validateSocialMediaRequest(c *fiber.Ctx, req interface{}, errorMessages map
 		return c.Redirect(redirectPath, fiber.StatusSeeOther)
 	}
 
-	validate := validator.New()
+	validate := newValidator()
 	if err := validate.Struct(req); err != nil {
 		err := err.(validator.ValidationErrors)[0]
 		if msg, ok := errorMessages[err.Field()+"_"+err.Tag()]; ok {
@@ -34,10 +37,13 @@ func validateSocialMediaRequest(c *fiber.Ctx, req interface{}, errorMessages map
 func ValidateSocialMediaRequest(c *fiber.Ctx) error {
 	var req SocialMediaRequest
 	errorMessages := map[string]string{
-		"Name_required":     "Sosyal medya adı zorunludur",
-		"Name_min":          "Sosyal medya adı en az 2 karakter olmalıdır",
-		"Icon_required":     "İkon zorunludur",
-		"IsActive_required": "Durum (Aktif/Pasif) seçilmelidir",
+		"Name_required":        "Sosyal medya adı zorunludur",
+		"Name_min":             "Sosyal medya adı en az 2 karakter olmalıdır",
+		"Icon_required":        "İkon zorunludur",
+		"IsActive_required":    "Durum (Aktif/Pasif) seçilmelidir",
+		"BaseURL_url":          "Profil adresi öneki geçerli bir adres olmalıdır",
+		"BaseURL_startswith":   "Profil adresi öneki https:// ile başlamalıdır",
+		"HandlePattern_regexp": "Kullanıcı adı deseni geçerli bir düzenli ifade olmalıdır",
 	}
 	if err := validateSocialMediaRequest(c, &req, errorMessages, "/dashboard/social-media/create"); err != nil {
 		return err
@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"net/url"
+	"regexp"
+
+	"davet.link/pkg/iban"
+	"davet.link/pkg/maplink"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator, projeye özel doğrulama etiketleri kayıtlı bir validator döner.
+//
+//	iban:    ülke uzunluğu ve mod-97 kontrolünden geçen, normalize edilmiş IBAN
+//	regexp:  derlenebilen bir düzenli ifade
+//	maplink: koordinat içeren ya da http(s) ile başlayan harita bağlantısı
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	_ = validate.RegisterValidation("iban", func(fl validator.FieldLevel) bool {
+		return iban.Validate(iban.Normalize(fl.Field().String())) == nil
+	})
+	_ = validate.RegisterValidation("regexp", func(fl validator.FieldLevel) bool {
+		_, err := regexp.Compile(fl.Field().String())
+		return err == nil
+	})
+	_ = validate.RegisterValidation("maplink", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		if _, ok := maplink.Parse(value); ok {
+			return true
+		}
+		u, err := url.Parse(value)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	})
+	return validate
+}
@@ -0,0 +1,75 @@
+package requests
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// VenueRequest, davetiye, program bölümü ve kartvizit formlarındaki ortak mekân alanlarıdır
+// (venue_name, venue_address_line1, ...). İl ve ilçe adları ile koordinatlar serviste çözülür.
+type VenueRequest struct {
+	Name         string `validate:"max=255"`
+	AddressLine1 string `validate:"max=255"`
+	AddressLine2 string `validate:"max=255"`
+	ProvinceID   uint
+	DistrictID   uint
+	// MapURL, yapıştırılan harita bağlantısı ya da "enlem, boylam" metnidir.
+	MapURL string `validate:"omitempty,maplink,max=1000"`
+}
+
+// parseVenueRequest, formdaki venue_ önekli alanları okur.
+func parseVenueRequest(values map[string]string) VenueRequest {
+	return VenueRequest{
+		Name:         strings.TrimSpace(values["venue_name"]),
+		AddressLine1: strings.TrimSpace(values["venue_address_line1"]),
+		AddressLine2: strings.TrimSpace(values["venue_address_line2"]),
+		ProvinceID:   parseVenueID(values["venue_province_id"]),
+		DistrictID:   parseVenueID(values["venue_district_id"]),
+		MapURL:       strings.TrimSpace(values["venue_map_url"]),
+	}
+}
+
+// firstFormValues, çok değerli form alanlarının ilk değerlerini döner.
+func firstFormValues(values map[string][]string) map[string]string {
+	first := make(map[string]string, len(values))
+	for key, list := range values {
+		if len(list) > 0 {
+			first[key] = list[0]
+		}
+	}
+	return first
+}
+
+func parseVenueID(value string) uint {
+	id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+// venueValidationMessage, doğrulama hatası mekân alanlarına aitse gösterilecek mesajı döner.
+func venueValidationMessage(err error) (string, bool) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return "", false
+	}
+	for _, fieldErr := range validationErrors {
+		if strings.Contains(fieldErr.StructNamespace(), ".Venue.") {
+			msg, ok := venueErrorMessages[fieldErr.Field()+"_"+fieldErr.Tag()]
+			return msg, ok
+		}
+	}
+	return "", false
+}
+
+var venueErrorMessages = map[string]string{
+	"Name_max":         "Mekan adı en fazla 255 karakter olabilir",
+	"AddressLine1_max": "Adres en fazla 255 karakter olabilir",
+	"AddressLine2_max": "Adres en fazla 255 karakter olabilir",
+	"MapURL_maplink":   "Harita bağlantısı geçerli bir adres ya da \"enlem, boylam\" olmalıdır",
+	"MapURL_max":       "Harita bağlantısı en fazla 1000 karakter olabilir",
+}
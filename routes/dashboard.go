@@ -18,6 +18,7 @@ func registerDashboardRoutes(app *fiber.App) {
 
 	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
+	dashboardGroup.Get("/home/export", dashboardHomeHandler.ExportStats)
 
 	userHandler := handlers.NewDashboardUserHandler()
 	dashboardGroup.Get("/users", userHandler.ListUsers)
@@ -60,12 +61,22 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Delete("/cards/delete/:id", cardHandler.DeleteCard)
 	dashboardGroup.Get("/cards/slug-check", cardHandler.SlugCheck)
 
+	pageHandler := handlers.NewDashboardPageHandler()
+	dashboardGroup.Get("/pages", pageHandler.ListPages)
+	dashboardGroup.Get("/pages/create", pageHandler.ShowCreatePage)
+	dashboardGroup.Post("/pages/create", pageHandler.CreatePage)
+	dashboardGroup.Get("/pages/update/:id", pageHandler.ShowUpdatePage)
+	dashboardGroup.Post("/pages/update/:id", pageHandler.UpdatePage)
+	dashboardGroup.Delete("/pages/delete/:id", pageHandler.DeletePage)
+
 	invitationHandler := handlers.NewDashboardInvitationHandler()
 	dashboardGroup.Get("/invitations", invitationHandler.ListInvitations)
 	dashboardGroup.Get("/invitations/create", invitationHandler.ShowCreateInvitation)
 	dashboardGroup.Post("/invitations/create", invitationHandler.CreateInvitation)
 	dashboardGroup.Get("/invitations/update/:id", invitationHandler.ShowUpdateInvitation)
 	dashboardGroup.Post("/invitations/update/:id", invitationHandler.UpdateInvitation)
+	dashboardGroup.Post("/invitations/preview", invitationHandler.PreviewInvitation)
 	dashboardGroup.Delete("/invitations/delete/:id", invitationHandler.DeleteInvitation)
+	dashboardGroup.Post("/invitations/album-quota/:id", invitationHandler.UpdateAlbumQuota)
 	//dashboardGroup.Get("/invitations/participants/:id", invitationHandler.ListParticipants)
 }
@@ -15,6 +15,7 @@ func registerPanelRoutes(app *fiber.App) {
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Panel),
 		middlewares.VerifiedMiddleware,
+		middlewares.NotificationMiddleware(),
 	)
 
 	panelGroup.Get("/home", handlers.PanelHomeHandler)
@@ -26,6 +27,20 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup.Get("/cards/update/:id", panelCardHandler.ShowUpdateCard)
 	panelGroup.Post("/cards/update/:id", panelCardHandler.UpdateCard)
 	panelGroup.Delete("/cards/delete/:id", panelCardHandler.DeleteCard)
+	panelGroup.Get("/cards/slug-check", panelCardHandler.SlugCheck)
+
+	panelOrganizationHandler := handlers.NewPanelOrganizationHandler()
+	panelGroup.Get("/organizations", panelOrganizationHandler.ListOrganizations)
+	panelGroup.Post("/organizations", panelOrganizationHandler.CreateOrganization)
+	panelGroup.Get("/organizations/:id", panelOrganizationHandler.ShowOrganization)
+	panelGroup.Post("/organizations/:id", panelOrganizationHandler.UpdateOrganization)
+	panelGroup.Post("/organizations/:id/members", panelOrganizationHandler.AddMember)
+	panelGroup.Delete("/organizations/:id/members/:userID", panelOrganizationHandler.RemoveMember)
+	panelGroup.Post("/organizations/:id/cards", panelOrganizationHandler.CreateMemberCard)
+
+	panelAnalyticsHandler := handlers.NewPanelAnalyticsHandler()
+	panelGroup.Get("/cards/analytics/:id", panelAnalyticsHandler.ShowCardAnalytics)
+	panelGroup.Get("/invitations/analytics/:id", panelAnalyticsHandler.ShowInvitationAnalytics)
 
 	panelInvitationHandler := handlers.NewPanelInvitationHandler()
 	panelGroup.Get("/invitations", panelInvitationHandler.ListInvitations)
@@ -33,6 +48,89 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup.Post("/invitations/create", panelInvitationHandler.CreateInvitation)
 	panelGroup.Get("/invitations/update/:id", panelInvitationHandler.ShowUpdateInvitation)
 	panelGroup.Post("/invitations/update/:id", panelInvitationHandler.UpdateInvitation)
+	panelGroup.Post("/invitations/preview", panelInvitationHandler.PreviewInvitation)
 	panelGroup.Delete("/invitations/delete/:id", panelInvitationHandler.DeleteInvitation)
-	//panelGroup.Get("/invitations/participants/:id", panelInvitationHandler.ListParticipants)
+
+	panelParticipantHandler := handlers.NewPanelInvitationParticipantHandler()
+	panelGroup.Get("/invitations/participants/:id", panelParticipantHandler.ListParticipants)
+	panelGroup.Get("/invitations/participants/:id/export", panelParticipantHandler.ExportParticipants)
+	panelGroup.Get("/invitations/participants/:id/import", panelParticipantHandler.ShowImportParticipants)
+	panelGroup.Post("/invitations/participants/:id/import/preview", panelParticipantHandler.PreviewImportParticipants)
+	panelGroup.Post("/invitations/participants/:id/import", panelParticipantHandler.ImportParticipants)
+	panelGroup.Delete("/invitations/participants/:id/delete/:participantID", panelParticipantHandler.DeleteParticipant)
+
+	panelGuestHandler := handlers.NewPanelInvitationGuestHandler()
+	panelGroup.Get("/invitations/guests/:id", panelGuestHandler.ListGuests)
+	panelGroup.Post("/invitations/guests/:id", panelGuestHandler.CreateGuest)
+	panelGroup.Delete("/invitations/guests/:id/delete/:guestID", panelGuestHandler.DeleteGuest)
+
+	panelMessageHandler := handlers.NewPanelInvitationMessageHandler()
+	panelGroup.Get("/invitations/messages/:id", panelMessageHandler.ListBroadcasts)
+	panelGroup.Post("/invitations/messages/:id", panelMessageHandler.CreateBroadcast)
+	panelGroup.Get("/invitations/messages/:id/:broadcastID", panelMessageHandler.ShowBroadcast)
+
+	panelQuestionHandler := handlers.NewPanelInvitationQuestionHandler()
+	panelGroup.Get("/invitations/questions/:id", panelQuestionHandler.ListQuestions)
+	panelGroup.Post("/invitations/questions/:id", panelQuestionHandler.CreateQuestion)
+	panelGroup.Delete("/invitations/questions/:id/delete/:questionID", panelQuestionHandler.DeleteQuestion)
+
+	panelCoHostHandler := handlers.NewPanelInvitationCoHostHandler()
+	panelGroup.Get("/invitations/co-hosts/:id", panelCoHostHandler.ListCoHosts)
+	panelGroup.Post("/invitations/co-hosts/:id", panelCoHostHandler.CreateCoHost)
+	panelGroup.Post("/invitations/co-hosts/:id/role/:coHostID", panelCoHostHandler.UpdateCoHostRole)
+	panelGroup.Delete("/invitations/co-hosts/:id/delete/:coHostID", panelCoHostHandler.DeleteCoHost)
+	panelGroup.Get("/co-hosts/accept/:token", panelCoHostHandler.ShowAcceptCoHost)
+	panelGroup.Post("/co-hosts/accept/:token", panelCoHostHandler.AcceptCoHost)
+
+	panelCheckInHandler := handlers.NewPanelInvitationCheckInHandler()
+	panelGroup.Get("/invitations/check-in/:id", panelCheckInHandler.ShowCheckIn)
+	panelGroup.Post("/invitations/check-in/:id", panelCheckInHandler.CheckIn)
+	panelGroup.Get("/invitations/check-in/:id/stats", panelCheckInHandler.CheckInStats)
+	panelGroup.Post("/invitations/check-in/:id/lookup", panelCheckInHandler.LookupTicket)
+	panelGroup.Post("/invitations/tickets/:id", panelCheckInHandler.SendTickets)
+
+	panelTableHandler := handlers.NewPanelInvitationTableHandler()
+	panelGroup.Get("/invitations/tables/:id", panelTableHandler.ListTables)
+	panelGroup.Post("/invitations/tables/:id", panelTableHandler.CreateTable)
+	panelGroup.Get("/invitations/tables/:id/print", panelTableHandler.PrintTables)
+	panelGroup.Post("/invitations/tables/:id/auto-assign", panelTableHandler.AutoAssign)
+	panelGroup.Post("/invitations/tables/:id/update/:tableID", panelTableHandler.UpdateTable)
+	panelGroup.Post("/invitations/tables/:id/assign/:participantID", panelTableHandler.AssignParticipant)
+	panelGroup.Delete("/invitations/tables/:id/delete/:tableID", panelTableHandler.DeleteTable)
+
+	panelGuestbookHandler := handlers.NewPanelInvitationGuestbookHandler()
+	panelGroup.Get("/invitations/guestbook/:id", panelGuestbookHandler.ListEntries)
+	panelGroup.Get("/invitations/guestbook/:id/export", panelGuestbookHandler.ExportEntries)
+	panelGroup.Post("/invitations/guestbook/:id/status/:entryID", panelGuestbookHandler.UpdateEntryStatus)
+	panelGroup.Delete("/invitations/guestbook/:id/delete/:entryID", panelGuestbookHandler.DeleteEntry)
+
+	panelGalleryHandler := handlers.NewPanelInvitationGalleryHandler()
+	panelGroup.Get("/invitations/gallery/:id", panelGalleryHandler.ShowGallery)
+	panelGroup.Post("/invitations/gallery/:id", panelGalleryHandler.UpdateAlbum)
+	panelGroup.Post("/invitations/gallery/:id/upload", panelGalleryHandler.UploadPhotos)
+	panelGroup.Get("/invitations/gallery/:id/download", panelGalleryHandler.DownloadPhotos)
+	panelGroup.Post("/invitations/gallery/:id/status/:photoID", panelGalleryHandler.UpdatePhotoStatus)
+	panelGroup.Delete("/invitations/gallery/:id/delete/:photoID", panelGalleryHandler.DeletePhoto)
+	panelGiftHandler := handlers.NewPanelInvitationGiftHandler()
+	panelGroup.Get("/invitations/gifts/:id", panelGiftHandler.ShowGifts)
+	panelGroup.Post("/invitations/gifts/:id/banks", panelGiftHandler.AddBank)
+	panelGroup.Delete("/invitations/gifts/:id/banks/delete/:bankID", panelGiftHandler.DeleteBank)
+	panelGroup.Post("/invitations/gifts/:id/items", panelGiftHandler.AddItem)
+	panelGroup.Post("/invitations/gifts/:id/items/:itemID", panelGiftHandler.UpdateItem)
+	panelGroup.Delete("/invitations/gifts/:id/items/delete/:itemID", panelGiftHandler.DeleteItem)
+	panelGroup.Delete("/invitations/gifts/:id/reservations/delete/:reservationID", panelGiftHandler.DeleteReservation)
+
+	panelEventHandler := handlers.NewPanelInvitationEventHandler()
+	panelGroup.Get("/invitations/events/:id", panelEventHandler.ListEvents)
+	panelGroup.Post("/invitations/events/:id", panelEventHandler.CreateEvent)
+	panelGroup.Get("/invitations/events/:id/update/:eventID", panelEventHandler.ShowUpdateEvent)
+	panelGroup.Post("/invitations/events/:id/update/:eventID", panelEventHandler.UpdateEvent)
+	panelGroup.Delete("/invitations/events/:id/delete/:eventID", panelEventHandler.DeleteEvent)
+
+	panelNotificationHandler := handlers.NewPanelNotificationHandler()
+	panelGroup.Get("/notifications", panelNotificationHandler.ListNotifications)
+	panelGroup.Get("/notifications/unread-count", panelNotificationHandler.UnreadCount)
+	panelGroup.Post("/notifications/read-all", panelNotificationHandler.MarkAllRead)
+	panelGroup.Post("/notifications/read/:id", panelNotificationHandler.MarkRead)
+	panelGroup.Post("/notifications/preferences", panelNotificationHandler.UpdatePreference)
 }
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IsGalleryUploadRequest, isteğin genel gövde sınırından büyük olabilecek galeri fotoğraf
+// yüklemelerinden biri olup olmadığını döner:
+//
+//	POST /panel/invitations/gallery/:id/upload
+//	POST /g/:guestToken/gallery
+//	POST /:invitationKey/gallery
+func IsGalleryUploadRequest(c *fiber.Ctx) bool {
+	if c.Method() != fiber.MethodPost {
+		return false
+	}
+	segments := strings.Split(strings.Trim(c.Path(), "/"), "/")
+	switch len(segments) {
+	case 2:
+		return segments[1] == "gallery"
+	case 3:
+		return segments[0] == "g" && segments[2] == "gallery"
+	case 5:
+		return segments[0] == "panel" && segments[1] == "invitations" && segments[2] == "gallery" && segments[4] == "upload"
+	}
+	return false
+}
@@ -1,19 +1,66 @@
 package routes
 
 import (
+	"davet.link/configs/limiterconfig"
 	handlers "davet.link/handlers/website"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
 func registerWebsiteRoutes(app *fiber.App) {
 	websiteHandler := handlers.NewWebsiteHandler()
+	guestbookLimiterConfig := limiterconfig.GetGuestbookLimiterConfig()
+	guestbookLimiterConfig.LimitReached = websiteHandler.GuestbookLimitReached
+	guestbookLimiter := limiter.New(guestbookLimiterConfig)
+	galleryPINLimiterConfig := limiterconfig.GetGalleryPINLimiterConfig()
+	galleryPINLimiterConfig.LimitReached = websiteHandler.GalleryLimitReached
+	galleryPINLimiter := limiter.New(galleryPINLimiterConfig)
+	galleryUploadLimiterConfig := limiterconfig.GetGalleryUploadLimiterConfig()
+	galleryUploadLimiterConfig.LimitReached = websiteHandler.GalleryLimitReached
+	galleryUploadLimiter := limiter.New(galleryUploadLimiterConfig)
+	giftReservationLimiterConfig := limiterconfig.GetGiftReservationLimiterConfig()
+	giftReservationLimiterConfig.LimitReached = websiteHandler.GiftLimitReached
+	giftReservationLimiter := limiter.New(giftReservationLimiterConfig)
 	app.Get("/", websiteHandler.ShowHomePage)
-	app.Get("/kullanim-sartlari", websiteHandler.ShowTermsOfUse)
-	// Statik sayfalar için tek bir route
-	app.Get("/:staticPageName", websiteHandler.ShowStaticPage)
+	app.Get("/sitemap.xml", websiteHandler.ShowSitemap)
+	// Yönetim panelinden düzenlenen sayfalar (ör: /sayfa/kvkk)
+	app.Get("/sayfa/:pageSlug", websiteHandler.ShowPage)
+	app.Get("/kullanim-sartlari", func(c *fiber.Ctx) error {
+		return c.Redirect("/sayfa/kullanim-sartlari", fiber.StatusMovedPermanently)
+	})
+	// Tanıtım sayfaları
+	app.Get("/dijital_davetiye", websiteHandler.ShowStaticPage("website/dijital_davetiye"))
+	app.Get("/dijital_dugun_davetiyesi", websiteHandler.ShowStaticPage("website/dijital_dugun_davetiyesi"))
+	app.Get("/dijital_egitim_davetiyesi", websiteHandler.ShowStaticPage("website/dijital_egitim_davetiyesi"))
+	// Kişiye özel misafir bağlantısı (ör: /g/Xy7...)
+	app.Get("/g/:guestToken", websiteHandler.ShowGuestInvitation)
+	app.Post("/g/:guestToken/rsvp", websiteHandler.SubmitGuestRSVP)
+	app.Post("/g/:guestToken/guestbook", guestbookLimiter, websiteHandler.SubmitGuestGuestbookEntry)
+	app.Get("/g/:guestToken/gallery", websiteHandler.ShowGuestGallery)
+	app.Post("/g/:guestToken/gallery", galleryUploadLimiter, websiteHandler.UploadGuestGalleryPhotos)
+	app.Get("/g/:guestToken/gifts", websiteHandler.ShowGuestGifts)
+	app.Post("/g/:guestToken/gifts/:itemID/reserve", giftReservationLimiter, websiteHandler.ReserveGuestGift)
+	app.Post("/g/:guestToken/gifts/cancel/:reservationID", giftReservationLimiter, websiteHandler.CancelGuestGiftReservation)
+	// Katılımcının kapıda okutulan bileti (ör: /t/12-ABCD...)
+	app.Get("/t/:ticketCode", websiteHandler.ShowTicket)
+	app.Get("/t/:ticketCode/qr.png", websiteHandler.ShowTicketQR)
+	// Mekân formlarındaki il ve ilçe seçimleri; davetiye rotasından önce tanımlanmalı
+	app.Get("/api/provinces", websiteHandler.ListProvinces)
+	app.Get("/api/provinces/:provinceID/districts", websiteHandler.ListDistricts)
+	// Kartvizit rotası (ör: /@serhan); davetiye rotasından önce tanımlanmalı
+	app.Get("/@:cardSlug", websiteHandler.ShowCard)
+	app.Get("/@:cardSlug/preview.png", websiteHandler.ShowCardPreview)
 	// Davetiye rotası (ör: /123asd1)
 	app.Get("/:invitationKey", websiteHandler.ShowInvitation)
-	// Kartvizit rotası (ör: /@serhan)
-	app.Get("/@:cardSlug", websiteHandler.ShowCard)
+	app.Post("/:invitationKey/rsvp", websiteHandler.SubmitRSVP)
+	app.Post("/:invitationKey/guestbook", guestbookLimiter, websiteHandler.SubmitGuestbookEntry)
+	app.Get("/:invitationKey/gallery", websiteHandler.ShowGallery)
+	app.Post("/:invitationKey/gallery", galleryUploadLimiter, websiteHandler.UploadGalleryPhotos)
+	app.Post("/:invitationKey/gallery/pin", galleryPINLimiter, websiteHandler.VerifyGalleryPIN)
+	app.Get("/:invitationKey/gifts", websiteHandler.ShowGifts)
+	app.Post("/:invitationKey/gifts/:itemID/reserve", giftReservationLimiter, websiteHandler.ReserveGift)
+	app.Post("/:invitationKey/gifts/cancel/:reservationID", giftReservationLimiter, websiteHandler.CancelGiftReservation)
+	app.Get("/:invitationKey/calendar.ics", websiteHandler.ShowInvitationCalendar)
+	app.Get("/:invitationKey/preview.png", websiteHandler.ShowInvitationPreview)
 }
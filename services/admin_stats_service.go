@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/pkg/spreadsheet"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+// DashboardStats, yönetim paneli ana sayfasındaki seçilen tarih aralığına ait metriklerdir.
+// Zaman serileri boş günler/haftalar sıfırla doldurulmuş olarak döner.
+type DashboardStats struct {
+	From time.Time
+	To   time.Time
+
+	SignupsPerDay         []repositories.PeriodCount
+	RSVPsPerWeek          []repositories.PeriodCount
+	InvitationsByCategory []repositories.LabelCount
+	Providers             []repositories.LabelCount
+
+	TotalSignups         int64
+	TotalRSVPs           int64
+	ConfirmedInvitations int64
+	PendingInvitations   int64
+	VerifiedUsers        int64
+	UnverifiedUsers      int64
+}
+
+type IAdminStatsService interface {
+	GetDashboardStats(from, to time.Time) (*DashboardStats, error)
+	ExportDashboardStats(w io.Writer, stats *DashboardStats) error
+}
+
+type AdminStatsService struct {
+	repo repositories.IAdminStatsRepository
+}
+
+func NewAdminStatsService() IAdminStatsService {
+	return &AdminStatsService{repo: repositories.NewAdminStatsRepository()}
+}
+
+// GetDashboardStats, from ve to günleri dahil olmak üzere aralıktaki metrikleri hesaplar.
+func (s *AdminStatsService) GetDashboardStats(from, to time.Time) (*DashboardStats, error) {
+	from = startOfDay(from)
+	to = startOfDay(to)
+	end := to.AddDate(0, 0, 1)
+	stats := &DashboardStats{From: from, To: to}
+	statsErr := errors.New("istatistikler getirilirken bir hata oluştu")
+
+	signups, err := s.repo.GetSignupsPerDay(from, end)
+	if err != nil {
+		logconfig.Log.Error("Günlük kayıt sayıları alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	stats.SignupsPerDay = fillPeriods(signups, from, end, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) })
+	for _, row := range stats.SignupsPerDay {
+		stats.TotalSignups += row.Count
+	}
+
+	rsvps, err := s.repo.GetRSVPsPerWeek(from, end)
+	if err != nil {
+		logconfig.Log.Error("Haftalık katılım bildirimleri alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	stats.RSVPsPerWeek = fillPeriods(rsvps, startOfWeek(from), end, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) })
+	for _, row := range stats.RSVPsPerWeek {
+		stats.TotalRSVPs += row.Count
+	}
+
+	if stats.InvitationsByCategory, err = s.repo.GetInvitationsByCategory(from, end); err != nil {
+		logconfig.Log.Error("Kategori bazında davetiye sayıları alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	for i := range stats.InvitationsByCategory {
+		if stats.InvitationsByCategory[i].Label == "" {
+			stats.InvitationsByCategory[i].Label = "Kategorisiz"
+		}
+	}
+
+	confirmations, err := s.repo.GetInvitationConfirmationCounts(from, end)
+	if err != nil {
+		logconfig.Log.Error("Davetiye onay durumları alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	for _, row := range confirmations {
+		if row.Label == "confirmed" {
+			stats.ConfirmedInvitations = row.Count
+		} else {
+			stats.PendingInvitations += row.Count
+		}
+	}
+
+	verifications, err := s.repo.GetUserVerificationCounts(from, end)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı doğrulama durumları alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	for _, row := range verifications {
+		if row.Label == "verified" {
+			stats.VerifiedUsers = row.Count
+		} else {
+			stats.UnverifiedUsers += row.Count
+		}
+	}
+
+	if stats.Providers, err = s.repo.GetUserProviderCounts(from, end); err != nil {
+		logconfig.Log.Error("Kayıt yöntemleri alınamadı", zap.Error(err))
+		return nil, statsErr
+	}
+	for i := range stats.Providers {
+		stats.Providers[i].Label = providerLabel(stats.Providers[i].Label)
+	}
+	return stats, nil
+}
+
+// ExportDashboardStats, metrikleri "Metrik, Dönem/Etiket, Değer" sütunlarıyla CSV olarak yazar.
+func (s *AdminStatsService) ExportDashboardStats(w io.Writer, stats *DashboardStats) error {
+	rows := make([][]string, 0, len(stats.SignupsPerDay)+len(stats.RSVPsPerWeek)+16)
+	for _, row := range stats.SignupsPerDay {
+		rows = append(rows, []string{"Günlük kayıt", row.Period.Format("2006-01-02"), strconv.FormatInt(row.Count, 10)})
+	}
+	for _, row := range stats.RSVPsPerWeek {
+		rows = append(rows, []string{"Haftalık katılım bildirimi", row.Period.Format("2006-01-02"), strconv.FormatInt(row.Count, 10)})
+	}
+	for _, row := range stats.InvitationsByCategory {
+		rows = append(rows, []string{"Kategoriye göre davetiye", row.Label, strconv.FormatInt(row.Count, 10)})
+	}
+	rows = append(rows,
+		[]string{"Davetiye onay durumu", "Onaylı", strconv.FormatInt(stats.ConfirmedInvitations, 10)},
+		[]string{"Davetiye onay durumu", "Onay bekleyen", strconv.FormatInt(stats.PendingInvitations, 10)},
+		[]string{"Kullanıcı doğrulama durumu", "Doğrulanmış", strconv.FormatInt(stats.VerifiedUsers, 10)},
+		[]string{"Kullanıcı doğrulama durumu", "Doğrulanmamış", strconv.FormatInt(stats.UnverifiedUsers, 10)},
+	)
+	for _, row := range stats.Providers {
+		rows = append(rows, []string{"Kayıt yöntemi", row.Label, strconv.FormatInt(row.Count, 10)})
+	}
+	return spreadsheet.WriteCSV(w, []string{"Metrik", "Dönem/Etiket", "Değer"}, rows)
+}
+
+// fillPeriods, veritabanından gelen sayımları [from, end) aralığındaki her dönem için sıfırla
+// doldurarak döner.
+func fillPeriods(rows []repositories.PeriodCount, from, end time.Time, next func(time.Time) time.Time) []repositories.PeriodCount {
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Period.Format("2006-01-02")] += row.Count
+	}
+	result := []repositories.PeriodCount{}
+	for period := from; period.Before(end); period = next(period) {
+		result = append(result, repositories.PeriodCount{Period: period, Count: counts[period.Format("2006-01-02")]})
+	}
+	return result
+}
+
+// startOfWeek, PostgreSQL'in date_trunc('week') davranışıyla uyumlu olarak haftanın pazartesisini döner.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+func providerLabel(provider string) string {
+	switch provider {
+	case "":
+		return "E-posta ve şifre"
+	case "google":
+		return "Google"
+	default:
+		return provider
+	}
+}
+
+var _ IAdminStatsService = (*AdminStatsService)(nil)
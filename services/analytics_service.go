@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/analytics"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	analyticsBufferCapacity = 10000
+	analyticsBatchSize      = 200
+	analyticsFlushInterval  = 5 * time.Second
+	analyticsTopReferrers   = 10
+)
+
+// ViewInput, görüntülenmenin kaydedilmesi için istekten alınan ham bilgilerdir. Bu değerler
+// yalnızca özetlenmek için kullanılır, olduğu gibi saklanmaz.
+type ViewInput struct {
+	IP        string
+	UserAgent string
+	Referer   string
+	Host      string
+}
+
+// ViewStats, bir davetiye veya kartvizitin seçilen dönemdeki görüntülenme istatistikleridir.
+// UniqueVisitors günlük tekil ziyaretçilerin toplamıdır; tuz her gün değiştiği için aynı kişi
+// farklı günlerde ayrı sayılır.
+type ViewStats struct {
+	Days           int
+	Daily          []repositories.DailyViewCount
+	TotalViews     int64
+	UniqueVisitors int64
+	Referrers      []repositories.LabelCount
+	Devices        []repositories.LabelCount
+	RSVPs          int64
+	ConversionRate float64
+}
+
+type IAnalyticsService interface {
+	TrackView(subjectType models.PageViewSubject, subjectID uint, input ViewInput)
+	GetStats(subjectType models.PageViewSubject, subjectID uint, days int) (*ViewStats, error)
+	DeleteExpiredSalts(ctx context.Context, now time.Time) error
+	Run(ctx context.Context)
+	Done() <-chan struct{}
+}
+
+type AnalyticsService struct {
+	repo   repositories.IAnalyticsRepository
+	buffer *analytics.Buffer[models.PageView]
+
+	mu      sync.Mutex
+	saltDay time.Time
+	salt    string
+}
+
+var (
+	analyticsServiceOnce     sync.Once
+	analyticsServiceInstance *AnalyticsService
+)
+
+// NewAnalyticsService, uygulama genelinde tek bir örnek döner; görüntülenmeler tüm handler'lar
+// için aynı tamponda biriktirilip Run tarafından toplu olarak yazılır.
+func NewAnalyticsService() IAnalyticsService {
+	analyticsServiceOnce.Do(func() {
+		s := &AnalyticsService{repo: repositories.NewAnalyticsRepository()}
+		s.buffer = analytics.NewBuffer(analyticsBufferCapacity, analyticsBatchSize, analyticsFlushInterval, s.flush)
+		analyticsServiceInstance = s
+	})
+	return analyticsServiceInstance
+}
+
+// TrackView, görüntülenmeyi yazılmak üzere tampona ekler. Botlar sayılmaz; istek akışını
+// yavaşlatmamak için hatalar yalnızca loglanır.
+func (s *AnalyticsService) TrackView(subjectType models.PageViewSubject, subjectID uint, input ViewInput) {
+	device := analytics.DeviceClass(input.UserAgent)
+	if device == analytics.DeviceBot {
+		return
+	}
+
+	day := analyticsDay(time.Now())
+	salt, err := s.dailySalt(day)
+	if err != nil {
+		logconfig.Log.Warn("Görüntülenme kaydedilemedi, günlük tuz alınamadı", zap.Error(err))
+		return
+	}
+
+	view := models.PageView{
+		SubjectType:    subjectType,
+		SubjectID:      subjectID,
+		Day:            day,
+		VisitorHash:    analytics.VisitorHash(salt, input.IP, input.UserAgent),
+		ReferrerDomain: analytics.ReferrerDomain(input.Referer, input.Host),
+		DeviceClass:    device,
+	}
+	if !s.buffer.Add(view) {
+		logconfig.Log.Warn("Görüntülenme tamponu dolu, kayıt atlandı",
+			zap.String("subject_type", string(subjectType)),
+			zap.Uint("subject_id", subjectID),
+		)
+	}
+}
+
+// dailySalt, günün tuzunu döner. Tuz ilk kullanımda veritabanına kaydedilir; böylece birden
+// fazla uygulama örneği aynı gün için aynı tuzu kullanır.
+func (s *AnalyticsService) dailySalt(day time.Time) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.salt != "" && s.saltDay.Equal(day) {
+		return s.salt, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	salt, err := s.repo.GetOrCreateSalt(context.Background(), day, hex.EncodeToString(buf))
+	if err != nil {
+		return "", err
+	}
+	s.saltDay, s.salt = day, salt
+	return salt, nil
+}
+
+func (s *AnalyticsService) flush(views []models.PageView) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.repo.CreatePageViews(ctx, views); err != nil {
+		logconfig.Log.Error("Görüntülenmeler kaydedilemedi", zap.Int("count", len(views)), zap.Error(err))
+	}
+}
+
+// Run, ctx iptal edilene kadar tampondaki görüntülenmeleri toplu olarak yazar.
+func (s *AnalyticsService) Run(ctx context.Context) {
+	s.buffer.Run(ctx)
+}
+
+// Done, Run kapanırken kalan görüntülenmeleri yazıp çıktığında kapanır.
+func (s *AnalyticsService) Done() <-chan struct{} {
+	return s.buffer.Done()
+}
+
+// DeleteExpiredSalts, önceki günlerin tuzlarını siler; böylece geçmiş özetler tekrar hesaplanamaz.
+func (s *AnalyticsService) DeleteExpiredSalts(ctx context.Context, now time.Time) error {
+	return s.repo.DeleteSaltsBefore(ctx, analyticsDay(now))
+}
+
+func (s *AnalyticsService) GetStats(subjectType models.PageViewSubject, subjectID uint, days int) (*ViewStats, error) {
+	if days <= 0 {
+		days = 30
+	}
+	from := analyticsDay(time.Now()).AddDate(0, 0, -(days - 1))
+
+	rows, err := s.repo.GetDailyViews(subjectType, subjectID, from)
+	if err != nil {
+		logconfig.Log.Error("Günlük görüntülenmeler alınamadı", zap.Uint("subject_id", subjectID), zap.Error(err))
+		return nil, errors.New("istatistikler getirilirken bir hata oluştu")
+	}
+	referrers, err := s.repo.GetReferrerCounts(subjectType, subjectID, from, analyticsTopReferrers)
+	if err != nil {
+		logconfig.Log.Error("Yönlendiren siteler alınamadı", zap.Uint("subject_id", subjectID), zap.Error(err))
+		return nil, errors.New("istatistikler getirilirken bir hata oluştu")
+	}
+	devices, err := s.repo.GetDeviceCounts(subjectType, subjectID, from)
+	if err != nil {
+		logconfig.Log.Error("Cihaz dağılımı alınamadı", zap.Uint("subject_id", subjectID), zap.Error(err))
+		return nil, errors.New("istatistikler getirilirken bir hata oluştu")
+	}
+
+	stats := &ViewStats{Days: days, Referrers: referrers, Devices: devices}
+	byDay := make(map[string]repositories.DailyViewCount, len(rows))
+	for _, row := range rows {
+		byDay[row.Day.Format("2006-01-02")] = row
+	}
+	for i := 0; i < days; i++ {
+		day := from.AddDate(0, 0, i)
+		row, ok := byDay[day.Format("2006-01-02")]
+		if !ok {
+			row = repositories.DailyViewCount{}
+		}
+		row.Day = day
+		stats.Daily = append(stats.Daily, row)
+		stats.TotalViews += row.Views
+		stats.UniqueVisitors += row.Visitors
+	}
+
+	if subjectType == models.PageViewInvitation {
+		rsvps, err := s.repo.CountRSVPsSince(subjectID, startOfDay(time.Now()).AddDate(0, 0, -(days-1)))
+		if err != nil {
+			logconfig.Log.Error("Katılım bildirimi sayısı alınamadı", zap.Uint("invitation_id", subjectID), zap.Error(err))
+			return nil, errors.New("istatistikler getirilirken bir hata oluştu")
+		}
+		stats.RSVPs = rsvps
+		if stats.UniqueVisitors > 0 {
+			stats.ConversionRate = float64(rsvps) * 100 / float64(stats.UniqueVisitors)
+		}
+	}
+	return stats, nil
+}
+
+// analyticsDay, yerel saate göre günü veritabanındaki date sütunuyla birebir eşleşmesi için
+// UTC gece yarısı olarak döner.
+func analyticsDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+var _ IAnalyticsService = (*AnalyticsService)(nil)
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"davet.link/configs/logconfig"
 	"davet.link/models"
+	"davet.link/pkg/iban"
 	"davet.link/pkg/queryparams"
 	"davet.link/repositories"
+	"davet.link/requests"
 
 	"go.uber.org/zap"
 )
@@ -19,6 +22,7 @@ type IBankService interface {
 	UpdateBank(ctx context.Context, id uint, bankData *models.Bank, updatedBy uint) error
 	DeleteBank(ctx context.Context, id uint) error
 	GetBankCount() (int64, error)
+	ResolveIBANBanks(banks []requests.CardBankRequest) error
 }
 
 type BankService struct {
@@ -68,6 +72,7 @@ func (s *BankService) UpdateBank(ctx context.Context, id uint, bankData *models.
 	updateData := map[string]interface{}{
 		"name":      bankData.Name,
 		"is_active": bankData.IsActive,
+		"code":      bankData.Code,
 	}
 	return s.repo.UpdateBank(ctx, id, updateData, updatedBy)
 }
@@ -80,4 +85,42 @@ func (s *BankService) GetBankCount() (int64, error) {
 	return s.repo.GetBankCount()
 }
 
+// ResolveIBANBanks, banka satırlarındaki TR IBAN'larının banka kodunu kayıtlı bankalarla
+// eşleştirir: banka seçilmemişse koddan belirlenir, seçilen banka IBAN'la uyuşmuyorsa hata döner.
+func (s *BankService) ResolveIBANBanks(banks []requests.CardBankRequest) error {
+	return resolveIBANBanks(s.repo, banks)
+}
+
+func resolveIBANBanks(repo repositories.IBankRepository, banks []requests.CardBankRequest) error {
+	for i := range banks {
+		formatted := iban.Format(banks[i].IBAN)
+		code := iban.BankCodeTR(banks[i].IBAN)
+		if code == "" {
+			if banks[i].BankID == 0 {
+				return fmt.Errorf("%s için banka seçilmelidir", formatted)
+			}
+			continue
+		}
+
+		detected, err := repo.GetBankByCode(code)
+		if err != nil {
+			if !errors.Is(err, repositories.ErrNotFound) {
+				logconfig.Log.Error("Banka kodu sorgulanamadı", zap.String("code", code), zap.Error(err))
+				return errors.New("banka bilgileri kontrol edilirken bir hata oluştu")
+			}
+			if banks[i].BankID == 0 {
+				return fmt.Errorf("%s için banka belirlenemedi, lütfen bankayı seçin", formatted)
+			}
+			continue
+		}
+
+		if banks[i].BankID == 0 {
+			banks[i].BankID = detected.ID
+		} else if banks[i].BankID != detected.ID {
+			return fmt.Errorf("%s IBAN'ı %s bankasına ait, seçilen bankayla eşleşmiyor", formatted, detected.Name)
+		}
+	}
+	return nil
+}
+
 var _ IBankService = (*BankService)(nil)
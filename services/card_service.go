@@ -3,29 +3,47 @@ package services
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
+
 	"davet.link/configs/logconfig"
 	"davet.link/models"
 	"davet.link/pkg/queryparams"
+	"davet.link/pkg/slugpolicy"
 	"davet.link/repositories"
 	"go.uber.org/zap"
 )
 
+var ErrSlugTaken = errors.New("bu kullanıcı adı zaten alınmış")
+
+// maxSlugSuggestions, dolu bir kullanıcı adı için önerilecek en fazla alternatif sayısıdır.
+const maxSlugSuggestions = 3
+
 type ICardService interface {
 	GetAllCards(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetCardsByUserID(userID uint, params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	CanManageCard(card *models.Card, userID uint) bool
 	GetCardByID(id uint) (*models.Card, error)
+	GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error)
 	CreateCardWithRelations(ctx context.Context, card *models.Card) error
 	UpdateCardWithRelations(ctx context.Context, card *models.Card) error
 	DeleteCardWithRelations(ctx context.Context, id uint) error
 	GetCardCount() (int64, error)
 	IsSlugAvailable(slug string, excludeID uint) (bool, error)
+	ValidateSlug(slug string, excludeID uint) error
+	SuggestSlugs(slug string, excludeID uint) []string
 }
 
 type CardService struct {
-	repo repositories.ICardRepository
+	repo             repositories.ICardRepository
+	organizationRepo repositories.IOrganizationRepository
 }
 
 func NewCardService() ICardService {
-	return &CardService{repo: repositories.NewCardRepository()}
+	return &CardService{
+		repo:             repositories.NewCardRepository(),
+		organizationRepo: repositories.NewOrganizationRepository(),
+	}
 }
 
 func (s *CardService) GetAllCards(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
@@ -46,6 +64,36 @@ func (s *CardService) GetAllCards(params queryparams.ListParams) (*queryparams.P
 	return result, nil
 }
 
+func (s *CardService) GetCardsByUserID(userID uint, params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	cards, totalCount, err := s.repo.GetCardsByUserID(userID, params)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcının kartları alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("kartlar getirilirken bir hata oluştu")
+	}
+	return &queryparams.PaginatedResult{
+		Data: cards,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}, nil
+}
+
+// CanManageCard, kartı kullanıcının düzenleyip düzenleyemeyeceğini döner. Kartın sahibi ve kartın
+// bağlı olduğu organizasyonun yöneticileri kartı yönetebilir.
+func (s *CardService) CanManageCard(card *models.Card, userID uint) bool {
+	if card.UserID == userID {
+		return true
+	}
+	if card.OrganizationID == nil {
+		return false
+	}
+	member, err := s.organizationRepo.GetMembership(*card.OrganizationID, userID)
+	return err == nil && member.IsAdmin()
+}
+
 func (s *CardService) GetCardByID(id uint) (*models.Card, error) {
 	card, err := s.repo.GetCardByID(id)
 	if err != nil {
@@ -55,6 +103,15 @@ func (s *CardService) GetCardByID(id uint) (*models.Card, error) {
 	return card, nil
 }
 
+func (s *CardService) GetActiveCardBySlug(ctx context.Context, slug string) (*models.Card, error) {
+	card, err := s.repo.GetActiveCardBySlug(ctx, slug)
+	if err != nil {
+		logconfig.Log.Warn("Kart slug ile bulunamadı", zap.String("slug", slug), zap.Error(err))
+		return nil, errors.New("kart bulunamadı")
+	}
+	return card, nil
+}
+
 func (s *CardService) CreateCardWithRelations(ctx context.Context, card *models.Card) error {
 	return s.repo.CreateCardWithRelations(ctx, card)
 }
@@ -75,4 +132,46 @@ func (s *CardService) IsSlugAvailable(slug string, excludeID uint) (bool, error)
 	return s.repo.IsSlugAvailable(slug, excludeID)
 }
 
+// ValidateSlug, kullanıcı adını slug politikasına (karakterler, uzunluk, ayrılmış ve yasaklı
+// kelimeler) ve benzersizliğe göre doğrular. Politika hataları slugpolicy paketinin hatalarıdır.
+func (s *CardService) ValidateSlug(slug string, excludeID uint) error {
+	if err := slugpolicy.Default.Check(slug); err != nil {
+		return err
+	}
+	available, err := s.repo.IsSlugAvailable(slug, excludeID)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı adı kontrol edilemedi", zap.String("slug", slug), zap.Error(err))
+		return errors.New("kullanıcı adı kontrol edilirken bir hata oluştu")
+	}
+	if !available {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// SuggestSlugs, dolu ya da ayrılmış bir kullanıcı adı için kullanılabilir alternatifler önerir
+// (ör. "ali" -> "ali-2", "ali2026", "ali-3"). Yasaklı kelime içeren adlar için öneri yapılmaz.
+func (s *CardService) SuggestSlugs(slug string, excludeID uint) []string {
+	base := slugpolicy.Normalize(slug)
+	if base == "" || errors.Is(slugpolicy.Default.Check(base), slugpolicy.ErrBlocked) {
+		return nil
+	}
+
+	candidates := []string{base + "-2", base + strconv.Itoa(time.Now().Year())}
+	for i := 3; i <= 9; i++ {
+		candidates = append(candidates, base+"-"+strconv.Itoa(i))
+	}
+
+	var suggestions []string
+	for _, candidate := range candidates {
+		if len(suggestions) == maxSlugSuggestions {
+			break
+		}
+		if s.ValidateSlug(candidate, excludeID) == nil {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
+
 var _ ICardService = (*CardService)(nil)
\ No newline at end of file
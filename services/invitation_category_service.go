@@ -3,15 +3,20 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"davet.link/configs/logconfig"
 	"davet.link/models"
+	"davet.link/pkg/invitationtheme"
 	"davet.link/pkg/queryparams"
 	"davet.link/repositories"
 
 	"go.uber.org/zap"
 )
 
+// ErrInvitationCategoryKindInvalid, kategorinin şablon türü kayıtlı türlerden biri değilse döner.
+var ErrInvitationCategoryKindInvalid = errors.New("kategori şablon türü geçersiz")
+
 type IInvitationCategoryService interface {
 	GetAllCategories(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
 	GetCategoryByID(id uint) (*models.InvitationCategory, error)
@@ -61,6 +66,9 @@ func (s *InvitationCategoryService) GetCategoryByID(id uint) (*models.Invitation
 }
 
 func (s *InvitationCategoryService) CreateCategory(ctx context.Context, category *models.InvitationCategory) error {
+	if err := validateCategory(category); err != nil {
+		return err
+	}
 	return s.repo.CreateCategory(ctx, category)
 }
 
@@ -69,15 +77,31 @@ func (s *InvitationCategoryService) UpdateCategory(ctx context.Context, id uint,
 	if err != nil {
 		return errors.New("davet kategorisi bulunamadı")
 	}
+	if err := validateCategory(categoryData); err != nil {
+		return err
+	}
 	updateData := map[string]interface{}{
-		"name":      categoryData.Name,
-		"icon":      categoryData.Icon,
-		"template":  categoryData.Template,
-		"is_active": categoryData.IsActive,
+		"name":         categoryData.Name,
+		"icon":         categoryData.Icon,
+		"template":     categoryData.Template,
+		"is_active":    categoryData.IsActive,
+		"field_schema": categoryData.FieldSchema,
 	}
 	return s.repo.UpdateCategory(ctx, id, updateData, updatedBy)
 }
 
+// validateCategory, şablon türünün kayıtlı olduğunu ve tanımlanan alan şemasının tutarlı
+// olduğunu denetler. Boş şema geçerlidir; türün varsayılan alanları kullanılır.
+func validateCategory(category *models.InvitationCategory) error {
+	if _, ok := invitationtheme.GetKind(category.Template); !ok {
+		return ErrInvitationCategoryKindInvalid
+	}
+	if err := category.FieldSchema.Validate(); err != nil {
+		return fmt.Errorf("detay alanları geçersiz: %w", err)
+	}
+	return nil
+}
+
 func (s *InvitationCategoryService) DeleteCategory(ctx context.Context, id uint) error {
 	return s.repo.DeleteCategory(ctx, id)
 }
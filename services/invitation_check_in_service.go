@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"davet.link/configs/envconfig"
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/qrcode"
+	"davet.link/pkg/ticketcode"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ticketQRScale      = 8
+	checkInRecentLimit = 20
+)
+
+var (
+	ErrTicketsDisabled       = errors.New("bilet imzalama anahtarı ayarlanmadığı için bilet oluşturulamıyor")
+	ErrTicketInvalid         = errors.New("bilet kodu geçersiz")
+	ErrTicketWrongInvitation = errors.New("bu bilet bu davetiyeye ait değil")
+	ErrTicketNotAttending    = errors.New("bu katılımcının katılımı onaylı değil")
+	ErrTicketFullyUsed       = errors.New("bu biletle tüm girişler zaten yapıldı")
+	ErrCheckInCountExceeded  = errors.New("girilen kişi sayısı biletin kalan hakkından fazla")
+	ErrNoTicketRecipients    = errors.New("telefon numarası kayıtlı ve katılımı onaylı katılımcı yok")
+)
+
+type IInvitationCheckInService interface {
+	TicketCodes(participants []models.InvitationParticipant) map[uint]string
+	TicketURL(participant *models.InvitationParticipant) (string, error)
+	GetTicket(code string) (*models.InvitationParticipant, error)
+	WriteTicketQR(w io.Writer, participant *models.InvitationParticipant) error
+	SendTickets(ctx context.Context, userID uint, invitation *models.Invitation, channel models.MessageChannel) (int64, error)
+	LookupTicket(invitationID uint, code string) (*models.InvitationParticipant, error)
+	CheckIn(ctx context.Context, invitationID uint, code string, count int, userID uint) (*models.InvitationParticipant, error)
+	GetStats(invitationID uint) (*repositories.CheckInStats, error)
+	GetRecentCheckIns(invitationID uint) ([]models.InvitationCheckIn, error)
+}
+
+type InvitationCheckInService struct {
+	repo             repositories.IInvitationCheckInRepository
+	participantRepo  repositories.IInvitationParticipantRepository
+	messagingService IMessagingService
+	secret           []byte
+}
+
+func NewInvitationCheckInService() IInvitationCheckInService {
+	return &InvitationCheckInService{
+		repo:             repositories.NewInvitationCheckInRepository(),
+		participantRepo:  repositories.NewInvitationParticipantRepository(),
+		messagingService: NewMessagingService(),
+		secret:           ticketSecret(),
+	}
+}
+
+// ticketSecret, bilet kodlarını imzalayan anahtardır. Geliştirme ortamında anahtar ayarlanmamışsa
+// sabit bir anahtar kullanılır; canlı ortamda anahtarsız bilet üretilmez.
+func ticketSecret() []byte {
+	if key := envconfig.GetEnvWithDefault("TICKET_SIGNING_KEY", ""); key != "" {
+		return []byte(key)
+	}
+	if envconfig.IsProduction() {
+		return nil
+	}
+	return []byte("davet.link-development-ticket-key")
+}
+
+// TicketCodes, katılımı onaylı katılımcıların bilet kodlarını katılımcı ID'sine göre döner.
+func (s *InvitationCheckInService) TicketCodes(participants []models.InvitationParticipant) map[uint]string {
+	codes := make(map[uint]string)
+	if s.secret == nil {
+		return codes
+	}
+	for _, participant := range participants {
+		if participant.Status == models.RSVPAttending {
+			codes[participant.ID] = ticketcode.Generate(s.secret, participant.ID)
+		}
+	}
+	return codes
+}
+
+// TicketURL, biletin QR kodunda ve mesajlarda kullanılan mutlak bağlantısıdır.
+func (s *InvitationCheckInService) TicketURL(participant *models.InvitationParticipant) (string, error) {
+	if s.secret == nil {
+		return "", ErrTicketsDisabled
+	}
+	return SiteURL() + "/t/" + ticketcode.Generate(s.secret, participant.ID), nil
+}
+
+// GetTicket, bilet sayfası için kodun ait olduğu katılımcıyı davetiyesiyle birlikte döner.
+// Katılımı artık onaylı olmayan katılımcıların biletleri gösterilmez.
+func (s *InvitationCheckInService) GetTicket(code string) (*models.InvitationParticipant, error) {
+	participant, err := s.findParticipant(code)
+	if err != nil {
+		return nil, err
+	}
+	if participant.Status != models.RSVPAttending {
+		return nil, ErrTicketNotAttending
+	}
+	return participant, nil
+}
+
+func (s *InvitationCheckInService) WriteTicketQR(w io.Writer, participant *models.InvitationParticipant) error {
+	url, err := s.TicketURL(participant)
+	if err != nil {
+		return err
+	}
+	code, err := qrcode.Encode([]byte(url))
+	if err != nil {
+		return err
+	}
+	return code.WritePNG(w, ticketQRScale)
+}
+
+// SendTickets, katılımı onaylı ve telefon numarası kayıtlı her katılımcıya bilet bağlantısını
+// seçilen kanaldan kuyruğa alır. Aynı kişi sayısı için daha önce gönderilmiş biletler tekrar
+// gönderilmez; kişi sayısı değişen katılımcılar güncel biletlerini yeniden alır.
+func (s *InvitationCheckInService) SendTickets(ctx context.Context, userID uint, invitation *models.Invitation, channel models.MessageChannel) (int64, error) {
+	if s.secret == nil {
+		return 0, ErrTicketsDisabled
+	}
+	available := false
+	for _, candidate := range s.messagingService.AvailableChannels() {
+		available = available || candidate == channel
+	}
+	if !available {
+		return 0, ErrChannelUnavailable
+	}
+
+	participants, err := s.participantRepo.GetParticipantsByInvitationID(invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Bilet gönderimi için katılımcılar alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return 0, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+
+	invitationID := invitation.ID
+	messages := make([]models.OutboundMessage, 0, len(participants))
+	for _, participant := range participants {
+		if participant.Status != models.RSVPAttending || participant.PhoneNumber == "" {
+			continue
+		}
+		url, _ := s.TicketURL(&participant)
+		participantID := participant.ID
+		messages = append(messages, models.OutboundMessage{
+			Channel:   channel,
+			Recipient: participant.PhoneNumber,
+			Body: fmt.Sprintf("%s için giriş biletiniz (%d kişi): %s\nGirişte bu bağlantıdaki QR kodu gösteriniz.",
+				invitation.Title, participant.GuestCount, url),
+			Kind:          models.OutboundMessageKindTicket,
+			Status:        models.OutboundMessagePending,
+			DedupKey:      fmt.Sprintf("ticket:%d:%d", participant.ID, participant.GuestCount),
+			UserID:        &userID,
+			InvitationID:  &invitationID,
+			ParticipantID: &participantID,
+		})
+	}
+	if len(messages) == 0 {
+		return 0, ErrNoTicketRecipients
+	}
+
+	queued, err := s.messagingService.EnqueueMessages(ctx, userID, messages)
+	if err != nil {
+		var quotaErr *repositories.MessageQuotaError
+		if errors.As(err, &quotaErr) {
+			return 0, fmt.Errorf("%w: bugün en fazla %d mesaj daha gönderebilirsiniz, biletler %d alıcıya gidecek",
+				ErrMessageQuotaExceeded, quotaErr.Remaining, len(messages))
+		}
+		logconfig.Log.Error("Biletler kuyruğa alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return 0, errors.New("biletler gönderilirken bir hata oluştu")
+	}
+	return queued, nil
+}
+
+// LookupTicket, kapıda okutulan kodun davetiyeye ait, katılımı onaylı bir katılımcıya ait olduğunu
+// doğrular ve katılımcıyı güncel giriş sayısıyla döner.
+func (s *InvitationCheckInService) LookupTicket(invitationID uint, code string) (*models.InvitationParticipant, error) {
+	participant, err := s.findParticipant(code)
+	if err != nil {
+		return nil, err
+	}
+	if participant.InvitationID != invitationID {
+		return nil, ErrTicketWrongInvitation
+	}
+	if participant.Status != models.RSVPAttending {
+		return participant, ErrTicketNotAttending
+	}
+	return participant, nil
+}
+
+// CheckIn, biletle gelen kişileri içeri alır. count 0 ise biletin kalan hakkının tamamı
+// kullanılır; kısmi girişlerde kalan kişiler aynı biletle daha sonra girebilir. Hata durumunda
+// da katılımcı (bulunabildiyse) güncel haliyle döner ki kapıdaki görevli önceki girişleri görsün.
+func (s *InvitationCheckInService) CheckIn(ctx context.Context, invitationID uint, code string, count int, userID uint) (*models.InvitationParticipant, error) {
+	participant, err := s.LookupTicket(invitationID, code)
+	if err != nil {
+		return participant, err
+	}
+
+	remaining := participant.RemainingCheckIns()
+	if count <= 0 {
+		count = remaining
+	}
+	if remaining == 0 {
+		return participant, ErrTicketFullyUsed
+	}
+	if count > remaining {
+		return participant, fmt.Errorf("%w (kalan: %d kişi)", ErrCheckInCountExceeded, remaining)
+	}
+
+	checkIn := &models.InvitationCheckIn{
+		InvitationID:  invitationID,
+		ParticipantID: participant.ID,
+		Count:         count,
+		CheckedInByID: userID,
+	}
+	updated, err := s.repo.CheckIn(ctx, participant.ID, count, checkIn)
+	if errors.Is(err, repositories.ErrCheckInRejected) {
+		// Başka bir cihaz aynı bileti bu sırada okuttu; güncel durumla yeniden değerlendirilir.
+		if current, lookupErr := s.LookupTicket(invitationID, code); lookupErr == nil {
+			if current.RemainingCheckIns() == 0 {
+				return current, ErrTicketFullyUsed
+			}
+			return current, fmt.Errorf("%w (kalan: %d kişi)", ErrCheckInCountExceeded, current.RemainingCheckIns())
+		}
+		return participant, ErrTicketFullyUsed
+	}
+	if err != nil {
+		logconfig.Log.Error("Giriş kaydedilemedi", zap.Uint("participant_id", participant.ID), zap.Error(err))
+		return participant, errors.New("giriş kaydedilirken bir hata oluştu")
+	}
+	logconfig.Log.Info("Kapıda giriş yapıldı",
+		zap.Uint("invitation_id", invitationID),
+		zap.Uint("participant_id", participant.ID),
+		zap.Int("count", count),
+		zap.Uint("checked_in_by", userID),
+	)
+	return updated, nil
+}
+
+func (s *InvitationCheckInService) GetStats(invitationID uint) (*repositories.CheckInStats, error) {
+	stats, err := s.repo.GetStats(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Giriş sayacı alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("giriş sayacı getirilirken bir hata oluştu")
+	}
+	return stats, nil
+}
+
+func (s *InvitationCheckInService) GetRecentCheckIns(invitationID uint) ([]models.InvitationCheckIn, error) {
+	checkIns, err := s.repo.GetRecentCheckIns(invitationID, checkInRecentLimit)
+	if err != nil {
+		logconfig.Log.Error("Son girişler alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("son girişler getirilirken bir hata oluştu")
+	}
+	return checkIns, nil
+}
+
+func (s *InvitationCheckInService) findParticipant(code string) (*models.InvitationParticipant, error) {
+	if s.secret == nil {
+		return nil, ErrTicketsDisabled
+	}
+	participantID, err := ticketcode.Parse(s.secret, code)
+	if err != nil {
+		return nil, ErrTicketInvalid
+	}
+	participant, err := s.participantRepo.GetParticipantByID(participantID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Bilet katılımcısı alınamadı", zap.Uint("participant_id", participantID), zap.Error(err))
+		}
+		return nil, ErrTicketInvalid
+	}
+	return participant, nil
+}
+
+var _ IInvitationCheckInService = (*InvitationCheckInService)(nil)
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const (
+	coHostTokenLength = 32
+	coHostLogLimit    = 50
+)
+
+var (
+	ErrCoHostNotFound       = errors.New("ortak ev sahibi bulunamadı")
+	ErrCoHostInviteNotFound = errors.New("davet bağlantısı geçersiz ya da davet geri alınmış")
+	// ErrCoHostMailNotSent, davet kaydedildiği halde e-postanın gönderilemediğini bildirir;
+	// bağlantı panelden kopyalanıp iletilebilir.
+	ErrCoHostMailNotSent = errors.New("davet e-postası gönderilemedi")
+)
+
+type IInvitationCoHostService interface {
+	GetCoHosts(invitationID uint) ([]models.InvitationCoHost, error)
+	GetLogs(invitationID uint) ([]models.InvitationCoHostLog, error)
+	InviteCoHost(ctx context.Context, invitation *models.Invitation, actorID uint, req requests.InvitationCoHostRequest) (*models.InvitationCoHost, error)
+	UpdateCoHostRole(ctx context.Context, invitation *models.Invitation, actorID, coHostID uint, req requests.InvitationCoHostRoleRequest) error
+	RemoveCoHost(ctx context.Context, invitation *models.Invitation, actorID, coHostID uint) error
+	GetCoHostByToken(ctx context.Context, token string) (*models.InvitationCoHost, error)
+	AcceptCoHost(ctx context.Context, token string, userID uint, email string) (*models.InvitationCoHost, error)
+}
+
+type InvitationCoHostService struct {
+	repo        repositories.IInvitationCoHostRepository
+	userRepo    repositories.IUserRepository
+	mailService IMailService
+}
+
+func NewInvitationCoHostService() IInvitationCoHostService {
+	return &InvitationCoHostService{
+		repo:        repositories.NewInvitationCoHostRepository(),
+		userRepo:    repositories.NewUserRepository(),
+		mailService: NewMailService(),
+	}
+}
+
+func (s *InvitationCoHostService) GetCoHosts(invitationID uint) ([]models.InvitationCoHost, error) {
+	coHosts, err := s.repo.GetCoHostsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Ortak ev sahipleri alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("ortak ev sahipleri getirilirken bir hata oluştu")
+	}
+	return coHosts, nil
+}
+
+func (s *InvitationCoHostService) GetLogs(invitationID uint) ([]models.InvitationCoHostLog, error) {
+	logs, err := s.repo.GetLogsByInvitationID(invitationID, coHostLogLimit)
+	if err != nil {
+		logconfig.Log.Error("Ortak ev sahibi geçmişi alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("değişiklik geçmişi getirilirken bir hata oluştu")
+	}
+	return logs, nil
+}
+
+// InviteCoHost, e-posta adresine kabul bağlantısı içeren bir ortak ev sahipliği daveti gönderir.
+// Davet kaydedilip e-posta gönderilemezse davet ErrCoHostMailNotSent ile birlikte döner.
+func (s *InvitationCoHostService) InviteCoHost(ctx context.Context, invitation *models.Invitation, actorID uint, req requests.InvitationCoHostRequest) (*models.InvitationCoHost, error) {
+	owner, err := s.userRepo.GetUserByID(invitation.UserID)
+	if err == nil && strings.EqualFold(owner.Email, req.Email) {
+		return nil, errors.New("davetiye sahibi ortak ev sahibi olarak eklenemez")
+	}
+	if _, err := s.repo.GetCoHostByEmail(invitation.ID, req.Email); err == nil {
+		return nil, errors.New("bu e-posta adresi zaten ortak ev sahibi olarak davet edildi")
+	}
+
+	coHost := &models.InvitationCoHost{
+		InvitationID: invitation.ID,
+		Email:        req.Email,
+		Role:         models.InvitationRole(req.Role),
+		InvitedByID:  actorID,
+	}
+	for {
+		token := generateInvitationKey(coHostTokenLength)
+		exists, err := s.repo.TokenExists(ctx, token)
+		if err != nil {
+			logconfig.Log.Error("Ortak ev sahibi token kontrolü sırasında veritabanı hatası", zap.Error(err))
+			return nil, errors.New("davet bağlantısı oluşturulamadı")
+		}
+		if !exists {
+			coHost.Token = token
+			break
+		}
+	}
+
+	log := newCoHostLog(coHost, actorID, models.CoHostActionInvited)
+	if err := s.repo.CreateCoHost(ctx, coHost, log); err != nil {
+		logconfig.Log.Error("Ortak ev sahibi davet edilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("davet kaydedilirken bir hata oluştu")
+	}
+	logCoHostChange(log)
+
+	inviterName := ""
+	if owner != nil {
+		inviterName = owner.Name
+	}
+	body := buildCoHostInviteEmailBody(invitation, coHost, inviterName)
+	if err := s.mailService.SendMail(coHost.Email, "Ortak ev sahipliği daveti: "+invitation.Title, body); err != nil {
+		logconfig.Log.Warn("Ortak ev sahibi davet e-postası gönderilemedi", zap.Uint("co_host_id", coHost.ID), zap.Error(err))
+		return coHost, ErrCoHostMailNotSent
+	}
+	return coHost, nil
+}
+
+func (s *InvitationCoHostService) UpdateCoHostRole(ctx context.Context, invitation *models.Invitation, actorID, coHostID uint, req requests.InvitationCoHostRoleRequest) error {
+	coHost, err := s.getInvitationCoHost(invitation, coHostID)
+	if err != nil {
+		return err
+	}
+	role := models.InvitationRole(req.Role)
+	if coHost.Role == role {
+		return nil
+	}
+
+	log := newCoHostLog(coHost, actorID, models.CoHostActionRoleChanged)
+	log.Role = role
+	log.PreviousRole = coHost.Role
+	if err := s.repo.UpdateCoHost(ctx, coHost, map[string]interface{}{"role": role}, log); err != nil {
+		logconfig.Log.Error("Ortak ev sahibi rolü güncellenemedi", zap.Uint("co_host_id", coHost.ID), zap.Error(err))
+		return errors.New("rol güncellenirken bir hata oluştu")
+	}
+	logCoHostChange(log)
+	return nil
+}
+
+func (s *InvitationCoHostService) RemoveCoHost(ctx context.Context, invitation *models.Invitation, actorID, coHostID uint) error {
+	coHost, err := s.getInvitationCoHost(invitation, coHostID)
+	if err != nil {
+		return err
+	}
+
+	log := newCoHostLog(coHost, actorID, models.CoHostActionRemoved)
+	if err := s.repo.DeleteCoHost(ctx, coHost, log); err != nil {
+		logconfig.Log.Error("Ortak ev sahibi çıkarılamadı", zap.Uint("co_host_id", coHost.ID), zap.Error(err))
+		return errors.New("ortak ev sahibi çıkarılırken bir hata oluştu")
+	}
+	logCoHostChange(log)
+	return nil
+}
+
+func (s *InvitationCoHostService) GetCoHostByToken(ctx context.Context, token string) (*models.InvitationCoHost, error) {
+	coHost, err := s.repo.GetCoHostByToken(ctx, token)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Ortak ev sahipliği daveti alınamadı", zap.Error(err))
+		}
+		return nil, ErrCoHostInviteNotFound
+	}
+	if coHost.Invitation == nil {
+		return nil, ErrCoHostInviteNotFound
+	}
+	return coHost, nil
+}
+
+// AcceptCoHost, daveti oturumdaki kullanıcı adına kabul eder. Davet yalnızca gönderildiği
+// e-posta adresiyle kayıtlı hesap tarafından kabul edilebilir; aynı kullanıcının tekrar kabul
+// etmesi hata vermez.
+func (s *InvitationCoHostService) AcceptCoHost(ctx context.Context, token string, userID uint, email string) (*models.InvitationCoHost, error) {
+	coHost, err := s.GetCoHostByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(coHost.Email, email) {
+		return nil, errors.New("bu davet farklı bir e-posta adresine gönderildi; lütfen davetin gönderildiği hesapla giriş yapın")
+	}
+	if coHost.Invitation.UserID == userID {
+		return nil, errors.New("bu davetiyenin sahibi zaten sizsiniz")
+	}
+	if coHost.IsAccepted() {
+		if coHost.UserID != nil && *coHost.UserID == userID {
+			return coHost, nil
+		}
+		return nil, errors.New("bu davet başka bir hesap tarafından kabul edildi")
+	}
+
+	now := time.Now()
+	log := newCoHostLog(coHost, userID, models.CoHostActionAccepted)
+	if err := s.repo.UpdateCoHost(ctx, coHost, map[string]interface{}{"user_id": userID, "accepted_at": now}, log); err != nil {
+		logconfig.Log.Error("Ortak ev sahipliği daveti kabul edilemedi", zap.Uint("co_host_id", coHost.ID), zap.Error(err))
+		return nil, errors.New("davet kabul edilirken bir hata oluştu")
+	}
+	logCoHostChange(log)
+	coHost.UserID = &userID
+	coHost.AcceptedAt = &now
+	return coHost, nil
+}
+
+func (s *InvitationCoHostService) getInvitationCoHost(invitation *models.Invitation, coHostID uint) (*models.InvitationCoHost, error) {
+	coHost, err := s.repo.GetCoHostByID(coHostID)
+	if err != nil || coHost.InvitationID != invitation.ID {
+		return nil, ErrCoHostNotFound
+	}
+	return coHost, nil
+}
+
+func newCoHostLog(coHost *models.InvitationCoHost, actorID uint, action models.CoHostAction) *models.InvitationCoHostLog {
+	return &models.InvitationCoHostLog{
+		InvitationID: coHost.InvitationID,
+		ActorID:      actorID,
+		Email:        coHost.Email,
+		Action:       action,
+		Role:         coHost.Role,
+	}
+}
+
+// logCoHostChange, veritabanına yazılan değişiklik kaydını uygulama günlüğüne de yazar.
+func logCoHostChange(log *models.InvitationCoHostLog) {
+	logconfig.Log.Info("Ortak ev sahibi değişikliği",
+		zap.Uint("invitation_id", log.InvitationID),
+		zap.Uint("actor_id", log.ActorID),
+		zap.String("email", log.Email),
+		zap.String("action", string(log.Action)),
+		zap.String("role", string(log.Role)),
+		zap.String("previous_role", string(log.PreviousRole)),
+	)
+}
+
+func buildCoHostInviteEmailBody(invitation *models.Invitation, coHost *models.InvitationCoHost, inviterName string) string {
+	inviter := "Bir davet.link kullanıcısı"
+	if inviterName != "" {
+		inviter = inviterName
+	}
+	link := SiteURL() + "/panel/co-hosts/accept/" + coHost.Token
+	return fmt.Sprintf("%s, \"%s\" davetiyesi için sizi ortak ev sahibi olarak davet etti (rol: %s).\n\n"+
+		"Daveti kabul etmek için bu e-posta adresiyle kayıtlı hesabınızla giriş yapıp aşağıdaki bağlantıya tıklayın:\n%s\n\n"+
+		"Henüz hesabınız yoksa önce bu e-posta adresiyle kayıt olun.",
+		inviter, invitation.Title, coHost.Role.Label(), link)
+}
+
+var _ IInvitationCoHostService = (*InvitationCoHostService)(nil)
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+// EventSummary, katılım bildirimi açık bir program bölümüne katılacakların özetidir.
+type EventSummary struct {
+	Event        models.InvitationEvent
+	Participants int64
+	Guests       int64
+}
+
+type IInvitationEventService interface {
+	GetEventsByInvitationID(invitationID uint) ([]models.InvitationEvent, error)
+	GetEventByID(id uint) (*models.InvitationEvent, error)
+	CreateEvent(ctx context.Context, invitationID uint, req requests.InvitationEventRequest) error
+	UpdateEvent(ctx context.Context, id uint, req requests.InvitationEventRequest, updatedBy uint) error
+	DeleteEvent(ctx context.Context, id uint) error
+	GetResponseValues(participantID uint) (map[uint]bool, error)
+	GetEventSummaries(invitationID uint) ([]EventSummary, error)
+}
+
+type InvitationEventService struct {
+	repo         repositories.IInvitationEventRepository
+	venueService IVenueService
+}
+
+func NewInvitationEventService() IInvitationEventService {
+	return &InvitationEventService{
+		repo:         repositories.NewInvitationEventRepository(),
+		venueService: NewVenueService(),
+	}
+}
+
+func (s *InvitationEventService) GetEventsByInvitationID(invitationID uint) ([]models.InvitationEvent, error) {
+	events, err := s.repo.GetEventsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye programı alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("program getirilirken bir hata oluştu")
+	}
+	return events, nil
+}
+
+func (s *InvitationEventService) GetEventByID(id uint) (*models.InvitationEvent, error) {
+	event, err := s.repo.GetEventByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Program bölümü bulunamadı", zap.Uint("event_id", id), zap.Error(err))
+		return nil, errors.New("program bölümü bulunamadı")
+	}
+	return event, nil
+}
+
+func (s *InvitationEventService) CreateEvent(ctx context.Context, invitationID uint, req requests.InvitationEventRequest) error {
+	venue, err := s.venueService.ResolveVenue(ctx, req.Venue)
+	if err != nil {
+		return err
+	}
+	event := &models.InvitationEvent{
+		InvitationID: invitationID,
+		Title:        strings.TrimSpace(req.Title),
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAtValue(),
+		Venue:        venue,
+		IsRSVP:       req.IsRSVP,
+	}
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		logconfig.Log.Error("Program bölümü oluşturulamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("program bölümü kaydedilirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationEventService) UpdateEvent(ctx context.Context, id uint, req requests.InvitationEventRequest, updatedBy uint) error {
+	venue, err := s.venueService.ResolveVenue(ctx, req.Venue)
+	if err != nil {
+		return err
+	}
+	updateData := venueUpdateData(venue)
+	updateData["title"] = strings.TrimSpace(req.Title)
+	updateData["starts_at"] = req.StartsAt
+	updateData["ends_at"] = req.EndsAtValue()
+	updateData["is_rsvp"] = req.IsRSVP
+	if err := s.repo.UpdateEvent(ctx, id, updateData, updatedBy); err != nil {
+		logconfig.Log.Error("Program bölümü güncellenemedi", zap.Uint("event_id", id), zap.Error(err))
+		return errors.New("program bölümü güncellenirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationEventService) DeleteEvent(ctx context.Context, id uint) error {
+	return s.repo.DeleteEvent(ctx, id)
+}
+
+// GetResponseValues, katılımcının program bölümlerine verdiği yanıtları katılım formunu
+// doldurmak için bölüm ID'sine göre döner.
+func (s *InvitationEventService) GetResponseValues(participantID uint) (map[uint]bool, error) {
+	responses, err := s.repo.GetResponsesByParticipantID(participantID)
+	if err != nil {
+		logconfig.Log.Warn("Program yanıtları alınamadı", zap.Uint("participant_id", participantID), zap.Error(err))
+		return nil, errors.New("program yanıtları getirilemedi")
+	}
+	values := make(map[uint]bool, len(responses))
+	for _, response := range responses {
+		values[response.EventID] = response.IsAttending
+	}
+	return values, nil
+}
+
+// GetEventSummaries, katılım bildirimi açık her program bölümü için katılacakları sayar.
+func (s *InvitationEventService) GetEventSummaries(invitationID uint) ([]EventSummary, error) {
+	events, err := s.GetEventsByInvitationID(invitationID)
+	if err != nil {
+		return nil, err
+	}
+	attendance, err := s.repo.CountAttendance(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Program katılımı hesaplanamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("program özetleri hesaplanamadı")
+	}
+	byEvent := make(map[uint]repositories.EventAttendance, len(attendance))
+	for _, row := range attendance {
+		byEvent[row.EventID] = row
+	}
+
+	var summaries []EventSummary
+	for _, event := range events {
+		if !event.IsRSVP {
+			continue
+		}
+		row := byEvent[event.ID]
+		summaries = append(summaries, EventSummary{Event: event, Participants: row.Participants, Guests: row.Guests})
+	}
+	return summaries, nil
+}
+
+var _ IInvitationEventService = (*InvitationEventService)(nil)
@@ -0,0 +1,371 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"davet.link/configs/envconfig"
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// GalleryContentType, davetiye albümü fotoğraflarının yüklendiği klasördür.
+const GalleryContentType = "gallery"
+
+const (
+	galleryPublicLimit  = 200
+	galleryPreviewLimit = 6
+	megabyte            = 1024 * 1024
+)
+
+var (
+	ErrAlbumNotFound       = errors.New("galeri bulunamadı")
+	ErrPhotoNotFound       = errors.New("fotoğraf bulunamadı")
+	ErrGalleryUploadClosed = errors.New("bu galeriye misafir yüklemesi kapalı")
+	ErrGalleryPINRequired  = errors.New("PIN ile yükleme için bir PIN belirlemelisiniz")
+	ErrGalleryPINInvalid   = errors.New("PIN hatalı")
+	ErrGalleryQuota        = errors.New("albümün depolama alanı doldu")
+	ErrGalleryPhoto        = errors.New("yüklenen dosya geçerli bir fotoğraf değil")
+)
+
+// GalleryPreview, davetiye sayfasındaki galeri bölümünün verisidir. GuestUpload, misafirlerin
+// galeriye fotoğraf yükleyebildiğini bildirir; bölüm fotoğraf olmasa da yükleme çağrısı için
+// gösterilir.
+type GalleryPreview struct {
+	Photos      []models.InvitationPhoto
+	GuestUpload bool
+}
+
+// GalleryUploader, fotoğrafı yükleyen misafirdir; davetiye sahibinin yüklemelerinde nil verilir.
+type GalleryUploader struct {
+	Guest *models.InvitationGuest
+	Name  string
+}
+
+type IInvitationGalleryService interface {
+	GetAlbum(invitationID uint) (*models.InvitationAlbum, error)
+	GetOrCreateAlbum(ctx context.Context, invitation *models.Invitation) (*models.InvitationAlbum, error)
+	UpdateAlbum(ctx context.Context, album *models.InvitationAlbum, req requests.InvitationAlbumRequest) error
+	SetQuota(ctx context.Context, album *models.InvitationAlbum, quotaMB int) error
+	MaxPhotoSize() int64
+	CanGuestUpload(album *models.InvitationAlbum, guest *models.InvitationGuest, pinVerified bool) bool
+	VerifyPIN(album *models.InvitationAlbum, pin string) error
+	GetPhotos(albumID uint, status models.PhotoStatus) ([]models.InvitationPhoto, error)
+	GetPublicPhotos(album *models.InvitationAlbum) []models.InvitationPhoto
+	GetPreview(invitation *models.Invitation) *GalleryPreview
+	GetPhotoByID(id uint) (*models.InvitationPhoto, error)
+	CountByStatus(albumID uint) (map[models.PhotoStatus]int64, error)
+	AddPhoto(ctx context.Context, album *models.InvitationAlbum, fileName, originalName string, size int64, uploader *GalleryUploader) error
+	SetPhotoStatus(ctx context.Context, photo *models.InvitationPhoto, status models.PhotoStatus) error
+	DeletePhoto(ctx context.Context, photo *models.InvitationPhoto) error
+	WriteZIP(w io.Writer, album *models.InvitationAlbum) error
+}
+
+type InvitationGalleryService struct {
+	repo         repositories.IInvitationGalleryRepository
+	defaultQuota int64
+	maxPhotoSize int64
+}
+
+func NewInvitationGalleryService() IInvitationGalleryService {
+	return &InvitationGalleryService{
+		repo:         repositories.NewInvitationGalleryRepository(),
+		defaultQuota: int64(envconfig.GetEnvAsInt("GALLERY_QUOTA_MB", 1024)) * megabyte,
+		maxPhotoSize: int64(envconfig.GetEnvAsInt("GALLERY_MAX_PHOTO_MB", 15)) * megabyte,
+	}
+}
+
+func (s *InvitationGalleryService) GetAlbum(invitationID uint) (*models.InvitationAlbum, error) {
+	album, err := s.repo.GetAlbumByInvitationID(invitationID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Albüm alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		}
+		return nil, ErrAlbumNotFound
+	}
+	return album, nil
+}
+
+// GetOrCreateAlbum, davetiyenin albümünü döner; albüm yoksa varsayılan kotayla oluşturur.
+func (s *InvitationGalleryService) GetOrCreateAlbum(ctx context.Context, invitation *models.Invitation) (*models.InvitationAlbum, error) {
+	album, err := s.repo.GetAlbumByInvitationID(invitation.ID)
+	if err == nil {
+		return album, nil
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		logconfig.Log.Error("Albüm alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("galeri getirilirken bir hata oluştu")
+	}
+
+	album = &models.InvitationAlbum{
+		InvitationID:    invitation.ID,
+		GuestUpload:     models.GalleryUploadOff,
+		RequireApproval: true,
+		QuotaBytes:      s.defaultQuota,
+	}
+	if err := s.repo.CreateAlbum(ctx, album); err != nil {
+		// Aynı anda açılan iki sayfa albümü birlikte oluşturmaya çalışmış olabilir.
+		if existing, getErr := s.repo.GetAlbumByInvitationID(invitation.ID); getErr == nil {
+			return existing, nil
+		}
+		logconfig.Log.Error("Albüm oluşturulamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("galeri oluşturulurken bir hata oluştu")
+	}
+	return album, nil
+}
+
+// UpdateAlbum, galeri ayarlarını kaydeder. PIN ile yükleme seçildiğinde albümün bir PIN'i
+// olmalıdır; yeni PIN girilmezse mevcut PIN korunur.
+func (s *InvitationGalleryService) UpdateAlbum(ctx context.Context, album *models.InvitationAlbum, req requests.InvitationAlbumRequest) error {
+	mode := models.GalleryUploadMode(req.GuestUpload)
+	if mode == models.GalleryUploadPIN && req.UploadPIN == "" && !album.HasPIN() {
+		return ErrGalleryPINRequired
+	}
+
+	data := map[string]interface{}{
+		"title":            req.Title,
+		"guest_upload":     mode,
+		"require_approval": req.RequireApproval,
+	}
+	if req.UploadPIN != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.UploadPIN), bcrypt.DefaultCost)
+		if err != nil {
+			logconfig.Log.Error("Albüm PIN'i şifrelenemedi", zap.Uint("album_id", album.ID), zap.Error(err))
+			return errors.New("galeri ayarları kaydedilirken bir hata oluştu")
+		}
+		data["upload_pin_hash"] = string(hash)
+	}
+
+	userID, _ := ctx.Value("user_id").(uint)
+	if err := s.repo.UpdateAlbum(ctx, album.ID, data, userID); err != nil {
+		logconfig.Log.Error("Albüm güncellenemedi", zap.Uint("album_id", album.ID), zap.Error(err))
+		return errors.New("galeri ayarları kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+// SetQuota, albüme ayrılan depolama alanını değiştirir. Kota kullanılan alanın altına
+// indirilebilir; bu durumda mevcut fotoğraflar korunur ama yeni yükleme yapılamaz.
+func (s *InvitationGalleryService) SetQuota(ctx context.Context, album *models.InvitationAlbum, quotaMB int) error {
+	userID, _ := ctx.Value("user_id").(uint)
+	if err := s.repo.UpdateAlbum(ctx, album.ID, map[string]interface{}{"quota_bytes": int64(quotaMB) * megabyte}, userID); err != nil {
+		logconfig.Log.Error("Albüm kotası güncellenemedi", zap.Uint("album_id", album.ID), zap.Error(err))
+		return errors.New("albüm kotası güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// MaxPhotoSize, tek bir fotoğraf dosyasının bayt cinsinden üst sınırıdır.
+func (s *InvitationGalleryService) MaxPhotoSize() int64 {
+	return s.maxPhotoSize
+}
+
+// CanGuestUpload, misafirin albüme fotoğraf yükleyip yükleyemeyeceğini bildirir. Kişiye özel
+// bağlantıyla gelen misafir her iki modda da yükleyebilir; PIN modunda bağlantısı olmayanlar
+// için PIN'in doğrulanmış olması gerekir.
+func (s *InvitationGalleryService) CanGuestUpload(album *models.InvitationAlbum, guest *models.InvitationGuest, pinVerified bool) bool {
+	switch album.GuestUpload {
+	case models.GalleryUploadLink:
+		return guest != nil
+	case models.GalleryUploadPIN:
+		return guest != nil || (pinVerified && album.HasPIN())
+	default:
+		return false
+	}
+}
+
+func (s *InvitationGalleryService) VerifyPIN(album *models.InvitationAlbum, pin string) error {
+	if album.GuestUpload != models.GalleryUploadPIN || !album.HasPIN() {
+		return ErrGalleryUploadClosed
+	}
+	if bcrypt.CompareHashAndPassword([]byte(album.UploadPINHash), []byte(pin)) != nil {
+		return ErrGalleryPINInvalid
+	}
+	return nil
+}
+
+func (s *InvitationGalleryService) GetPhotos(albumID uint, status models.PhotoStatus) ([]models.InvitationPhoto, error) {
+	photos, err := s.repo.GetPhotos(albumID, status)
+	if err != nil {
+		logconfig.Log.Error("Albüm fotoğrafları alınamadı", zap.Uint("album_id", albumID), zap.Error(err))
+		return nil, errors.New("fotoğraflar getirilirken bir hata oluştu")
+	}
+	return photos, nil
+}
+
+// GetPublicPhotos, galeri sayfasında gösterilecek onaylı fotoğrafları döner.
+func (s *InvitationGalleryService) GetPublicPhotos(album *models.InvitationAlbum) []models.InvitationPhoto {
+	photos, err := s.repo.GetApprovedPhotos(album.ID, galleryPublicLimit)
+	if err != nil {
+		logconfig.Log.Warn("Onaylı albüm fotoğrafları alınamadı", zap.Uint("album_id", album.ID), zap.Error(err))
+		return nil
+	}
+	return photos
+}
+
+// GetPreview, davetiye sayfasındaki galeri bölümünde gösterilen son fotoğraflardır. Albümü
+// olmayan veya fotoğrafsız ve yüklemeye kapalı albümü olan davetiyelerde nil döner.
+func (s *InvitationGalleryService) GetPreview(invitation *models.Invitation) *GalleryPreview {
+	if invitation.ID == 0 {
+		return nil
+	}
+	album, err := s.repo.GetAlbumByInvitationID(invitation.ID)
+	if err != nil {
+		return nil
+	}
+	photos, err := s.repo.GetApprovedPhotos(album.ID, galleryPreviewLimit)
+	if err != nil {
+		logconfig.Log.Warn("Galeri önizlemesi alınamadı", zap.Uint("album_id", album.ID), zap.Error(err))
+		return nil
+	}
+	preview := &GalleryPreview{Photos: photos, GuestUpload: album.GuestUpload != models.GalleryUploadOff}
+	if len(preview.Photos) == 0 && !preview.GuestUpload {
+		return nil
+	}
+	return preview
+}
+
+func (s *InvitationGalleryService) GetPhotoByID(id uint) (*models.InvitationPhoto, error) {
+	photo, err := s.repo.GetPhotoByID(id)
+	if err != nil {
+		return nil, ErrPhotoNotFound
+	}
+	return photo, nil
+}
+
+func (s *InvitationGalleryService) CountByStatus(albumID uint) (map[models.PhotoStatus]int64, error) {
+	counts, err := s.repo.CountByStatus(albumID)
+	if err != nil {
+		logconfig.Log.Error("Albüm fotoğraf sayıları alınamadı", zap.Uint("album_id", albumID), zap.Error(err))
+		return nil, errors.New("fotoğraf sayıları getirilirken bir hata oluştu")
+	}
+	return counts, nil
+}
+
+// AddPhoto, filemanager ile yüklenmiş dosyayı albüme ekler. Davetiye sahibinin fotoğrafları
+// doğrudan yayınlanır; misafir fotoğrafları albüm onay istiyorsa onay bekler. Fotoğraf
+// eklenemezse dosyayı silmek çağıranın sorumluluğundadır.
+func (s *InvitationGalleryService) AddPhoto(ctx context.Context, album *models.InvitationAlbum, fileName, originalName string, size int64, uploader *GalleryUploader) error {
+	if !isImageFile(uploadPath(GalleryContentType, fileName)) {
+		return ErrGalleryPhoto
+	}
+
+	photo := &models.InvitationPhoto{
+		AlbumID:      album.ID,
+		InvitationID: album.InvitationID,
+		FileName:     fileName,
+		OriginalName: filepath.Base(originalName),
+		Size:         size,
+		Status:       models.PhotoApproved,
+	}
+	if uploader != nil {
+		photo.ByGuest = true
+		photo.UploaderName = uploader.Name
+		if uploader.Guest != nil {
+			photo.GuestID = &uploader.Guest.ID
+			if photo.UploaderName == "" {
+				photo.UploaderName = uploader.Guest.Title
+			}
+		}
+		if album.RequireApproval {
+			photo.Status = models.PhotoPending
+		}
+	}
+
+	if err := s.repo.AddPhoto(ctx, photo); err != nil {
+		if errors.Is(err, repositories.ErrAlbumQuotaExceeded) {
+			return ErrGalleryQuota
+		}
+		logconfig.Log.Error("Fotoğraf albüme eklenemedi", zap.Uint("album_id", album.ID), zap.Error(err))
+		return errors.New("fotoğraf kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGalleryService) SetPhotoStatus(ctx context.Context, photo *models.InvitationPhoto, status models.PhotoStatus) error {
+	userID, _ := ctx.Value("user_id").(uint)
+	if err := s.repo.UpdatePhotoStatus(ctx, photo.ID, status, userID); err != nil {
+		logconfig.Log.Error("Fotoğraf güncellenemedi", zap.Uint("photo_id", photo.ID), zap.Error(err))
+		return errors.New("fotoğraf güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// DeletePhoto, fotoğrafı ve dosyasını siler; kapladığı alan albümün kotasına geri döner.
+func (s *InvitationGalleryService) DeletePhoto(ctx context.Context, photo *models.InvitationPhoto) error {
+	if err := s.repo.DeletePhoto(ctx, photo); err != nil {
+		logconfig.Log.Error("Fotoğraf silinemedi", zap.Uint("photo_id", photo.ID), zap.Error(err))
+		return errors.New("fotoğraf silinirken bir hata oluştu")
+	}
+	filemanager.DeleteFile(GalleryContentType, photo.FileName)
+	return nil
+}
+
+// WriteZIP, albümdeki tüm fotoğrafların orijinallerini yüklenme sırasıyla ZIP arşivi olarak
+// yazar. Dosyalar tek tek okunup doğrudan w'ye aktarılır; fotoğraflar zaten sıkıştırılmış
+// olduğundan arşive sıkıştırılmadan eklenir. Diskte bulunamayan dosyalar atlanır.
+func (s *InvitationGalleryService) WriteZIP(w io.Writer, album *models.InvitationAlbum) error {
+	archive := zip.NewWriter(w)
+	sequence := 0
+	err := s.repo.EachPhoto(album.ID, func(photo models.InvitationPhoto) error {
+		file, err := os.Open(uploadPath(GalleryContentType, photo.FileName))
+		if err != nil {
+			logconfig.Log.Warn("Albüm fotoğrafı arşive eklenemedi", zap.Uint("photo_id", photo.ID), zap.Error(err))
+			return nil
+		}
+		defer file.Close()
+
+		sequence++
+		entry, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     zipEntryName(sequence, photo),
+			Method:   zip.Store,
+			Modified: photo.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(entry, file)
+		return err
+	})
+	if err != nil {
+		logconfig.Log.Error("Albüm arşivi oluşturulamadı", zap.Uint("album_id", album.ID), zap.Error(err))
+		return errors.New("albüm arşivi oluşturulurken bir hata oluştu")
+	}
+	return archive.Close()
+}
+
+// zipEntryName, arşivdeki dosya adıdır: misafir fotoğrafları yükleyenin adıyla ayrı klasörde
+// toplanır, aynı adlı dosyalar sıra numarasıyla ayrışır.
+func zipEntryName(sequence int, photo models.InvitationPhoto) string {
+	name := photo.OriginalName
+	if name == "" || name == "." {
+		name = photo.FileName
+	}
+	name = fmt.Sprintf("%04d-%s", sequence, zipSafeName(name))
+	if !photo.ByGuest {
+		return name
+	}
+	folder := zipSafeName(photo.UploaderName)
+	if folder == "" {
+		folder = "misafir"
+	}
+	return "misafirler/" + folder + "/" + name
+}
+
+func zipSafeName(name string) string {
+	return strings.TrimSpace(strings.NewReplacer("/", "-", "\\", "-", ":", "-", "..", ".").Replace(name))
+}
+
+var _ IInvitationGalleryService = (*InvitationGalleryService)(nil)
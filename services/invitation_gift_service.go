@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/iban"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+// maxInvitationBanks, bir davetiyeye eklenebilecek hediye hesabı sayısıdır.
+const maxInvitationBanks = 5
+
+var (
+	ErrInvitationBankNotFound = errors.New("banka hesabı bulunamadı")
+	ErrInvitationBankLimit    = errors.New("bir davetiyeye en fazla 5 banka hesabı eklenebilir")
+	ErrInvitationBankExists   = errors.New("bu IBAN davetiyede zaten kayıtlı")
+	ErrGiftItemNotFound       = errors.New("ürün bulunamadı")
+	ErrGiftItemReserved       = errors.New("bu ürünün tüm adetleri başka bir misafir tarafından ayrıldı")
+	ErrGiftQuantityTooLow     = errors.New("adet, ayrılmış adetten az olamaz")
+	ErrGiftReservationMissing = errors.New("ayırma bulunamadı")
+)
+
+// GiftPreview, davetiye sayfasındaki hediye bölümünün verisidir. ItemCount ve AvailableCount
+// hediye listesine bağlantı vermek içindir; ürünler ayrı sayfada listelenir.
+type GiftPreview struct {
+	Banks          []models.InvitationBank
+	ItemCount      int
+	AvailableCount int
+}
+
+type IInvitationGiftService interface {
+	GetBanks(invitationID uint) ([]models.InvitationBank, error)
+	AddBank(ctx context.Context, invitation *models.Invitation, req requests.InvitationBankRequest) error
+	DeleteBank(ctx context.Context, invitation *models.Invitation, bankID uint) error
+	GetItems(invitationID uint) ([]models.InvitationGiftItem, error)
+	GetItemsWithReservations(invitationID uint) ([]models.InvitationGiftItem, error)
+	GetItem(invitation *models.Invitation, itemID uint) (*models.InvitationGiftItem, error)
+	AddItem(ctx context.Context, invitation *models.Invitation, req requests.GiftItemRequest) error
+	UpdateItem(ctx context.Context, item *models.InvitationGiftItem, req requests.GiftItemRequest, updatedBy uint) error
+	DeleteItem(ctx context.Context, item *models.InvitationGiftItem) error
+	GetPreview(invitation *models.Invitation) *GiftPreview
+	Reserve(ctx context.Context, invitation *models.Invitation, itemID uint, guest *models.InvitationGuest, req requests.GiftReservationRequest) (*models.InvitationGiftReservation, error)
+	VisitorReservations(invitationID uint, tokens []string) map[uint]uint
+	CancelVisitorReservation(ctx context.Context, invitation *models.Invitation, reservationID uint, tokens []string) (string, error)
+	DeleteReservation(ctx context.Context, invitation *models.Invitation, reservationID uint) error
+}
+
+type InvitationGiftService struct {
+	repo     repositories.IInvitationGiftRepository
+	bankRepo repositories.IBankRepository
+}
+
+func NewInvitationGiftService() IInvitationGiftService {
+	return &InvitationGiftService{
+		repo:     repositories.NewInvitationGiftRepository(),
+		bankRepo: repositories.NewBankRepository(),
+	}
+}
+
+func (s *InvitationGiftService) GetBanks(invitationID uint) ([]models.InvitationBank, error) {
+	banks, err := s.repo.GetBanks(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye banka hesapları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("banka hesapları getirilirken bir hata oluştu")
+	}
+	return banks, nil
+}
+
+// AddBank, davetiyeye hediye hesabı ekler. Banka, kart formlarındaki gibi TR IBAN'ının banka
+// kodundan belirlenir ya da seçilen bankanın IBAN'la uyuştuğu kontrol edilir.
+func (s *InvitationGiftService) AddBank(ctx context.Context, invitation *models.Invitation, req requests.InvitationBankRequest) error {
+	banks, err := s.GetBanks(invitation.ID)
+	if err != nil {
+		return err
+	}
+	if len(banks) >= maxInvitationBanks {
+		return ErrInvitationBankLimit
+	}
+	for _, bank := range banks {
+		if iban.Normalize(bank.IBAN) == req.IBAN {
+			return ErrInvitationBankExists
+		}
+	}
+
+	rows := []requests.CardBankRequest{{BankID: req.BankID, IBAN: req.IBAN}}
+	if err := resolveIBANBanks(s.bankRepo, rows); err != nil {
+		return err
+	}
+	if _, err := s.bankRepo.GetBankByID(rows[0].BankID); err != nil {
+		return errors.New("banka bulunamadı")
+	}
+
+	bank := &models.InvitationBank{
+		InvitationID:  invitation.ID,
+		BankID:        rows[0].BankID,
+		IBAN:          req.IBAN,
+		AccountHolder: req.AccountHolder,
+	}
+	if err := s.repo.CreateBank(ctx, bank); err != nil {
+		logconfig.Log.Error("Davetiye banka hesabı eklenemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("banka hesabı eklenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) DeleteBank(ctx context.Context, invitation *models.Invitation, bankID uint) error {
+	bank, err := s.repo.GetBankByID(bankID)
+	if err != nil || bank.InvitationID != invitation.ID {
+		return ErrInvitationBankNotFound
+	}
+	if err := s.repo.DeleteBank(ctx, bank.ID); err != nil {
+		logconfig.Log.Error("Davetiye banka hesabı silinemedi", zap.Uint("bank_id", bank.ID), zap.Error(err))
+		return errors.New("banka hesabı silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) GetItems(invitationID uint) ([]models.InvitationGiftItem, error) {
+	items, err := s.repo.GetItems(invitationID, false)
+	if err != nil {
+		logconfig.Log.Error("Hediye listesi alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("hediye listesi getirilirken bir hata oluştu")
+	}
+	return items, nil
+}
+
+// GetItemsWithReservations, hediye listesini ayırmalarla birlikte döner; yalnızca davetiye
+// sahibinin ekranında kullanılır.
+func (s *InvitationGiftService) GetItemsWithReservations(invitationID uint) ([]models.InvitationGiftItem, error) {
+	items, err := s.repo.GetItems(invitationID, true)
+	if err != nil {
+		logconfig.Log.Error("Hediye listesi alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("hediye listesi getirilirken bir hata oluştu")
+	}
+	return items, nil
+}
+
+// GetItem, ürünü yalnızca verilen davetiyeye aitse döner.
+func (s *InvitationGiftService) GetItem(invitation *models.Invitation, itemID uint) (*models.InvitationGiftItem, error) {
+	item, err := s.repo.GetItemByID(itemID)
+	if err != nil || item.InvitationID != invitation.ID {
+		return nil, ErrGiftItemNotFound
+	}
+	return item, nil
+}
+
+func (s *InvitationGiftService) AddItem(ctx context.Context, invitation *models.Invitation, req requests.GiftItemRequest) error {
+	item := &models.InvitationGiftItem{
+		InvitationID: invitation.ID,
+		Name:         req.Name,
+		Description:  req.Description,
+		URL:          req.URL,
+		Quantity:     req.Quantity,
+	}
+	if err := s.repo.CreateItem(ctx, item); err != nil {
+		logconfig.Log.Error("Hediye listesine ürün eklenemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("ürün eklenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// UpdateItem, ürünü günceller. Adet, misafirlerin ayırdığı adetin altına düşürülemez.
+func (s *InvitationGiftService) UpdateItem(ctx context.Context, item *models.InvitationGiftItem, req requests.GiftItemRequest, updatedBy uint) error {
+	data := map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+		"url":         req.URL,
+	}
+	if err := s.repo.UpdateItem(ctx, item.ID, req.Quantity, data, updatedBy); err != nil {
+		if errors.Is(err, repositories.ErrGiftQuantityReserved) {
+			return ErrGiftQuantityTooLow
+		}
+		logconfig.Log.Error("Hediye listesindeki ürün güncellenemedi", zap.Uint("item_id", item.ID), zap.Error(err))
+		return errors.New("ürün güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGiftService) DeleteItem(ctx context.Context, item *models.InvitationGiftItem) error {
+	if err := s.repo.DeleteItem(ctx, item.ID); err != nil {
+		logconfig.Log.Error("Hediye listesindeki ürün silinemedi", zap.Uint("item_id", item.ID), zap.Error(err))
+		return errors.New("ürün silinirken bir hata oluştu")
+	}
+	return nil
+}
+
+// GetPreview, davetiye sayfasındaki hediye bölümünün verisidir. Banka hesabı ve hediye listesi
+// olmayan davetiyelerde nil döner.
+func (s *InvitationGiftService) GetPreview(invitation *models.Invitation) *GiftPreview {
+	if invitation.ID == 0 {
+		return nil
+	}
+	banks, err := s.repo.GetBanks(invitation.ID)
+	if err != nil {
+		logconfig.Log.Warn("Davetiye banka hesapları alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil
+	}
+	items, err := s.repo.GetItems(invitation.ID, false)
+	if err != nil {
+		logconfig.Log.Warn("Hediye listesi alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil
+	}
+	if len(banks) == 0 && len(items) == 0 {
+		return nil
+	}
+	preview := &GiftPreview{Banks: banks, ItemCount: len(items)}
+	for _, item := range items {
+		if !item.IsReserved() {
+			preview.AvailableCount++
+		}
+	}
+	return preview
+}
+
+// Reserve, misafir adına ürünün bir adedini ayırır. Dönen ayırmanın Token'ı ziyaretçinin
+// oturumunda saklanır; ayırmayı geri alabilmek için gereklidir.
+func (s *InvitationGiftService) Reserve(ctx context.Context, invitation *models.Invitation, itemID uint, guest *models.InvitationGuest, req requests.GiftReservationRequest) (*models.InvitationGiftReservation, error) {
+	item, err := s.GetItem(invitation, itemID)
+	if err != nil {
+		return nil, err
+	}
+	token := generateToken()
+	if token == "" {
+		return nil, errors.New("ürün ayrılırken bir hata oluştu")
+	}
+
+	reservation := &models.InvitationGiftReservation{
+		GiftItemID:   item.ID,
+		InvitationID: invitation.ID,
+		Token:        token,
+		Name:         req.Name,
+		Note:         req.Note,
+	}
+	if guest != nil {
+		reservation.GuestID = &guest.ID
+		if reservation.Name == "" {
+			reservation.Name = guest.Title
+		}
+	}
+	if err := s.repo.Reserve(ctx, reservation); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrGiftItemFullyReserved):
+			return nil, ErrGiftItemReserved
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, ErrGiftItemNotFound
+		}
+		logconfig.Log.Error("Hediye ayrılamadı", zap.Uint("item_id", item.ID), zap.Error(err))
+		return nil, errors.New("ürün ayrılırken bir hata oluştu")
+	}
+	return reservation, nil
+}
+
+// VisitorReservations, ziyaretçinin oturumundaki anahtarlarla yaptığı ayırmaları ürün
+// kimliğinden ayırma kimliğine eşleyerek döner.
+func (s *InvitationGiftService) VisitorReservations(invitationID uint, tokens []string) map[uint]uint {
+	reserved := map[uint]uint{}
+	reservations, err := s.repo.GetReservationsByTokens(tokens)
+	if err != nil {
+		logconfig.Log.Warn("Ziyaretçinin ayırmaları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return reserved
+	}
+	for _, reservation := range reservations {
+		if reservation.InvitationID == invitationID {
+			reserved[reservation.GiftItemID] = reservation.ID
+		}
+	}
+	return reserved
+}
+
+// CancelVisitorReservation, ziyaretçinin kendi ayırmasını geri alır ve oturumdan çıkarılması
+// gereken anahtarı döner. Ayırma ziyaretçinin anahtarlarından biriyle yapılmadıysa bulunamadı
+// hatası verilir.
+func (s *InvitationGiftService) CancelVisitorReservation(ctx context.Context, invitation *models.Invitation, reservationID uint, tokens []string) (string, error) {
+	reservation, err := s.repo.GetReservationByID(reservationID)
+	if err != nil || reservation.InvitationID != invitation.ID {
+		return "", ErrGiftReservationMissing
+	}
+	owned := false
+	for _, token := range tokens {
+		if token == reservation.Token {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		return "", ErrGiftReservationMissing
+	}
+	if err := s.cancelReservation(ctx, reservation); err != nil {
+		return "", err
+	}
+	return reservation.Token, nil
+}
+
+// DeleteReservation, davetiye sahibinin bir ayırmayı kaldırmasıdır; ürünün adedi yeniden
+// ayrılabilir olur.
+func (s *InvitationGiftService) DeleteReservation(ctx context.Context, invitation *models.Invitation, reservationID uint) error {
+	reservation, err := s.repo.GetReservationByID(reservationID)
+	if err != nil || reservation.InvitationID != invitation.ID {
+		return ErrGiftReservationMissing
+	}
+	return s.cancelReservation(ctx, reservation)
+}
+
+func (s *InvitationGiftService) cancelReservation(ctx context.Context, reservation *models.InvitationGiftReservation) error {
+	if err := s.repo.CancelReservation(ctx, reservation); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrGiftReservationMissing
+		}
+		logconfig.Log.Error("Hediye ayırması kaldırılamadı", zap.Uint("reservation_id", reservation.ID), zap.Error(err))
+		return errors.New("ayırma kaldırılırken bir hata oluştu")
+	}
+	return nil
+}
+
+var _ IInvitationGiftService = (*InvitationGiftService)(nil)
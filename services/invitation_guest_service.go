@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/phonenumber"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const guestTokenLength = 22
+
+// GuestBoard, davetiye sahibinin misafir durum panosunda gösterilen özet ve listeyi tutar.
+type GuestBoard struct {
+	Guests         []models.InvitationGuest
+	Invited        int64
+	Opened         int64
+	Accepted       int64
+	Declined       int64
+	Maybe          int64
+	TotalSeats     int
+	ConfirmedSeats int
+}
+
+type IInvitationGuestService interface {
+	GetGuestBoard(invitationID uint) (*GuestBoard, error)
+	GetGuestByID(id uint) (*models.InvitationGuest, error)
+	GetGuestByToken(ctx context.Context, token string) (*models.InvitationGuest, error)
+	CreateGuest(ctx context.Context, invitationID uint, req requests.InvitationGuestRequest) (*models.InvitationGuest, error)
+	DeleteGuest(ctx context.Context, id uint) error
+	MarkGuestOpened(ctx context.Context, guest *models.InvitationGuest) error
+}
+
+type InvitationGuestService struct {
+	repo repositories.IInvitationGuestRepository
+}
+
+func NewInvitationGuestService() IInvitationGuestService {
+	return &InvitationGuestService{repo: repositories.NewInvitationGuestRepository()}
+}
+
+func (s *InvitationGuestService) GetGuestBoard(invitationID uint) (*GuestBoard, error) {
+	guests, err := s.repo.GetGuestsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Misafirler alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("misafirler getirilirken bir hata oluştu")
+	}
+	counts, err := s.repo.CountByStatus(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Misafir durum sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("misafir durumları getirilirken bir hata oluştu")
+	}
+
+	board := &GuestBoard{
+		Guests:   guests,
+		Invited:  counts[models.GuestStatusInvited],
+		Opened:   counts[models.GuestStatusOpened],
+		Accepted: counts[models.GuestStatusAccepted],
+		Declined: counts[models.GuestStatusDeclined],
+		Maybe:    counts[models.GuestStatusMaybe],
+	}
+	for _, guest := range guests {
+		board.TotalSeats += guest.SeatLimit
+		if guest.Status == models.GuestStatusAccepted && guest.Participant != nil {
+			board.ConfirmedSeats += guest.Participant.GuestCount
+		}
+	}
+	return board, nil
+}
+
+func (s *InvitationGuestService) GetGuestByID(id uint) (*models.InvitationGuest, error) {
+	guest, err := s.repo.GetGuestByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Misafir bulunamadı", zap.Uint("guest_id", id), zap.Error(err))
+		return nil, errors.New("misafir bulunamadı")
+	}
+	return guest, nil
+}
+
+func (s *InvitationGuestService) GetGuestByToken(ctx context.Context, token string) (*models.InvitationGuest, error) {
+	guest, err := s.repo.GetGuestByToken(ctx, token)
+	if err != nil {
+		logconfig.Log.Warn("Misafir bağlantısı bulunamadı", zap.Error(err))
+		return nil, errors.New("davet bağlantısı geçersiz")
+	}
+	return guest, nil
+}
+
+func (s *InvitationGuestService) CreateGuest(ctx context.Context, invitationID uint, req requests.InvitationGuestRequest) (*models.InvitationGuest, error) {
+	guest := &models.InvitationGuest{
+		InvitationID: invitationID,
+		Title:        req.Title,
+		SeatLimit:    req.SeatLimit,
+		Status:       models.GuestStatusInvited,
+	}
+	if req.PhoneNumber != "" {
+		phone, err := phonenumber.NormalizeTR(req.PhoneNumber)
+		if err != nil {
+			return nil, errors.New("telefon numarası geçerli bir Türkiye numarası değil")
+		}
+		guest.PhoneNumber = phone
+	}
+
+	for {
+		token := generateInvitationKey(guestTokenLength)
+		exists, err := s.repo.TokenExists(ctx, token)
+		if err != nil {
+			logconfig.Log.Error("Misafir token kontrolü sırasında veritabanı hatası", zap.Error(err))
+			return nil, errors.New("misafir bağlantısı oluşturulamadı")
+		}
+		if !exists {
+			guest.Token = token
+			break
+		}
+	}
+
+	if err := s.repo.CreateGuest(ctx, guest); err != nil {
+		logconfig.Log.Error("Misafir oluşturulamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("misafir kaydedilirken bir veritabanı hatası oluştu")
+	}
+	return guest, nil
+}
+
+func (s *InvitationGuestService) DeleteGuest(ctx context.Context, id uint) error {
+	return s.repo.DeleteGuest(ctx, id)
+}
+
+// MarkGuestOpened, kişiye özel bağlantının ilk açılışını kaydeder; hata sayfa gösterimini engellemez.
+func (s *InvitationGuestService) MarkGuestOpened(ctx context.Context, guest *models.InvitationGuest) error {
+	if guest.OpenedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	if err := s.repo.MarkOpened(ctx, guest.ID, now); err != nil {
+		logconfig.Log.Warn("Misafir bağlantısı açılış zamanı kaydedilemedi", zap.Uint("guest_id", guest.ID), zap.Error(err))
+		return err
+	}
+	guest.OpenedAt = &now
+	if guest.Status == models.GuestStatusInvited {
+		guest.Status = models.GuestStatusOpened
+	}
+	return nil
+}
+
+var _ IInvitationGuestService = (*InvitationGuestService)(nil)
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"davet.link/configs/fileconfig"
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/pdf"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	"go.uber.org/zap"
+)
+
+// GuestbookContentType, anı defteri fotoğraflarının yüklendiği klasördür.
+const GuestbookContentType = "guestbook"
+
+const (
+	guestbookPublicLimit  = 100
+	guestbookPendingLimit = 300
+	guestbookPDFPhotoSize = 260
+)
+
+var (
+	ErrGuestbookClosed    = errors.New("bu davetiyenin anı defteri kapalı")
+	ErrGuestbookLinks     = errors.New("anı defteri mesajlarında bağlantı paylaşılamaz")
+	ErrGuestbookDuplicate = errors.New("bu mesaj anı defterine zaten gönderilmiş")
+	ErrGuestbookFull      = errors.New("anı defterinde onay bekleyen çok fazla mesaj var, lütfen daha sonra tekrar deneyin")
+	ErrGuestbookPhoto     = errors.New("yüklenen dosya geçerli bir fotoğraf değil")
+	ErrGuestbookNotFound  = errors.New("mesaj bulunamadı")
+)
+
+// guestbookLinkPattern, mesajdaki bağlantıları yakalar; iyi dilek mesajlarında bağlantıya
+// ihtiyaç olmadığından bağlantı içeren mesajlar istenmeyen ileti sayılır.
+var guestbookLinkPattern = regexp.MustCompile(`(?i)(https?://|www\.|\b[a-z0-9-]+\.(com|net|org|xyz|ru|info|top|link)\b)`)
+
+// GuestbookExport, anı defterinin JSON olarak indirilen halidir.
+type GuestbookExport struct {
+	Invitation string                 `json:"invitation"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Entries    []GuestbookExportEntry `json:"entries"`
+}
+
+type GuestbookExportEntry struct {
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"`
+	PhotoURL  string    `json:"photo_url,omitempty"`
+	Guest     string    `json:"guest,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IInvitationGuestbookService interface {
+	GetEntries(invitationID uint, status models.GuestbookEntryStatus) ([]models.InvitationGuestbookEntry, error)
+	GetPublicEntries(invitation *models.Invitation) []models.InvitationGuestbookEntry
+	GetEntryByID(id uint) (*models.InvitationGuestbookEntry, error)
+	CountByStatus(invitationID uint) (map[models.GuestbookEntryStatus]int64, error)
+	SubmitEntry(ctx context.Context, invitation *models.Invitation, guest *models.InvitationGuest, photo string, req requests.GuestbookEntryRequest) error
+	SetStatus(ctx context.Context, entry *models.InvitationGuestbookEntry, status models.GuestbookEntryStatus) error
+	DeleteEntry(ctx context.Context, entry *models.InvitationGuestbookEntry) error
+	ExportJSON(w io.Writer, invitation *models.Invitation) error
+	ExportPDF(w io.Writer, invitation *models.Invitation) error
+}
+
+type InvitationGuestbookService struct {
+	repo repositories.IInvitationGuestbookRepository
+}
+
+func NewInvitationGuestbookService() IInvitationGuestbookService {
+	return &InvitationGuestbookService{repo: repositories.NewInvitationGuestbookRepository()}
+}
+
+func (s *InvitationGuestbookService) GetEntries(invitationID uint, status models.GuestbookEntryStatus) ([]models.InvitationGuestbookEntry, error) {
+	entries, err := s.repo.GetEntriesByInvitationID(invitationID, status)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri mesajları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("mesajlar getirilirken bir hata oluştu")
+	}
+	return entries, nil
+}
+
+// GetPublicEntries, davetiye sayfasında gösterilecek onaylı mesajları döner. Anı defteri
+// kapalıysa veya mesajlar alınamazsa sayfa mesajsız gösterilir.
+func (s *InvitationGuestbookService) GetPublicEntries(invitation *models.Invitation) []models.InvitationGuestbookEntry {
+	if !invitation.IsGuestbook || invitation.ID == 0 {
+		return nil
+	}
+	entries, err := s.repo.GetApprovedEntries(invitation.ID, guestbookPublicLimit)
+	if err != nil {
+		logconfig.Log.Warn("Onaylı anı defteri mesajları alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil
+	}
+	return entries
+}
+
+func (s *InvitationGuestbookService) GetEntryByID(id uint) (*models.InvitationGuestbookEntry, error) {
+	entry, err := s.repo.GetEntryByID(id)
+	if err != nil {
+		return nil, ErrGuestbookNotFound
+	}
+	return entry, nil
+}
+
+func (s *InvitationGuestbookService) CountByStatus(invitationID uint) (map[models.GuestbookEntryStatus]int64, error) {
+	counts, err := s.repo.CountByStatus(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri sayıları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("mesaj sayıları getirilirken bir hata oluştu")
+	}
+	return counts, nil
+}
+
+// SubmitEntry, misafirin mesajını onay bekleyen olarak kaydeder. Bağlantı içeren, daha önce
+// gönderilmiş veya onay kuyruğu dolmuşken gelen mesajlar reddedilir. photo, filemanager ile
+// yüklenmiş dosyanın adıdır; mesaj kaydedilemezse dosyayı silmek çağıranın sorumluluğundadır.
+func (s *InvitationGuestbookService) SubmitEntry(ctx context.Context, invitation *models.Invitation, guest *models.InvitationGuest, photo string, req requests.GuestbookEntryRequest) error {
+	if !invitation.IsGuestbook {
+		return ErrGuestbookClosed
+	}
+	if guestbookLinkPattern.MatchString(req.Message) || guestbookLinkPattern.MatchString(req.Name) {
+		return ErrGuestbookLinks
+	}
+	if photo != "" && !isImageFile(filepath.Join(fileconfig.Config.GetPath(GuestbookContentType), photo)) {
+		return ErrGuestbookPhoto
+	}
+
+	duplicate, err := s.repo.HasMessage(invitation.ID, req.Message)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri mesajı kontrol edilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("mesajınız kaydedilirken bir hata oluştu")
+	}
+	if duplicate {
+		return ErrGuestbookDuplicate
+	}
+	counts, err := s.repo.CountByStatus(invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Anı defteri sayıları alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("mesajınız kaydedilirken bir hata oluştu")
+	}
+	if counts[models.GuestbookEntryPending] >= guestbookPendingLimit {
+		return ErrGuestbookFull
+	}
+
+	entry := &models.InvitationGuestbookEntry{
+		InvitationID: invitation.ID,
+		Name:         req.Name,
+		Message:      req.Message,
+		Status:       models.GuestbookEntryPending,
+		Photo:        photo,
+	}
+	if guest != nil {
+		entry.GuestID = &guest.ID
+	}
+	if err := s.repo.CreateEntry(ctx, entry); err != nil {
+		logconfig.Log.Error("Anı defteri mesajı kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("mesajınız kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationGuestbookService) SetStatus(ctx context.Context, entry *models.InvitationGuestbookEntry, status models.GuestbookEntryStatus) error {
+	userID, _ := ctx.Value("user_id").(uint)
+	if err := s.repo.UpdateStatus(ctx, entry.ID, status, userID); err != nil {
+		logconfig.Log.Error("Anı defteri mesajı güncellenemedi", zap.Uint("entry_id", entry.ID), zap.Error(err))
+		return errors.New("mesaj güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+// DeleteEntry, mesajı ve varsa fotoğrafını siler.
+func (s *InvitationGuestbookService) DeleteEntry(ctx context.Context, entry *models.InvitationGuestbookEntry) error {
+	if err := s.repo.DeleteEntry(ctx, entry.ID); err != nil {
+		logconfig.Log.Error("Anı defteri mesajı silinemedi", zap.Uint("entry_id", entry.ID), zap.Error(err))
+		return errors.New("mesaj silinirken bir hata oluştu")
+	}
+	filemanager.DeleteFile(GuestbookContentType, entry.Photo)
+	return nil
+}
+
+// ExportJSON, anı defterindeki tüm mesajları durumlarıyla birlikte JSON olarak yazar.
+func (s *InvitationGuestbookService) ExportJSON(w io.Writer, invitation *models.Invitation) error {
+	entries, err := s.GetEntries(invitation.ID, "")
+	if err != nil {
+		return err
+	}
+	export := GuestbookExport{
+		Invitation: invitation.Title,
+		ExportedAt: time.Now(),
+		Entries:    make([]GuestbookExportEntry, 0, len(entries)),
+	}
+	// Dışa aktarımda mesajlar yazıldıkları sırayla yer alır.
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		item := GuestbookExportEntry{
+			Name:      entry.Name,
+			Message:   entry.Message,
+			Status:    string(entry.Status),
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.Photo != "" {
+			item.PhotoURL = SiteURL() + "/uploads/" + GuestbookContentType + "/" + entry.Photo
+		}
+		if entry.Guest != nil {
+			item.Guest = entry.Guest.Title
+		}
+		export.Entries = append(export.Entries, item)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// ExportPDF, onaylı mesajları fotoğraflarıyla birlikte yazıldıkları sırayla hatıra belgesi
+// olarak yazar. Okunamayan fotoğraflar atlanır.
+func (s *InvitationGuestbookService) ExportPDF(w io.Writer, invitation *models.Invitation) error {
+	entries, err := s.GetEntries(invitation.ID, models.GuestbookEntryApproved)
+	if err != nil {
+		return err
+	}
+
+	doc := pdf.New()
+	doc.Space(120)
+	doc.Text(invitation.Title, pdf.Style{Font: pdf.Bold, Size: 26, Align: pdf.Center})
+	doc.Text("Anı Defteri", pdf.Style{Font: pdf.Italic, Size: 16, Align: pdf.Center, Gray: 0.35})
+	if !invitation.Date.IsZero() {
+		doc.Text(invitation.Date.Format("02.01.2006"), pdf.Style{Size: 12, Align: pdf.Center, Gray: 0.35})
+	}
+	if len(entries) == 0 {
+		doc.Space(40)
+		doc.Text("Henüz onaylanmış mesaj yok.", pdf.Style{Size: 12, Align: pdf.Center, Gray: 0.35})
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if i == len(entries)-1 {
+			doc.AddPage()
+		} else {
+			doc.Space(14)
+			doc.Rule()
+			doc.Space(10)
+		}
+		doc.EnsureSpace(60)
+		doc.Text(entry.Message, pdf.Style{Size: 12})
+		doc.Space(4)
+		doc.Text("— "+entry.Name+", "+entry.CreatedAt.Format("02.01.2006"), pdf.Style{Font: pdf.Italic, Size: 11, Gray: 0.35})
+		if entry.Photo == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(fileconfig.Config.GetPath(GuestbookContentType), entry.Photo))
+		if err == nil {
+			doc.Space(8)
+			err = doc.Image(data, guestbookPDFPhotoSize)
+		}
+		if err != nil {
+			logconfig.Log.Warn("Anı defteri fotoğrafı belgeye eklenemedi", zap.Uint("entry_id", entry.ID), zap.Error(err))
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		logconfig.Log.Error("Anı defteri belgesi oluşturulamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return errors.New("anı defteri belgesi oluşturulurken bir hata oluştu")
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// isImageFile, dosyanın uzantısından bağımsız olarak çözülebilen bir görsel olduğunu doğrular.
+func isImageFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	_, _, err = image.DecodeConfig(file)
+	return err == nil
+}
+
+var _ IInvitationGuestbookService = (*InvitationGuestbookService)(nil)
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/filemanager"
+	"davet.link/pkg/phonenumber"
+	"davet.link/pkg/spreadsheet"
+	"davet.link/pkg/turkishsearch"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ParticipantImportContentType = "imports"
+	participantImportSampleSize  = 5
+	participantExportSheetName   = "Katılımcılar"
+)
+
+var (
+	ErrParticipantImportEmpty   = errors.New("dosyada içe aktarılacak satır bulunamadı")
+	ErrParticipantImportColumns = errors.New("seçilen sütunlar dosyada bulunamadı")
+)
+
+// ParticipantColumnMapping, içe aktarılan dosyadaki sütunların katılımcı alanlarıyla eşleşmesini tutar.
+// Sütun indeksleri 0'dan başlar; -1 sütunun dosyada olmadığını belirtir.
+type ParticipantColumnMapping struct {
+	TitleColumn       int
+	PhoneNumberColumn int
+	GuestCountColumn  int
+	HasHeader         bool
+}
+
+type ParticipantImportPreview struct {
+	FileName    string
+	Headers     []string
+	SampleRows  [][]string
+	TotalRows   int
+	ColumnCount int
+	Mapping     ParticipantColumnMapping
+}
+
+type ParticipantImportRowError struct {
+	Row     int
+	Value   string
+	Message string
+}
+
+type ParticipantImportResult struct {
+	TotalRows  int
+	Created    int
+	Updated    int
+	Waitlisted int
+	Errors     []ParticipantImportRowError
+}
+
+// ParticipantStatusSummary, katılım durumlarına göre kayıt ve kişi sayılarını tutar.
+type ParticipantStatusSummary struct {
+	Attending        int64
+	AttendingGuests  int64
+	NotAttending     int64
+	Maybe            int64
+	MaybeGuests      int64
+	Waitlisted       int64
+	WaitlistedGuests int64
+	TotalResponses   int64
+}
+
+type IInvitationParticipantService interface {
+	GetStatusSummary(invitationID uint) (*ParticipantStatusSummary, error)
+	GetParticipantsByInvitationID(invitationID uint) ([]models.InvitationParticipant, error)
+	GetParticipantByID(id uint) (*models.InvitationParticipant, error)
+	DeleteParticipant(ctx context.Context, id uint) error
+	ExportParticipants(w io.Writer, invitationID uint, format string) error
+	PreviewImport(fileName string) (*ParticipantImportPreview, error)
+	ImportParticipants(ctx context.Context, invitationID uint, fileName string, mapping ParticipantColumnMapping) (*ParticipantImportResult, error)
+}
+
+type InvitationParticipantService struct {
+	repo                repositories.IInvitationParticipantRepository
+	questionRepo        repositories.IInvitationQuestionRepository
+	rsvpRepo            repositories.IRSVPRepository
+	notificationService INotificationService
+}
+
+func NewInvitationParticipantService() IInvitationParticipantService {
+	return &InvitationParticipantService{
+		repo:                repositories.NewInvitationParticipantRepository(),
+		questionRepo:        repositories.NewInvitationQuestionRepository(),
+		rsvpRepo:            repositories.NewRSVPRepository(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+func (s *InvitationParticipantService) GetStatusSummary(invitationID uint) (*ParticipantStatusSummary, error) {
+	rows, err := s.repo.CountByStatus(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Katılım durumu özeti alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("katılım özeti getirilirken bir hata oluştu")
+	}
+	summary := &ParticipantStatusSummary{}
+	for _, row := range rows {
+		summary.TotalResponses += row.Count
+		switch row.Status {
+		case models.RSVPNotAttending:
+			summary.NotAttending += row.Count
+		case models.RSVPMaybe:
+			summary.Maybe += row.Count
+			summary.MaybeGuests += row.Guests
+		case models.RSVPWaitlisted:
+			summary.Waitlisted += row.Count
+			summary.WaitlistedGuests += row.Guests
+		default:
+			summary.Attending += row.Count
+			summary.AttendingGuests += row.Guests
+		}
+	}
+	return summary, nil
+}
+
+func (s *InvitationParticipantService) GetParticipantsByInvitationID(invitationID uint) ([]models.InvitationParticipant, error) {
+	participants, err := s.repo.GetParticipantsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Katılımcılar alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+	return participants, nil
+}
+
+func (s *InvitationParticipantService) GetParticipantByID(id uint) (*models.InvitationParticipant, error) {
+	participant, err := s.repo.GetParticipantByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Katılımcı bulunamadı", zap.Uint("participant_id", id), zap.Error(err))
+		return nil, errors.New("katılımcı bulunamadı")
+	}
+	return participant, nil
+}
+
+// DeleteParticipant, katılımcıyı siler; boşalan yerlere yedek listedeki katılımcılar alınır.
+func (s *InvitationParticipantService) DeleteParticipant(ctx context.Context, id uint) error {
+	participant, err := s.GetParticipantByID(id)
+	if err != nil {
+		return err
+	}
+	outcome, err := s.rsvpRepo.DeleteParticipant(ctx, participant)
+	if err != nil {
+		logconfig.Log.Error("Katılımcı silinemedi", zap.Uint("participant_id", id), zap.Error(err))
+		return errors.New("katılımcı silinirken bir veritabanı hatası oluştu")
+	}
+	if len(outcome.Promoted) > 0 {
+		logconfig.Log.Info("Yedek listeden katılımcı alındı",
+			zap.Uint("invitation_id", participant.InvitationID),
+			zap.Int("promoted", len(outcome.Promoted)),
+		)
+		events := make([]ParticipantEvent, 0, len(outcome.Promoted))
+		for _, promoted := range outcome.Promoted {
+			events = append(events, ParticipantEvent{Type: models.NotificationRSVPPromoted, Invitation: &participant.Invitation, Participant: promoted})
+		}
+		s.notificationService.NotifyParticipantEvents(ctx, events...)
+	}
+	return nil
+}
+
+func (s *InvitationParticipantService) ExportParticipants(w io.Writer, invitationID uint, format string) error {
+	participants, err := s.GetParticipantsByInvitationID(invitationID)
+	if err != nil {
+		return err
+	}
+
+	questions, err := s.questionRepo.GetQuestionsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Dışa aktarma için sorular alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("sorular getirilirken bir hata oluştu")
+	}
+
+	header := []string{"Ad Soyad", "Telefon", "Kişi Sayısı", "Durum", "Mesaj"}
+	for _, question := range questions {
+		header = append(header, question.Label)
+	}
+	header = append(header, "Kayıt Tarihi")
+
+	rows := make([][]string, 0, len(participants))
+	for _, p := range participants {
+		answers := make(map[uint]string, len(p.Answers))
+		for _, answer := range p.Answers {
+			answers[answer.QuestionID] = answer.DisplayValue()
+		}
+		row := []string{
+			p.Title,
+			p.PhoneNumber,
+			strconv.Itoa(p.GuestCount),
+			p.Status.Label(),
+			p.Message,
+		}
+		for _, question := range questions {
+			row = append(row, answers[question.ID])
+		}
+		rows = append(rows, append(row, p.CreatedAt.Format("02.01.2006 15:04")))
+	}
+
+	switch format {
+	case spreadsheet.FormatCSV:
+		return spreadsheet.WriteCSV(w, header, rows)
+	case spreadsheet.FormatXLSX:
+		return spreadsheet.WriteXLSX(w, participantExportSheetName, header, rows)
+	default:
+		return spreadsheet.ErrUnsupportedFormat
+	}
+}
+
+func (s *InvitationParticipantService) PreviewImport(fileName string) (*ParticipantImportPreview, error) {
+	rows, err := s.readImportFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	columnCount := 0
+	for _, row := range rows {
+		if len(row) > columnCount {
+			columnCount = len(row)
+		}
+	}
+
+	mapping := guessParticipantColumns(rows[0])
+	preview := &ParticipantImportPreview{
+		FileName:    fileName,
+		ColumnCount: columnCount,
+		Mapping:     mapping,
+	}
+
+	dataRows := rows
+	if mapping.HasHeader {
+		preview.Headers = padRow(rows[0], columnCount)
+		dataRows = rows[1:]
+	} else {
+		for i := 0; i < columnCount; i++ {
+			preview.Headers = append(preview.Headers, fmt.Sprintf("Sütun %d", i+1))
+		}
+	}
+	preview.TotalRows = len(dataRows)
+	for i := 0; i < len(dataRows) && i < participantImportSampleSize; i++ {
+		preview.SampleRows = append(preview.SampleRows, padRow(dataRows[i], columnCount))
+	}
+	return preview, nil
+}
+
+func (s *InvitationParticipantService) ImportParticipants(ctx context.Context, invitationID uint, fileName string, mapping ParticipantColumnMapping) (*ParticipantImportResult, error) {
+	rows, err := s.readImportFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	firstRow := 1
+	if mapping.HasHeader {
+		rows = rows[1:]
+		firstRow = 2
+	}
+
+	result := &ParticipantImportResult{TotalRows: len(rows)}
+	participants := make([]models.InvitationParticipant, 0, len(rows))
+	participantRows := make([]int, 0, len(rows))
+	seenPhones := make(map[string]int)
+
+	for i, row := range rows {
+		rowNumber := firstRow + i
+		title := cellAt(row, mapping.TitleColumn)
+		rawPhone := cellAt(row, mapping.PhoneNumberColumn)
+
+		if mapping.TitleColumn >= len(row) && mapping.PhoneNumberColumn >= len(row) {
+			result.Errors = append(result.Errors, ParticipantImportRowError{Row: rowNumber, Message: ErrParticipantImportColumns.Error()})
+			continue
+		}
+
+		phone, err := phonenumber.NormalizeTR(rawPhone)
+		if err != nil {
+			result.Errors = append(result.Errors, ParticipantImportRowError{Row: rowNumber, Value: rawPhone, Message: "Telefon numarası geçerli bir Türkiye numarası değil"})
+			continue
+		}
+
+		guestCount := 1
+		if mapping.GuestCountColumn >= 0 {
+			if raw := cellAt(row, mapping.GuestCountColumn); raw != "" {
+				guestCount, err = strconv.Atoi(raw)
+				if err != nil {
+					result.Errors = append(result.Errors, ParticipantImportRowError{Row: rowNumber, Value: raw, Message: "Kişi sayısı sayı olmalıdır"})
+					continue
+				}
+			}
+		}
+
+		req := requests.InvitationParticipantRequest{Title: title, PhoneNumber: phone, GuestCount: guestCount}
+		if err := requests.ValidateInvitationParticipant(req); err != nil {
+			result.Errors = append(result.Errors, ParticipantImportRowError{Row: rowNumber, Value: title, Message: err.Error()})
+			continue
+		}
+
+		if previousRow, ok := seenPhones[phone]; ok {
+			result.Errors = append(result.Errors, ParticipantImportRowError{
+				Row:     rowNumber,
+				Value:   rawPhone,
+				Message: fmt.Sprintf("Bu telefon numarası dosyada %d. satırda zaten var", previousRow),
+			})
+			continue
+		}
+		seenPhones[phone] = rowNumber
+
+		participants = append(participants, models.InvitationParticipant{
+			Title:       req.Title,
+			PhoneNumber: req.PhoneNumber,
+			GuestCount:  req.GuestCount,
+		})
+		participantRows = append(participantRows, rowNumber)
+	}
+
+	if len(participants) > 0 {
+		outcome, err := s.repo.UpsertParticipantsByPhone(ctx, invitationID, participants)
+		if err != nil {
+			logconfig.Log.Error("Katılımcılar içe aktarılamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+			return nil, errors.New("katılımcılar kaydedilirken bir veritabanı hatası oluştu")
+		}
+		result.Created = outcome.Created
+		result.Updated = outcome.Updated
+		result.Waitlisted = outcome.Waitlisted
+		for i, available := range outcome.Rejected {
+			if available < 0 {
+				available = 0
+			}
+			result.Errors = append(result.Errors, ParticipantImportRowError{
+				Row:     participantRows[i],
+				Value:   participants[i].Title,
+				Message: fmt.Sprintf("Kalan kapasite yetersiz; bu katılımcının kişi sayısı en fazla %d olabilir", available),
+			})
+		}
+		for _, i := range outcome.OverPerParticipant {
+			result.Errors = append(result.Errors, ParticipantImportRowError{
+				Row:     participantRows[i],
+				Value:   strconv.Itoa(participants[i].GuestCount),
+				Message: fmt.Sprintf("Bir katılımcı için en fazla %d kişi bildirilebilir", outcome.Invitation.MaxGuestsPerParticipant),
+			})
+		}
+		sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+		if len(outcome.Promoted) > 0 {
+			logconfig.Log.Info("Yedek listeden katılımcı alındı",
+				zap.Uint("invitation_id", invitationID),
+				zap.Int("promoted", len(outcome.Promoted)),
+			)
+			events := make([]ParticipantEvent, 0, len(outcome.Promoted))
+			for _, promoted := range outcome.Promoted {
+				events = append(events, ParticipantEvent{Type: models.NotificationRSVPPromoted, Invitation: outcome.Invitation, Participant: promoted})
+			}
+			s.notificationService.NotifyParticipantEvents(ctx, events...)
+		}
+	}
+
+	filemanager.DeleteTempFile(ParticipantImportContentType, fileName)
+	logconfig.Log.Info("Katılımcılar içe aktarıldı",
+		zap.Uint("invitation_id", invitationID),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("waitlisted", result.Waitlisted),
+		zap.Int("errors", len(result.Errors)),
+	)
+	return result, nil
+}
+
+func (s *InvitationParticipantService) readImportFile(fileName string) ([][]string, error) {
+	path, err := filemanager.TempFilePath(ParticipantImportContentType, fileName)
+	if err != nil {
+		logconfig.Log.Warn("İçe aktarma dosyası bulunamadı", zap.String("file_name", fileName), zap.Error(err))
+		return nil, errors.New("içe aktarma dosyası bulunamadı, lütfen dosyayı tekrar yükleyin")
+	}
+	rows, err := spreadsheet.ReadFile(path)
+	if err != nil {
+		logconfig.Log.Warn("İçe aktarma dosyası okunamadı", zap.String("file_name", fileName), zap.Error(err))
+		return nil, errors.New("dosya okunamadı, geçerli bir CSV veya XLSX dosyası yükleyin")
+	}
+	if len(rows) == 0 {
+		return nil, ErrParticipantImportEmpty
+	}
+	return rows, nil
+}
+
+var participantColumnAliases = map[string][]string{
+	"title":       {"ad soyad", "adsoyad", "isim", "ad", "misafir", "davetli", "name", "full name"},
+	"phone":       {"telefon", "tel", "telefon no", "telefon numarasi", "gsm", "cep", "cep telefonu", "phone", "mobile"},
+	"guest_count": {"kisi sayisi", "kisi", "sayi", "adet", "misafir sayisi", "guest count", "guests"},
+}
+
+// guessParticipantColumns, başlık satırındaki isimlere bakarak sütun eşleştirmesini tahmin eder.
+func guessParticipantColumns(firstRow []string) ParticipantColumnMapping {
+	mapping := ParticipantColumnMapping{TitleColumn: -1, PhoneNumberColumn: -1, GuestCountColumn: -1}
+	for i, cell := range firstRow {
+		normalized := strings.TrimSpace(turkishsearch.Normalize(cell))
+		switch {
+		case mapping.TitleColumn < 0 && containsString(participantColumnAliases["title"], normalized):
+			mapping.TitleColumn = i
+		case mapping.PhoneNumberColumn < 0 && containsString(participantColumnAliases["phone"], normalized):
+			mapping.PhoneNumberColumn = i
+		case mapping.GuestCountColumn < 0 && containsString(participantColumnAliases["guest_count"], normalized):
+			mapping.GuestCountColumn = i
+		}
+	}
+
+	mapping.HasHeader = mapping.TitleColumn >= 0 || mapping.PhoneNumberColumn >= 0
+	if mapping.TitleColumn < 0 {
+		mapping.TitleColumn = 0
+	}
+	if mapping.PhoneNumberColumn < 0 {
+		mapping.PhoneNumberColumn = 1
+	}
+	return mapping
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func cellAt(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func padRow(row []string, length int) []string {
+	padded := make([]string, length)
+	copy(padded, row)
+	return padded
+}
+
+var _ IInvitationParticipantService = (*InvitationParticipantService)(nil)
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const questionSummaryTextLimit = 20
+
+var ErrQuestionOptions = errors.New("seçmeli sorular için en az iki seçenek girilmelidir")
+
+// QuestionValueSummary, bir seçeneği seçen katılımcı ve kişi sayısını tutar.
+type QuestionValueSummary struct {
+	Label  string
+	Count  int64
+	Guests int64
+}
+
+// QuestionSummary, bir özel soruya verilen yanıtların tipine göre özetidir.
+type QuestionSummary struct {
+	Question      models.InvitationQuestion
+	AnswerCount   int64
+	Values        []QuestionValueSummary
+	NumberTotal   float64
+	NumberAverage float64
+	TextAnswers   []repositories.AnswerText
+}
+
+type IInvitationQuestionService interface {
+	GetQuestionsByInvitationID(invitationID uint) ([]models.InvitationQuestion, error)
+	GetQuestionByID(id uint) (*models.InvitationQuestion, error)
+	CreateQuestion(ctx context.Context, invitationID uint, req requests.InvitationQuestionRequest) error
+	DeleteQuestion(ctx context.Context, id uint) error
+	GetAnswerValues(participantID uint) (map[uint]string, error)
+	GetQuestionSummaries(invitationID uint) ([]QuestionSummary, error)
+}
+
+type InvitationQuestionService struct {
+	repo repositories.IInvitationQuestionRepository
+}
+
+func NewInvitationQuestionService() IInvitationQuestionService {
+	return &InvitationQuestionService{repo: repositories.NewInvitationQuestionRepository()}
+}
+
+func (s *InvitationQuestionService) GetQuestionsByInvitationID(invitationID uint) ([]models.InvitationQuestion, error) {
+	questions, err := s.repo.GetQuestionsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Özel sorular alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("sorular getirilirken bir hata oluştu")
+	}
+	return questions, nil
+}
+
+func (s *InvitationQuestionService) GetQuestionByID(id uint) (*models.InvitationQuestion, error) {
+	question, err := s.repo.GetQuestionByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Özel soru bulunamadı", zap.Uint("question_id", id), zap.Error(err))
+		return nil, errors.New("soru bulunamadı")
+	}
+	return question, nil
+}
+
+func (s *InvitationQuestionService) CreateQuestion(ctx context.Context, invitationID uint, req requests.InvitationQuestionRequest) error {
+	question := &models.InvitationQuestion{
+		InvitationID: invitationID,
+		Label:        strings.TrimSpace(req.Label),
+		Type:         models.QuestionType(req.Type),
+		IsRequired:   req.IsRequired,
+	}
+	if question.Type == models.QuestionTypeChoice {
+		question.Options = req.Options
+		options := question.OptionList()
+		if len(options) < 2 {
+			return ErrQuestionOptions
+		}
+		question.Options = strings.Join(options, "\n")
+	}
+
+	sortOrder, err := s.repo.GetNextSortOrder(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Soru sırası hesaplanamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("soru kaydedilirken bir veritabanı hatası oluştu")
+	}
+	question.SortOrder = sortOrder
+
+	if err := s.repo.CreateQuestion(ctx, question); err != nil {
+		logconfig.Log.Error("Özel soru oluşturulamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("soru kaydedilirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationQuestionService) DeleteQuestion(ctx context.Context, id uint) error {
+	return s.repo.DeleteQuestion(ctx, id)
+}
+
+// GetAnswerValues, katılımcının mevcut yanıtlarını katılım formunu doldurmak için soru ID'sine göre döner.
+func (s *InvitationQuestionService) GetAnswerValues(participantID uint) (map[uint]string, error) {
+	answers, err := s.repo.GetAnswersByParticipantID(participantID)
+	if err != nil {
+		logconfig.Log.Warn("Katılımcı yanıtları alınamadı", zap.Uint("participant_id", participantID), zap.Error(err))
+		return nil, errors.New("yanıtlar getirilemedi")
+	}
+	values := make(map[uint]string, len(answers))
+	for _, answer := range answers {
+		values[answer.QuestionID] = answer.FormValue()
+	}
+	return values, nil
+}
+
+// GetQuestionSummaries, her soru için yanıt dağılımını SQL toplamlarıyla hesaplar.
+// Katılmayacağını bildiren katılımcıların yanıtları özete dahil edilmez.
+func (s *InvitationQuestionService) GetQuestionSummaries(invitationID uint) ([]QuestionSummary, error) {
+	questions, err := s.GetQuestionsByInvitationID(invitationID)
+	if err != nil || len(questions) == 0 {
+		return nil, err
+	}
+
+	valueCounts, err := s.repo.CountAnswerValues(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Soru yanıt dağılımı alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("soru özetleri hesaplanamadı")
+	}
+	numberStats, err := s.repo.GetNumberStats(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Sayısal yanıt özeti alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("soru özetleri hesaplanamadı")
+	}
+	textAnswers, err := s.repo.GetTextAnswers(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Metin yanıtları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("soru özetleri hesaplanamadı")
+	}
+
+	summaries := make([]QuestionSummary, 0, len(questions))
+	indexByQuestion := make(map[uint]int, len(questions))
+	for i, question := range questions {
+		summary := QuestionSummary{Question: question}
+		// Seçenekler sıfır yanıtla da görünsün diye önceden eklenir.
+		for _, option := range question.OptionList() {
+			summary.Values = append(summary.Values, QuestionValueSummary{Label: option})
+		}
+		if question.Type == models.QuestionTypeBoolean {
+			summary.Values = []QuestionValueSummary{{Label: "Evet"}, {Label: "Hayır"}}
+		}
+		summaries = append(summaries, summary)
+		indexByQuestion[question.ID] = i
+	}
+
+	for _, row := range valueCounts {
+		i, ok := indexByQuestion[row.QuestionID]
+		if !ok {
+			continue
+		}
+		label := row.ChoiceValue
+		if row.BoolValue != nil {
+			label = models.InvitationAnswer{BoolValue: row.BoolValue}.DisplayValue()
+		}
+		summaries[i].AnswerCount += row.Count
+		summaries[i].Values = addQuestionValue(summaries[i].Values, label, row.Count, row.Guests)
+	}
+	for _, row := range numberStats {
+		if i, ok := indexByQuestion[row.QuestionID]; ok {
+			summaries[i].AnswerCount = row.Count
+			summaries[i].NumberTotal = row.Total
+			summaries[i].NumberAverage = row.Average
+		}
+	}
+	for _, row := range textAnswers {
+		i, ok := indexByQuestion[row.QuestionID]
+		if !ok {
+			continue
+		}
+		summaries[i].AnswerCount++
+		if len(summaries[i].TextAnswers) < questionSummaryTextLimit {
+			summaries[i].TextAnswers = append(summaries[i].TextAnswers, row)
+		}
+	}
+	return summaries, nil
+}
+
+func addQuestionValue(values []QuestionValueSummary, label string, count, guests int64) []QuestionValueSummary {
+	for i := range values {
+		if values[i].Label == label {
+			values[i].Count += count
+			values[i].Guests += guests
+			return values
+		}
+	}
+	// Sonradan kaldırılmış bir seçeneğe verilmiş yanıtlar da özette görünür.
+	return append(values, QuestionValueSummary{Label: label, Count: count, Guests: guests})
+}
+
+var _ IInvitationQuestionService = (*InvitationQuestionService)(nil)
@@ -5,28 +5,53 @@ import (
 	"crypto/rand"
 	"davet.link/configs/logconfig"
 	"davet.link/models"
+	"davet.link/pkg/detailfields"
+	"davet.link/pkg/invitationtheme"
 	"davet.link/pkg/queryparams"
 	"davet.link/repositories"
+	"davet.link/requests"
 	"errors"
+	"fmt"
 	"go.uber.org/zap"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvitationThemeNotFound    = errors.New("seçilen şablon bulunamadı")
+	ErrInvitationCategoryNotFound = errors.New("davetiye kategorisi bulunamadı")
 )
 
 type IInvitationService interface {
 	GetAllInvitations(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetInvitationsForUser(userID uint, params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetInvitationRole(invitation *models.Invitation, userID uint) (models.InvitationRole, bool)
 	GetInvitationByID(id uint) (*models.Invitation, error)
 	GetInvitationByKey(ctx context.Context, key string) (*models.Invitation, error) // YENİ METOT
 	CreateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error
 	DeleteInvitationWithRelations(ctx context.Context, id uint) error
 	GetInvitationCount() (int64, error)
+	PreviewInvitation(req requests.InvitationRequest, image string) (*models.Invitation, []string, error)
 }
 
 type InvitationService struct {
-	repo repositories.IInvitationRepository
+	repo                repositories.IInvitationRepository
+	categoryRepo        repositories.IInvitationCategoryRepository
+	coHostRepo          repositories.IInvitationCoHostRepository
+	venueService        IVenueService
+	notificationService INotificationService
 }
 
 func NewInvitationService() IInvitationService {
-	return &InvitationService{repo: repositories.NewInvitationRepository()}
+	return &InvitationService{
+		repo:                repositories.NewInvitationRepository(),
+		categoryRepo:        repositories.NewInvitationCategoryRepository(),
+		coHostRepo:          repositories.NewInvitationCoHostRepository(),
+		venueService:        NewVenueService(),
+		notificationService: NewNotificationService(),
+	}
 }
 
 func (s *InvitationService) GetAllInvitations(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
@@ -42,6 +67,34 @@ func (s *InvitationService) GetAllInvitations(params queryparams.ListParams) (*q
 	return result, nil
 }
 
+func (s *InvitationService) GetInvitationsForUser(userID uint, params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	invitations, totalCount, err := s.repo.GetInvitationsForUser(userID, params)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcının davetiyeleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("davetiyeler getirilirken bir veritabanı hatası oluştu")
+	}
+	return &queryparams.PaginatedResult{
+		Data: invitations,
+		Meta: queryparams.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage, TotalItems: totalCount, TotalPages: queryparams.CalculateTotalPages(totalCount, params.PerPage)},
+	}, nil
+}
+
+// GetInvitationRole, kullanıcının davetiyedeki rolünü döner: sahibi için owner, daveti kabul
+// etmiş ortak ev sahipleri için verilen rol. Kullanıcının erişimi yoksa false döner.
+func (s *InvitationService) GetInvitationRole(invitation *models.Invitation, userID uint) (models.InvitationRole, bool) {
+	if invitation.UserID == userID {
+		return models.InvitationRoleOwner, true
+	}
+	coHost, err := s.coHostRepo.GetAcceptedCoHost(invitation.ID, userID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Ortak ev sahipliği alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		}
+		return "", false
+	}
+	return coHost.Role, true
+}
+
 func (s *InvitationService) GetInvitationByID(id uint) (*models.Invitation, error) {
 	invitation, err := s.repo.GetInvitationByID(id)
 	if err != nil {
@@ -61,6 +114,9 @@ func (s *InvitationService) GetInvitationByKey(ctx context.Context, key string)
 }
 
 func (s *InvitationService) CreateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error {
+	if err := s.applyCategory(invitation); err != nil {
+		return err
+	}
 	for {
 		key := generateInvitationKey(11)
 		exists, err := s.repo.KeyExists(ctx, key)
@@ -77,7 +133,25 @@ func (s *InvitationService) CreateInvitationWithRelations(ctx context.Context, i
 }
 
 func (s *InvitationService) UpdateInvitationWithRelations(ctx context.Context, invitation *models.Invitation) error {
-	return s.repo.UpdateInvitationWithRelations(ctx, invitation)
+	if err := s.applyCategory(invitation); err != nil {
+		return err
+	}
+	promoted, err := s.repo.UpdateInvitationWithRelations(ctx, invitation)
+	if err != nil {
+		return err
+	}
+	if len(promoted) > 0 {
+		logconfig.Log.Info("Kapasite değişikliğiyle yedek listeden katılımcı alındı",
+			zap.Uint("invitation_id", invitation.ID),
+			zap.Int("promoted", len(promoted)),
+		)
+		events := make([]ParticipantEvent, 0, len(promoted))
+		for _, participant := range promoted {
+			events = append(events, ParticipantEvent{Type: models.NotificationRSVPPromoted, Invitation: invitation, Participant: participant})
+		}
+		s.notificationService.NotifyParticipantEvents(ctx, events...)
+	}
+	return nil
 }
 
 func (s *InvitationService) DeleteInvitationWithRelations(ctx context.Context, id uint) error {
@@ -88,6 +162,141 @@ func (s *InvitationService) GetInvitationCount() (int64, error) {
 	return s.repo.GetInvitationCount()
 }
 
+// PreviewInvitation, formdaki bilgilerden kaydedilmeyecek bir davetiye oluşturur. Şablonun
+// kategoriyle uyumsuzluğu ve eksik zorunlu alanlar hata yerine uyarı olarak döner; böylece
+// sahibi form tamamlanmadan da görünümü inceleyebilir.
+func (s *InvitationService) PreviewInvitation(req requests.InvitationRequest, image string) (*models.Invitation, []string, error) {
+	category, err := s.categoryRepo.GetCategoryByID(req.CategoryID)
+	if err != nil {
+		return nil, nil, ErrInvitationCategoryNotFound
+	}
+	// Önizlemede yalnızca yüklenmiş görselin adı kullanılır; yol bileşenleri atılır
+	if image != "" {
+		image = filepath.Base(image)
+	}
+	invitation := &models.Invitation{
+		CategoryID:       req.CategoryID,
+		Template:         req.Template,
+		ThemeOptions:     invitationtheme.EncodeOptions(req.ThemeOptions),
+		Title:            req.Title,
+		Image:            image,
+		Telephone:        req.Telephone,
+		Date:             req.Date,
+		Time:             req.Time,
+		IsParticipant:    req.IsParticipant,
+		IsGuestbook:      req.IsGuestbook,
+		RSVPDeadline:     req.RSVPDeadlineValue(),
+		Category:         category,
+		InvitationDetail: &models.InvitationDetail{Fields: req.Fields},
+	}
+
+	var warnings []string
+	// Önizleme isteğinin bağlamı yoktur; kısa bağlantılar yine de zaman aşımıyla sınırlıdır.
+	venue, err := s.venueService.ResolveVenue(context.Background(), req.Venue)
+	if err != nil {
+		warnings = append(warnings, "Mekan: "+err.Error()+".")
+	}
+	invitation.Venue = venue
+	schema := CategorySchema(category)
+	values, err := schema.Normalize(req.Fields)
+	if err != nil {
+		warnings = append(warnings, err.Error()+".")
+		values = detailfields.Values{}
+	}
+	invitation.InvitationDetail.Fields = values
+	if missing := schema.Missing(values); len(missing) > 0 {
+		warnings = append(warnings, "Doldurulması gereken alanlar: "+strings.Join(missing, ", ")+".")
+	}
+	if invitation.Template == "" {
+		invitation.Template = invitationtheme.DefaultTheme
+	}
+	theme, ok := invitationtheme.Get(invitation.Template)
+	if !ok {
+		return nil, nil, ErrInvitationThemeNotFound
+	}
+	if !theme.Supports(category.Template) {
+		warnings = append(warnings, fmt.Sprintf("%s şablonu %s kategorisinde kullanılamaz.", theme.Label, category.Name))
+	}
+	if missing := theme.Missing(values.Flatten()); len(missing) > 0 {
+		warnings = append(warnings, "Şablonun gerektirdiği alanlar: "+strings.Join(missing, ", ")+".")
+	}
+	if _, err := theme.ResolveOptions(req.ThemeOptions); err != nil {
+		warnings = append(warnings, "Şablon ayarları geçersiz: "+err.Error()+".")
+	}
+	return invitation, warnings, nil
+}
+
+// applyCategory, detay alanlarını ve şablonu davetiyenin kategorisine göre doğrular. Detay
+// değerleri kategorinin alan şemasına göre normalize edilir; şablon seçilmemişse varsayılan
+// şablon atanır ve seçenekler varsayılanlarla tamamlanarak saklanacak biçime çevrilir.
+func (s *InvitationService) applyCategory(invitation *models.Invitation) error {
+	category, err := s.categoryRepo.GetCategoryByID(invitation.CategoryID)
+	if err != nil {
+		logconfig.Log.Warn("Davetiye kategorisi bulunamadı", zap.Uint("category_id", invitation.CategoryID), zap.Error(err))
+		return ErrInvitationCategoryNotFound
+	}
+	if invitation.InvitationDetail == nil {
+		invitation.InvitationDetail = &models.InvitationDetail{}
+	}
+	schema := CategorySchema(category)
+	values, err := schema.Normalize(invitation.InvitationDetail.Fields)
+	if err != nil {
+		return err
+	}
+	if missing := schema.Missing(values); len(missing) > 0 {
+		return fmt.Errorf("zorunlu alanlar eksik: %s", strings.Join(missing, ", "))
+	}
+	invitation.InvitationDetail.Fields = values
+
+	if invitation.Template == "" {
+		invitation.Template = invitationtheme.DefaultTheme
+	}
+	theme, ok := invitationtheme.Get(invitation.Template)
+	if !ok {
+		return ErrInvitationThemeNotFound
+	}
+	if !theme.Supports(category.Template) {
+		return fmt.Errorf("%s şablonu %s kategorisinde kullanılamaz", strings.ToLower(theme.Label), category.Name)
+	}
+	if missing := theme.Missing(values.Flatten()); len(missing) > 0 {
+		return fmt.Errorf("şablon için zorunlu alanlar eksik: %s", strings.Join(missing, ", "))
+	}
+	options, err := theme.ResolveOptions(invitationtheme.DecodeOptions(invitation.ThemeOptions))
+	if err != nil {
+		return fmt.Errorf("şablon ayarları geçersiz: %w", err)
+	}
+	invitation.ThemeOptions = invitationtheme.EncodeOptions(options)
+	return nil
+}
+
+// InvitationAppearance, davetiyenin kayıtlı şablonunu ve seçeneklerini görünüme çevirir.
+func InvitationAppearance(invitation *models.Invitation) invitationtheme.Appearance {
+	return invitationtheme.Resolve(invitation.Template, invitation.ThemeOptions)
+}
+
+// CategorySchema, kategorinin detay alanı şemasını döner; kategoriye özel şema
+// tanımlanmamışsa türünün varsayılan alanları kullanılır.
+func CategorySchema(category *models.InvitationCategory) detailfields.Schema {
+	if category == nil {
+		return nil
+	}
+	return invitationtheme.SchemaFor(category.Template, category.FieldSchema)
+}
+
+// InvitationDetailEntries, davetiye sayfasında gösterilecek dolu detay alanlarını kategorinin
+// şemasındaki sırayla döner; başlıkta kullanılan alanlar tekrar listelenmez. Kategorinin
+// yüklenmiş olması gerekir.
+func InvitationDetailEntries(invitation *models.Invitation) []detailfields.Entry {
+	_, headlineKeys := invitationHeadline(invitation)
+	var entries []detailfields.Entry
+	for _, entry := range CategorySchema(invitation.Category).Entries(invitation.DetailFields()) {
+		if !slices.Contains(headlineKeys, entry.Field.Key) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 func generateInvitationKey(n int) string {
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTableNotFound                = errors.New("masa bulunamadı")
+	ErrTableParticipantNotFound     = errors.New("katılımcı bulunamadı")
+	ErrTableParticipantNotAttending = errors.New("yalnızca katılımı onaylı katılımcılar masaya yerleştirilebilir")
+	ErrTableCapacityBelowSeated     = errors.New("kapasite masada oturan kişi sayısından az olamaz")
+)
+
+// SeatingPlan, oturma planı ekranının verisidir. Toplamlar yalnızca katılımı onaylı
+// katılımcılardan hesaplanır.
+type SeatingPlan struct {
+	Tables           []models.InvitationTable
+	Unassigned       []models.InvitationParticipant
+	Capacity         int
+	SeatedGuests     int
+	UnassignedGuests int
+}
+
+type IInvitationTableService interface {
+	GetSeatingPlan(invitationID uint) (*SeatingPlan, error)
+	GetTableByID(id uint) (*models.InvitationTable, error)
+	CreateTable(ctx context.Context, invitationID uint, req requests.InvitationTableRequest) error
+	UpdateTable(ctx context.Context, table *models.InvitationTable, req requests.InvitationTableRequest) error
+	DeleteTable(ctx context.Context, id uint) error
+	AssignParticipant(ctx context.Context, invitationID, participantID, tableID uint) error
+	AutoAssign(ctx context.Context, invitationID uint) (*repositories.AutoAssignOutcome, error)
+	GetParticipantTableName(participantID uint) string
+}
+
+type InvitationTableService struct {
+	repo            repositories.IInvitationTableRepository
+	participantRepo repositories.IInvitationParticipantRepository
+}
+
+func NewInvitationTableService() IInvitationTableService {
+	return &InvitationTableService{
+		repo:            repositories.NewInvitationTableRepository(),
+		participantRepo: repositories.NewInvitationParticipantRepository(),
+	}
+}
+
+func (s *InvitationTableService) GetSeatingPlan(invitationID uint) (*SeatingPlan, error) {
+	tables, err := s.repo.GetTablesByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Masalar alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("masalar getirilirken bir hata oluştu")
+	}
+	unassigned, err := s.repo.GetUnassignedParticipants(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Masası olmayan katılımcılar alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+
+	plan := &SeatingPlan{Tables: tables, Unassigned: unassigned}
+	for _, table := range tables {
+		plan.Capacity += table.Capacity
+		plan.SeatedGuests += table.SeatedGuests()
+	}
+	for _, participant := range unassigned {
+		plan.UnassignedGuests += participant.GuestCount
+	}
+	return plan, nil
+}
+
+func (s *InvitationTableService) GetTableByID(id uint) (*models.InvitationTable, error) {
+	table, err := s.repo.GetTableByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Masa bulunamadı", zap.Uint("table_id", id), zap.Error(err))
+		return nil, ErrTableNotFound
+	}
+	return table, nil
+}
+
+func (s *InvitationTableService) CreateTable(ctx context.Context, invitationID uint, req requests.InvitationTableRequest) error {
+	table := &models.InvitationTable{
+		InvitationID: invitationID,
+		Name:         req.Name,
+		Capacity:     req.Capacity,
+	}
+	if err := s.repo.CreateTable(ctx, table); err != nil {
+		logconfig.Log.Error("Masa oluşturulamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return errors.New("masa kaydedilirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+// UpdateTable, masanın adını ve kapasitesini günceller. Kapasite, masada oturanların
+// toplamının altına düşürülemez; önce katılımcıların başka masalara taşınması gerekir.
+func (s *InvitationTableService) UpdateTable(ctx context.Context, table *models.InvitationTable, req requests.InvitationTableRequest) error {
+	tables, err := s.repo.GetTablesByInvitationID(table.InvitationID)
+	if err != nil {
+		logconfig.Log.Error("Masalar alınamadı", zap.Uint("invitation_id", table.InvitationID), zap.Error(err))
+		return errors.New("masa güncellenirken bir hata oluştu")
+	}
+	for _, current := range tables {
+		if current.ID == table.ID && req.Capacity < current.SeatedGuests() {
+			return fmt.Errorf("%w (oturan: %d kişi)", ErrTableCapacityBelowSeated, current.SeatedGuests())
+		}
+	}
+
+	userID, _ := ctx.Value("user_id").(uint)
+	data := map[string]interface{}{
+		"name":     req.Name,
+		"capacity": req.Capacity,
+	}
+	if err := s.repo.UpdateTable(ctx, table.ID, data, userID); err != nil {
+		logconfig.Log.Error("Masa güncellenemedi", zap.Uint("table_id", table.ID), zap.Error(err))
+		return errors.New("masa güncellenirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationTableService) DeleteTable(ctx context.Context, id uint) error {
+	if err := s.repo.DeleteTable(ctx, id); err != nil {
+		logconfig.Log.Error("Masa silinemedi", zap.Uint("table_id", id), zap.Error(err))
+		return errors.New("masa silinirken bir veritabanı hatası oluştu")
+	}
+	return nil
+}
+
+// AssignParticipant, katılımcıyı grubuyla birlikte masaya yerleştirir; tableID 0 ise masadan
+// kaldırır. Katılımcının kişi sayısı masanın boş koltuklarına sığmıyorsa atama yapılmaz.
+func (s *InvitationTableService) AssignParticipant(ctx context.Context, invitationID, participantID, tableID uint) error {
+	participant, err := s.participantRepo.GetParticipantByID(participantID)
+	if err != nil || participant.InvitationID != invitationID {
+		return ErrTableParticipantNotFound
+	}
+
+	var target *uint
+	if tableID != 0 {
+		if participant.Status != models.RSVPAttending {
+			return ErrTableParticipantNotAttending
+		}
+		table, err := s.repo.GetTableByID(tableID)
+		if err != nil || table.InvitationID != invitationID {
+			return ErrTableNotFound
+		}
+		target = &table.ID
+	}
+
+	err = s.repo.AssignParticipant(ctx, participant, target)
+	var fullErr *repositories.TableFullError
+	if errors.As(err, &fullErr) {
+		return fmt.Errorf("%s %d kişilik, masada %d boş koltuk kalmış", participant.Title, participant.GuestCount, fullErr.Available)
+	}
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrTableNotFound
+	}
+	if err != nil {
+		logconfig.Log.Error("Katılımcı masaya yerleştirilemedi",
+			zap.Uint("participant_id", participantID), zap.Uint("table_id", tableID), zap.Error(err))
+		return errors.New("masa ataması kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *InvitationTableService) AutoAssign(ctx context.Context, invitationID uint) (*repositories.AutoAssignOutcome, error) {
+	outcome, err := s.repo.AutoAssign(ctx, invitationID)
+	if err != nil {
+		logconfig.Log.Error("Otomatik masa yerleştirme yapılamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("otomatik yerleştirme sırasında bir hata oluştu")
+	}
+	logconfig.Log.Info("Katılımcılar masalara otomatik yerleştirildi",
+		zap.Uint("invitation_id", invitationID),
+		zap.Int("assigned", outcome.Assigned),
+		zap.Int("unplaced", len(outcome.Unplaced)),
+	)
+	return outcome, nil
+}
+
+// GetParticipantTableName, misafir sayfasında gösterilmek üzere katılımcının masasının adını
+// döner; masası yoksa boş döner.
+func (s *InvitationTableService) GetParticipantTableName(participantID uint) string {
+	table, err := s.repo.GetParticipantTable(participantID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Warn("Katılımcının masası alınamadı", zap.Uint("participant_id", participantID), zap.Error(err))
+		}
+		return ""
+	}
+	return table.Name
+}
+
+var _ IInvitationTableService = (*InvitationTableService)(nil)
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/envconfig"
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/messaging"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const (
+	messageDispatchBatchSize = 50
+	messageMaxAttempts       = 3
+	messageStaleAfter        = 10 * time.Minute
+)
+
+var (
+	ErrMessageQuotaExceeded = errors.New("günlük mesaj gönderim kotanız doldu")
+	ErrNoMessageRecipients  = errors.New("bu davetiyede telefon numarası kayıtlı katılımcı yok")
+	ErrChannelUnavailable   = errors.New("seçilen gönderim kanalı kullanılamıyor")
+)
+
+// BroadcastSummary, duyuru listesinde gösterilen teslim durumu özetidir.
+type BroadcastSummary struct {
+	Broadcast models.MessageBroadcast
+	Pending   int64
+	Sent      int64
+	Failed    int64
+}
+
+// MessageQuota, kullanıcının günlük gönderim kotası ve bugünkü kullanımıdır.
+type MessageQuota struct {
+	Limit     int64
+	Used      int64
+	Remaining int64
+}
+
+type IMessagingService interface {
+	GetBroadcasts(invitationID uint) ([]BroadcastSummary, error)
+	GetBroadcast(id uint) (*models.MessageBroadcast, error)
+	CreateBroadcast(ctx context.Context, userID uint, invitation *models.Invitation, req requests.MessageBroadcastRequest) (*models.MessageBroadcast, error)
+	GetQuota(userID uint) (*MessageQuota, error)
+	EnqueueMessages(ctx context.Context, userID uint, messages []models.OutboundMessage) (int64, error)
+	AvailableChannels() []models.MessageChannel
+	DispatchPending(ctx context.Context) (int, error)
+}
+
+type MessagingService struct {
+	repo            repositories.IOutboundMessageRepository
+	participantRepo repositories.IInvitationParticipantRepository
+	providers       map[models.MessageChannel]messaging.Provider
+	dailyQuota      int64
+}
+
+func NewMessagingService() IMessagingService {
+	return &MessagingService{
+		repo:            repositories.NewOutboundMessageRepository(),
+		participantRepo: repositories.NewInvitationParticipantRepository(),
+		providers:       newMessagingProviders(),
+		dailyQuota:      int64(envconfig.GetEnvAsInt("MESSAGING_DAILY_QUOTA", 500)),
+	}
+}
+
+// newMessagingProviders, ortam değişkenlerine göre kanal sağlayıcılarını oluşturur.
+// Sağlayıcı ayarlanmamış ya da tanınmıyorsa kanal kapalı kalır; mesajları yalnızca bellekte tutan
+// sahte sağlayıcı production dışında kullanılabilir.
+func newMessagingProviders() map[models.MessageChannel]messaging.Provider {
+	providers := make(map[models.MessageChannel]messaging.Provider)
+
+	switch provider := envconfig.GetEnvWithDefault("SMS_PROVIDER", "none"); provider {
+	case "http":
+		providers[models.MessageChannelSMS] = messaging.NewHTTPSMSProvider(messaging.HTTPSMSConfig{
+			Endpoint: envconfig.GetEnvWithDefault("SMS_API_URL", ""),
+			APIKey:   envconfig.GetEnvWithDefault("SMS_API_KEY", ""),
+			Sender:   envconfig.GetEnvWithDefault("SMS_SENDER", "davet.link"),
+		})
+	case "fake":
+		if fake := newFakeMessagingProvider("SMS_PROVIDER", messaging.ChannelSMS); fake != nil {
+			providers[models.MessageChannelSMS] = fake
+		}
+	case "none":
+	default:
+		logconfig.Log.Error("Tanınmayan SMS sağlayıcısı, SMS kanalı kapalı", zap.String("SMS_PROVIDER", provider))
+	}
+
+	switch provider := envconfig.GetEnvWithDefault("WHATSAPP_PROVIDER", "none"); provider {
+	case "cloud":
+		providers[models.MessageChannelWhatsApp] = messaging.NewWhatsAppProvider(messaging.WhatsAppConfig{
+			BaseURL:          envconfig.GetEnvWithDefault("WHATSAPP_API_URL", ""),
+			AccessToken:      envconfig.GetEnvWithDefault("WHATSAPP_ACCESS_TOKEN", ""),
+			PhoneNumberID:    envconfig.GetEnvWithDefault("WHATSAPP_PHONE_NUMBER_ID", ""),
+			TemplateName:     envconfig.GetEnvWithDefault("WHATSAPP_TEMPLATE_NAME", "davet_duyuru"),
+			TemplateLanguage: envconfig.GetEnvWithDefault("WHATSAPP_TEMPLATE_LANGUAGE", "tr"),
+		})
+	case "fake":
+		if fake := newFakeMessagingProvider("WHATSAPP_PROVIDER", messaging.ChannelWhatsApp); fake != nil {
+			providers[models.MessageChannelWhatsApp] = fake
+		}
+	case "none":
+	default:
+		logconfig.Log.Error("Tanınmayan WhatsApp sağlayıcısı, WhatsApp kanalı kapalı", zap.String("WHATSAPP_PROVIDER", provider))
+	}
+	return providers
+}
+
+// newFakeMessagingProvider, production ortamında nil döner; sahte sağlayıcı mesajları teslim
+// etmeden gönderildi olarak işaretleyeceğinden orada kanal kapalı kalır.
+func newFakeMessagingProvider(envKey string, channel messaging.Channel) messaging.Provider {
+	if envconfig.IsProduction() {
+		logconfig.Log.Error("Sahte mesaj sağlayıcısı production ortamında kullanılamaz, kanal kapalı", zap.String(envKey, "fake"))
+		return nil
+	}
+	return messaging.NewFakeProvider(channel)
+}
+
+func (s *MessagingService) AvailableChannels() []models.MessageChannel {
+	channels := make([]models.MessageChannel, 0, len(s.providers))
+	for _, channel := range []models.MessageChannel{models.MessageChannelSMS, models.MessageChannelWhatsApp} {
+		if _, ok := s.providers[channel]; ok {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func (s *MessagingService) GetBroadcasts(invitationID uint) ([]BroadcastSummary, error) {
+	broadcasts, err := s.repo.GetBroadcastsByInvitationID(invitationID)
+	if err != nil {
+		logconfig.Log.Error("Duyurular alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("duyurular getirilirken bir hata oluştu")
+	}
+
+	ids := make([]uint, 0, len(broadcasts))
+	for _, broadcast := range broadcasts {
+		ids = append(ids, broadcast.ID)
+	}
+	counts, err := s.repo.CountBroadcastStatuses(ids)
+	if err != nil {
+		logconfig.Log.Error("Duyuru teslim durumları alınamadı", zap.Uint("invitation_id", invitationID), zap.Error(err))
+		return nil, errors.New("duyuru teslim durumları getirilirken bir hata oluştu")
+	}
+
+	summaries := make([]BroadcastSummary, len(broadcasts))
+	index := make(map[uint]int, len(broadcasts))
+	for i, broadcast := range broadcasts {
+		summaries[i].Broadcast = broadcast
+		index[broadcast.ID] = i
+	}
+	for _, row := range counts {
+		summary := &summaries[index[row.BroadcastID]]
+		switch row.Status {
+		case models.OutboundMessageSent:
+			summary.Sent += row.Count
+		case models.OutboundMessageFailed:
+			summary.Failed += row.Count
+		default:
+			summary.Pending += row.Count
+		}
+	}
+	return summaries, nil
+}
+
+func (s *MessagingService) GetBroadcast(id uint) (*models.MessageBroadcast, error) {
+	broadcast, err := s.repo.GetBroadcastWithMessages(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, errors.New("duyuru bulunamadı")
+		}
+		logconfig.Log.Error("Duyuru alınamadı", zap.Uint("broadcast_id", id), zap.Error(err))
+		return nil, errors.New("duyuru getirilirken bir hata oluştu")
+	}
+	return broadcast, nil
+}
+
+func (s *MessagingService) GetQuota(userID uint) (*MessageQuota, error) {
+	used, err := s.repo.CountByUserSince(userID, startOfDay(time.Now()))
+	if err != nil {
+		logconfig.Log.Error("Mesaj kotası hesaplanamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("mesaj kotası hesaplanırken bir hata oluştu")
+	}
+	remaining := s.dailyQuota - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &MessageQuota{Limit: s.dailyQuota, Used: used, Remaining: remaining}, nil
+}
+
+// EnqueueMessages, mesajları kullanıcının günlük kotasına sığıyorsa kuyruğa alır. Kota yetmezse
+// *repositories.MessageQuotaError döner; hata mesajını çağıran kendi bağlamına göre oluşturur.
+func (s *MessagingService) EnqueueMessages(ctx context.Context, userID uint, messages []models.OutboundMessage) (int64, error) {
+	return s.repo.EnqueueMessagesWithinQuota(ctx, userID, messages, s.dailyQuota, startOfDay(time.Now()))
+}
+
+// CreateBroadcast, duyuruyu davetiyenin telefon numarası kayıtlı tüm katılımcıları için kuyruğa alır.
+// Aynı numaraya tek mesaj gönderilir. Alıcı sayısı kullanıcının kalan günlük kotasını aşarsa
+// duyuru hiç gönderilmez.
+func (s *MessagingService) CreateBroadcast(ctx context.Context, userID uint, invitation *models.Invitation, req requests.MessageBroadcastRequest) (*models.MessageBroadcast, error) {
+	channel := models.MessageChannel(req.Channel)
+	if _, ok := s.providers[channel]; !ok {
+		return nil, ErrChannelUnavailable
+	}
+
+	participants, err := s.participantRepo.GetParticipantsByInvitationID(invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Duyuru için katılımcılar alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("katılımcılar getirilirken bir hata oluştu")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if invitation.Telephone != "" {
+		body += "\nİletişim: " + invitation.Telephone
+	}
+
+	seen := make(map[string]bool)
+	invitationID := invitation.ID
+	messages := make([]models.OutboundMessage, 0, len(participants))
+	for _, participant := range participants {
+		if participant.PhoneNumber == "" || seen[participant.PhoneNumber] {
+			continue
+		}
+		seen[participant.PhoneNumber] = true
+		participantID := participant.ID
+		messages = append(messages, models.OutboundMessage{
+			Channel:       channel,
+			Recipient:     participant.PhoneNumber,
+			Body:          body,
+			Kind:          models.OutboundMessageKindAnnouncement,
+			Status:        models.OutboundMessagePending,
+			UserID:        &userID,
+			InvitationID:  &invitationID,
+			ParticipantID: &participantID,
+		})
+	}
+	if len(messages) == 0 {
+		return nil, ErrNoMessageRecipients
+	}
+
+	broadcast := &models.MessageBroadcast{
+		InvitationID:   invitation.ID,
+		UserID:         userID,
+		Channel:        channel,
+		Body:           body,
+		RecipientCount: len(messages),
+	}
+	// Duyuru kaydı oluşmadan ID bilinmediğinden tekilleştirme anahtarı zaman damgasıyla üretilir.
+	stamp := time.Now().UnixNano()
+	for i := range messages {
+		messages[i].DedupKey = fmt.Sprintf("announcement:%d:%d:%d", invitation.ID, stamp, *messages[i].ParticipantID)
+	}
+	if err := s.repo.CreateBroadcast(ctx, broadcast, messages, s.dailyQuota, startOfDay(time.Now())); err != nil {
+		var quotaErr *repositories.MessageQuotaError
+		if errors.As(err, &quotaErr) {
+			return nil, fmt.Errorf("%w: bugün en fazla %d mesaj daha gönderebilirsiniz, bu duyuru %d alıcıya gidecek",
+				ErrMessageQuotaExceeded, quotaErr.Remaining, len(messages))
+		}
+		logconfig.Log.Error("Duyuru kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("duyuru kaydedilirken bir hata oluştu")
+	}
+	return broadcast, nil
+}
+
+// DispatchPending, kuyruktaki mesajları kanallarının sağlayıcısıyla gönderir ve her alıcının
+// teslim durumunu günceller. Başarısız gönderimler messageMaxAttempts denemeye kadar tekrarlanır.
+// Zamanlayıcı tarafından periyodik olarak çağrılır.
+func (s *MessagingService) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := s.repo.ClaimPending(ctx, messageDispatchBatchSize, messageStaleAfter)
+	if err != nil {
+		return 0, fmt.Errorf("gönderilecek mesajlar alınamadı: %w", err)
+	}
+
+	sent := 0
+	for _, message := range messages {
+		provider, ok := s.providers[message.Channel]
+		if !ok {
+			if err := s.repo.MarkFailed(ctx, message.ID, "", ErrChannelUnavailable.Error(), true); err != nil {
+				logconfig.Log.Error("Mesaj durumu güncellenemedi", zap.Uint("message_id", message.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		result, err := provider.Send(ctx, messaging.Message{To: message.Recipient, Body: message.Body})
+		if err != nil {
+			final := message.Attempts+1 >= messageMaxAttempts
+			logconfig.Log.Warn("Mesaj gönderilemedi",
+				zap.Uint("message_id", message.ID),
+				zap.String("provider", provider.Name()),
+				zap.Bool("final", final),
+				zap.Error(err),
+			)
+			if err := s.repo.MarkFailed(ctx, message.ID, provider.Name(), err.Error(), final); err != nil {
+				logconfig.Log.Error("Mesaj durumu güncellenemedi", zap.Uint("message_id", message.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		if err := s.repo.MarkSent(ctx, message.ID, provider.Name(), result.ProviderMessageID, time.Now()); err != nil {
+			logconfig.Log.Error("Mesaj durumu güncellenemedi", zap.Uint("message_id", message.ID), zap.Error(err))
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+var _ IMessagingService = (*MessagingService)(nil)
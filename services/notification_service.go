@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+const notificationListLimit = 50
+
+// ParticipantEvent, davetiye sahibine bildirilecek bir katılımcı olayını tanımlar.
+type ParticipantEvent struct {
+	Type        models.NotificationType
+	Invitation  *models.Invitation
+	Participant models.InvitationParticipant
+}
+
+type INotificationService interface {
+	NotifyParticipantEvents(ctx context.Context, events ...ParticipantEvent)
+	GetNotifications(userID uint) ([]models.Notification, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(ctx context.Context, userID uint, id uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+	GetPreference(userID uint) (*models.NotificationPreference, error)
+	UpdatePreference(ctx context.Context, userID uint, req requests.NotificationPreferenceRequest) error
+	SendDailyDigests(ctx context.Context, now time.Time) error
+}
+
+type NotificationService struct {
+	repo        repositories.INotificationRepository
+	userRepo    repositories.IUserRepository
+	mailService IMailService
+}
+
+func NewNotificationService() INotificationService {
+	return &NotificationService{
+		repo:        repositories.NewNotificationRepository(),
+		userRepo:    repositories.NewUserRepository(),
+		mailService: NewMailService(),
+	}
+}
+
+// NotifyParticipantEvents, olayları davetiye sahibinin bildirim merkezine yazar ve anlık e-posta
+// tercih eden kullanıcılara e-postayı arka planda gönderir. Hatalar loglanır; katılım bildirimi
+// akışını kesmez.
+func (s *NotificationService) NotifyParticipantEvents(ctx context.Context, events ...ParticipantEvent) {
+	byUser := make(map[uint][]models.Notification)
+	for _, event := range events {
+		if event.Invitation == nil || event.Invitation.UserID == 0 {
+			continue
+		}
+		notification := buildParticipantNotification(event)
+		byUser[notification.UserID] = append(byUser[notification.UserID], notification)
+	}
+
+	for userID, notifications := range byUser {
+		if err := s.repo.CreateNotifications(ctx, notifications); err != nil {
+			logconfig.Log.Error("Bildirimler kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+			continue
+		}
+
+		preference, err := s.repo.GetPreferenceByUserID(userID)
+		if err != nil {
+			logconfig.Log.Error("Bildirim tercihleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+			continue
+		}
+		if !preference.EmailEnabled || preference.Frequency != models.NotificationInstant {
+			continue
+		}
+		go s.sendInstantEmail(userID, notifications)
+	}
+}
+
+func (s *NotificationService) sendInstantEmail(userID uint, notifications []models.Notification) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		logconfig.Log.Error("Bildirim e-postası için kullanıcı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+
+	subject := notifications[0].Title
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("%d yeni bildiriminiz var", len(notifications))
+	}
+	if err := s.mailService.SendMail(user.Email, subject, buildNotificationEmailBody(notifications)); err != nil {
+		logconfig.Log.Warn("Bildirim e-postası gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+
+	if err := s.repo.MarkEmailed(context.Background(), notificationIDs(notifications), time.Now()); err != nil {
+		logconfig.Log.Error("Bildirimler e-posta gönderildi olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+func (s *NotificationService) GetNotifications(userID uint) ([]models.Notification, error) {
+	notifications, err := s.repo.GetNotificationsByUserID(userID, notificationListLimit)
+	if err != nil {
+		logconfig.Log.Error("Bildirimler alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("bildirimler getirilirken bir hata oluştu")
+	}
+	return notifications, nil
+}
+
+func (s *NotificationService) CountUnread(userID uint) (int64, error) {
+	return s.repo.CountUnread(userID)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID uint, id uint) error {
+	if err := s.repo.MarkRead(ctx, userID, id, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return errors.New("bildirim bulunamadı")
+		}
+		logconfig.Log.Error("Bildirim okundu olarak işaretlenemedi", zap.Uint("notification_id", id), zap.Error(err))
+		return errors.New("bildirim güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint) error {
+	if err := s.repo.MarkAllRead(ctx, userID, time.Now()); err != nil {
+		logconfig.Log.Error("Bildirimler okundu olarak işaretlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return errors.New("bildirimler güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *NotificationService) GetPreference(userID uint) (*models.NotificationPreference, error) {
+	preference, err := s.repo.GetPreferenceByUserID(userID)
+	if err != nil {
+		logconfig.Log.Error("Bildirim tercihleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("bildirim tercihleri getirilirken bir hata oluştu")
+	}
+	return preference, nil
+}
+
+func (s *NotificationService) UpdatePreference(ctx context.Context, userID uint, req requests.NotificationPreferenceRequest) error {
+	preference := &models.NotificationPreference{
+		UserID:       userID,
+		EmailEnabled: req.EmailEnabled,
+		Frequency:    models.NotificationFrequency(req.Frequency),
+		DigestHour:   req.DigestHour,
+	}
+	if err := s.repo.SavePreference(ctx, preference); err != nil {
+		logconfig.Log.Error("Bildirim tercihleri kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return errors.New("bildirim tercihleri kaydedilirken bir hata oluştu")
+	}
+	return nil
+}
+
+// SendDailyDigests, günlük özet tercih eden ve özet saati gelmiş kullanıcılara o gün henüz
+// gönderilmemişse, e-posta ile iletilmemiş bildirimlerinin özetini gönderir.
+// Zamanlayıcı tarafından periyodik olarak çağrılır. Özet göndermeden önce sahiplenilir; birden
+// fazla uygulama örneği çalışsa da her kullanıcıya günde tek özet gider.
+func (s *NotificationService) SendDailyDigests(ctx context.Context, now time.Time) error {
+	preferences, err := s.repo.GetDigestPreferences()
+	if err != nil {
+		return fmt.Errorf("özet tercihleri alınamadı: %w", err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, preference := range preferences {
+		if now.Hour() < preference.DigestHour {
+			continue
+		}
+		if preference.LastDigestAt != nil && !preference.LastDigestAt.Before(today) {
+			continue
+		}
+		if preference.User == nil {
+			continue
+		}
+
+		claimed, err := s.repo.ClaimDigest(ctx, preference.UserID, today, now)
+		if err != nil {
+			logconfig.Log.Error("Özet gönderimi sahiplenilemedi", zap.Uint("user_id", preference.UserID), zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		since := now.Add(-24 * time.Hour)
+		if preference.LastDigestAt != nil && preference.LastDigestAt.After(since) {
+			since = *preference.LastDigestAt
+		}
+		notifications, err := s.repo.GetUnemailedNotifications(preference.UserID, since)
+		if err != nil {
+			logconfig.Log.Error("Özet için bildirimler alınamadı", zap.Uint("user_id", preference.UserID), zap.Error(err))
+			s.releaseDigest(ctx, preference, now)
+			continue
+		}
+		if len(notifications) == 0 {
+			continue
+		}
+
+		subject := fmt.Sprintf("Günlük özet: %d yeni bildirim", len(notifications))
+		if err := s.mailService.SendMail(preference.User.Email, subject, buildNotificationEmailBody(notifications)); err != nil {
+			logconfig.Log.Warn("Günlük özet e-postası gönderilemedi", zap.Uint("user_id", preference.UserID), zap.Error(err))
+			s.releaseDigest(ctx, preference, now)
+			continue
+		}
+		if err := s.repo.MarkEmailed(ctx, notificationIDs(notifications), now); err != nil {
+			logconfig.Log.Error("Bildirimler e-posta gönderildi olarak işaretlenemedi", zap.Uint("user_id", preference.UserID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// releaseDigest, gönderilemeyen özetin sahipliğini bırakır; özet zamanlayıcının bir sonraki çalışmasında yeniden denenir.
+func (s *NotificationService) releaseDigest(ctx context.Context, preference models.NotificationPreference, claimedAt time.Time) {
+	if err := s.repo.ReleaseDigest(ctx, preference.UserID, claimedAt, preference.LastDigestAt); err != nil {
+		logconfig.Log.Error("Özet gönderim zamanı geri alınamadı", zap.Uint("user_id", preference.UserID), zap.Error(err))
+	}
+}
+
+func buildParticipantNotification(event ParticipantEvent) models.Notification {
+	invitation := event.Invitation
+	participant := event.Participant
+	invitationTitle := invitation.Title
+	if invitationTitle == "" {
+		invitationTitle = invitation.InvitationKey
+	}
+
+	var title, body string
+	switch event.Type {
+	case models.NotificationRSVPCreated:
+		title = "Yeni katılım bildirimi: " + participant.Title
+		body = fmt.Sprintf("%s, \"%s\" davetiyenize yanıt verdi: %s", participant.Title, invitationTitle, participantStatusText(participant))
+	case models.NotificationRSVPCancelled:
+		title = "Katılım iptal edildi: " + participant.Title
+		body = fmt.Sprintf("%s, \"%s\" davetiyenize katılamayacağını bildirdi.", participant.Title, invitationTitle)
+	case models.NotificationRSVPPromoted:
+		title = "Yedek listeden katılımcı alındı: " + participant.Title
+		body = fmt.Sprintf("Açılan yerler nedeniyle %s (%d kişi), \"%s\" davetiyenizin katılımcı listesine alındı.", participant.Title, participant.GuestCount, invitationTitle)
+	default:
+		title = "Katılım bildirimi güncellendi: " + participant.Title
+		body = fmt.Sprintf("%s, \"%s\" davetiyesine verdiği yanıtı güncelledi: %s", participant.Title, invitationTitle, participantStatusText(participant))
+	}
+	if participant.Message != "" && event.Type != models.NotificationRSVPPromoted {
+		body += "\nMesaj: " + participant.Message
+	}
+
+	invitationID := invitation.ID
+	notification := models.Notification{
+		UserID:       invitation.UserID,
+		Type:         event.Type,
+		Title:        title,
+		Body:         body,
+		Link:         fmt.Sprintf("/panel/invitations/participants/%d", invitation.ID),
+		InvitationID: &invitationID,
+	}
+	if participant.ID != 0 {
+		participantID := participant.ID
+		notification.ParticipantID = &participantID
+	}
+	return notification
+}
+
+func participantStatusText(participant models.InvitationParticipant) string {
+	if participant.Status == models.RSVPNotAttending {
+		return participant.Status.Label()
+	}
+	return fmt.Sprintf("%s (%d kişi)", participant.Status.Label(), participant.GuestCount)
+}
+
+func buildNotificationEmailBody(notifications []models.Notification) string {
+	baseURL := SiteURL()
+	var b strings.Builder
+	for i, notification := range notifications {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString(notification.Title)
+		if notification.Body != "" {
+			b.WriteString("\n")
+			b.WriteString(notification.Body)
+		}
+		if notification.Link != "" {
+			b.WriteString("\n")
+			b.WriteString(baseURL + notification.Link)
+		}
+	}
+	b.WriteString("\n\nBildirim tercihlerinizi panelinizdeki Bildirimler sayfasından değiştirebilirsiniz.")
+	return b.String()
+}
+
+func notificationIDs(notifications []models.Notification) []uint {
+	ids := make([]uint, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.ID)
+	}
+	return ids
+}
+
+var _ INotificationService = (*NotificationService)(nil)
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/slugpolicy"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organizasyon bulunamadı")
+	ErrOrganizationForbidden = errors.New("bu işlem için organizasyon yöneticisi olmalısınız")
+)
+
+type IOrganizationService interface {
+	GetMemberships(userID uint) ([]models.OrganizationMember, error)
+	CreateOrganization(ctx context.Context, userID uint, req requests.OrganizationRequest, logo string) (*models.Organization, error)
+	GetOrganizationForUser(organizationID, userID uint) (*models.Organization, *models.OrganizationMember, error)
+	UpdateOrganization(ctx context.Context, organization *models.Organization, req requests.OrganizationRequest, logo string) error
+	AddMember(ctx context.Context, organization *models.Organization, req requests.OrganizationMemberRequest) (*models.OrganizationMember, error)
+	RemoveMember(ctx context.Context, organization *models.Organization, userID uint) error
+	GetOrganizationCards(organizationID uint) ([]models.Card, error)
+	CreateMemberCard(ctx context.Context, organization *models.Organization, req requests.OrganizationCardRequest) (*models.Card, error)
+}
+
+type OrganizationService struct {
+	repo            repositories.IOrganizationRepository
+	cardRepo        repositories.ICardRepository
+	bankRepo        repositories.IBankRepository
+	socialMediaRepo repositories.ISocialMediaRepository
+}
+
+func NewOrganizationService() IOrganizationService {
+	return &OrganizationService{
+		repo:            repositories.NewOrganizationRepository(),
+		cardRepo:        repositories.NewCardRepository(),
+		bankRepo:        repositories.NewBankRepository(),
+		socialMediaRepo: repositories.NewSocialMediaRepository(),
+	}
+}
+
+func (s *OrganizationService) GetMemberships(userID uint) ([]models.OrganizationMember, error) {
+	members, err := s.repo.GetMembershipsByUserID(userID)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcının organizasyonları alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("organizasyonlar getirilirken bir hata oluştu")
+	}
+	return members, nil
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, userID uint, req requests.OrganizationRequest, logo string) (*models.Organization, error) {
+	if err := resolveIBANBanks(s.bankRepo, req.Banks); err != nil {
+		return nil, err
+	}
+	if err := normalizeProfileURLs(s.socialMediaRepo, req.SocialMedia); err != nil {
+		return nil, err
+	}
+	organization := &models.Organization{Logo: logo}
+	applyOrganizationRequest(organization, req)
+
+	if err := s.repo.CreateOrganization(ctx, organization, userID); err != nil {
+		logconfig.Log.Error("Organizasyon oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, errors.New("organizasyon oluşturulurken bir hata oluştu")
+	}
+	return organization, nil
+}
+
+// GetOrganizationForUser, organizasyonu kullanıcının üyeliğiyle birlikte döner. Kullanıcı üye
+// değilse organizasyon yokmuş gibi ErrOrganizationNotFound döner.
+func (s *OrganizationService) GetOrganizationForUser(organizationID, userID uint) (*models.Organization, *models.OrganizationMember, error) {
+	member, err := s.repo.GetMembership(organizationID, userID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Organizasyon üyeliği alınamadı", zap.Uint("organization_id", organizationID), zap.Error(err))
+		}
+		return nil, nil, ErrOrganizationNotFound
+	}
+	organization, err := s.repo.GetOrganizationByID(organizationID)
+	if err != nil {
+		logconfig.Log.Warn("Organizasyon bulunamadı", zap.Uint("organization_id", organizationID), zap.Error(err))
+		return nil, nil, ErrOrganizationNotFound
+	}
+	return organization, member, nil
+}
+
+// UpdateOrganization, organizasyon bilgilerini günceller. logo boşsa mevcut logo korunur.
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, organization *models.Organization, req requests.OrganizationRequest, logo string) error {
+	if err := resolveIBANBanks(s.bankRepo, req.Banks); err != nil {
+		return err
+	}
+	if err := normalizeProfileURLs(s.socialMediaRepo, req.SocialMedia); err != nil {
+		return err
+	}
+	applyOrganizationRequest(organization, req)
+	if logo != "" {
+		organization.Logo = logo
+	}
+	if err := s.repo.UpdateOrganizationWithRelations(ctx, organization); err != nil {
+		logconfig.Log.Error("Organizasyon güncellenemedi", zap.Uint("organization_id", organization.ID), zap.Error(err))
+		return errors.New("organizasyon güncellenirken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *OrganizationService) AddMember(ctx context.Context, organization *models.Organization, req requests.OrganizationMemberRequest) (*models.OrganizationMember, error) {
+	user, err := s.repo.GetPanelUserByEmail(req.Email)
+	if err != nil {
+		return nil, errors.New("bu e-posta adresiyle kayıtlı bir kullanıcı bulunamadı")
+	}
+	if _, err := s.repo.GetMembership(organization.ID, user.ID); err == nil {
+		return nil, errors.New("kullanıcı zaten bu organizasyonun üyesi")
+	}
+
+	member := &models.OrganizationMember{
+		OrganizationID: organization.ID,
+		UserID:         user.ID,
+		Role:           models.OrganizationRole(req.Role),
+	}
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		logconfig.Log.Error("Organizasyona üye eklenemedi", zap.Uint("organization_id", organization.ID), zap.Error(err))
+		return nil, errors.New("üye eklenirken bir hata oluştu")
+	}
+	member.User = user
+	return member, nil
+}
+
+// RemoveMember, üyeyi organizasyondan çıkarır. Organizasyonun son yöneticisi çıkarılamaz.
+// Üyenin organizasyon kartları silinmez.
+func (s *OrganizationService) RemoveMember(ctx context.Context, organization *models.Organization, userID uint) error {
+	member, err := s.repo.GetMembership(organization.ID, userID)
+	if err != nil {
+		return errors.New("üye bulunamadı")
+	}
+	if member.IsAdmin() {
+		admins, err := s.repo.CountAdmins(organization.ID)
+		if err != nil {
+			logconfig.Log.Error("Yönetici sayısı alınamadı", zap.Uint("organization_id", organization.ID), zap.Error(err))
+			return errors.New("üye çıkarılırken bir hata oluştu")
+		}
+		if admins <= 1 {
+			return errors.New("organizasyonun son yöneticisi çıkarılamaz")
+		}
+	}
+	if err := s.repo.RemoveMember(ctx, organization.ID, userID); err != nil {
+		logconfig.Log.Error("Organizasyondan üye çıkarılamadı", zap.Uint("organization_id", organization.ID), zap.Error(err))
+		return errors.New("üye çıkarılırken bir hata oluştu")
+	}
+	return nil
+}
+
+func (s *OrganizationService) GetOrganizationCards(organizationID uint) ([]models.Card, error) {
+	cards, err := s.repo.GetCardsByOrganizationID(organizationID)
+	if err != nil {
+		logconfig.Log.Error("Organizasyon kartları alınamadı", zap.Uint("organization_id", organizationID), zap.Error(err))
+		return nil, errors.New("kartlar getirilirken bir hata oluştu")
+	}
+	return cards, nil
+}
+
+// CreateMemberCard, organizasyon üyesi adına organizasyona bağlı bir kart oluşturur. Kartın
+// sahibi üyedir; organizasyon yöneticileri de kartı düzenleyebilir.
+func (s *OrganizationService) CreateMemberCard(ctx context.Context, organization *models.Organization, req requests.OrganizationCardRequest) (*models.Card, error) {
+	if _, err := s.repo.GetMembership(organization.ID, req.UserID); err != nil {
+		return nil, errors.New("seçilen kullanıcı bu organizasyonun üyesi değil")
+	}
+	if err := slugpolicy.Default.Check(req.Slug); err != nil {
+		return nil, err
+	}
+	available, err := s.cardRepo.IsSlugAvailable(req.Slug, 0)
+	if err != nil {
+		logconfig.Log.Error("Kart adresi kontrol edilemedi", zap.String("slug", req.Slug), zap.Error(err))
+		return nil, errors.New("kart oluşturulurken bir hata oluştu")
+	}
+	if !available {
+		return nil, errors.New("bu kart adresi zaten kullanılıyor")
+	}
+
+	organizationID := organization.ID
+	card := &models.Card{
+		IsActive:       true,
+		UserID:         req.UserID,
+		Slug:           req.Slug,
+		Name:           req.Name,
+		Title:          req.Title,
+		Telephone:      req.Telephone,
+		Email:          req.Email,
+		WebsiteUrl:     organization.WebsiteUrl,
+		OrganizationID: &organizationID,
+	}
+	if err := s.cardRepo.CreateCardWithRelations(ctx, card); err != nil {
+		logconfig.Log.Error("Organizasyon kartı oluşturulamadı", zap.Uint("organization_id", organization.ID), zap.Error(err))
+		return nil, errors.New("kart oluşturulurken bir hata oluştu")
+	}
+	return card, nil
+}
+
+func applyOrganizationRequest(organization *models.Organization, req requests.OrganizationRequest) {
+	organization.Name = req.Name
+	organization.BrandColor = req.BrandColor
+	organization.WebsiteUrl = req.WebsiteUrl
+
+	organization.Banks = []models.OrganizationBank{}
+	for _, bank := range req.Banks {
+		organization.Banks = append(organization.Banks, models.OrganizationBank{
+			BaseModel:      models.BaseModel{ID: bank.ID},
+			OrganizationID: organization.ID,
+			BankID:         bank.BankID,
+			IBAN:           bank.IBAN,
+		})
+	}
+	organization.SocialMedia = []models.OrganizationSocialMedia{}
+	for _, sm := range req.SocialMedia {
+		organization.SocialMedia = append(organization.SocialMedia, models.OrganizationSocialMedia{
+			BaseModel:      models.BaseModel{ID: sm.ID},
+			OrganizationID: organization.ID,
+			SocialMediaID:  sm.SocialMediaID,
+			URL:            sm.URL,
+		})
+	}
+}
+
+var _ IOrganizationService = (*OrganizationService)(nil)
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/queryparams"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+var ErrPageSlugTaken = errors.New("bu sayfa adresi zaten kullanılıyor")
+
+type IPageService interface {
+	GetAllPages(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetPageByID(id uint) (*models.Page, error)
+	GetPublishedPageBySlug(slug string) (*models.Page, error)
+	CreatePage(ctx context.Context, page *models.Page) error
+	UpdatePage(ctx context.Context, id uint, pageData *models.Page, updatedBy uint) error
+	DeletePage(ctx context.Context, id uint) error
+}
+
+type PageService struct {
+	repo repositories.IPageRepository
+}
+
+func NewPageService() IPageService {
+	return &PageService{repo: repositories.NewPageRepository()}
+}
+
+func (s *PageService) GetAllPages(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	pages, totalCount, err := s.repo.GetAllPages(params)
+	if err != nil {
+		logconfig.Log.Error("Sayfalar alınamadı", zap.Error(err))
+		return nil, errors.New("sayfalar getirilirken bir hata oluştu")
+	}
+	result := &queryparams.PaginatedResult{
+		Data: pages,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}
+	return result, nil
+}
+
+func (s *PageService) GetPageByID(id uint) (*models.Page, error) {
+	page, err := s.repo.GetPageByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Sayfa bulunamadı", zap.Uint("page_id", id), zap.Error(err))
+		return nil, errors.New("sayfa bulunamadı")
+	}
+	return page, nil
+}
+
+// GetPublishedPageBySlug, yalnızca yayında olan sayfayı döner; taslaklar ziyaretçiye
+// bulunamadı olarak görünür.
+func (s *PageService) GetPublishedPageBySlug(slug string) (*models.Page, error) {
+	page, err := s.repo.GetPublishedPageBySlug(slug)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			logconfig.Log.Error("Sayfa alınamadı", zap.String("slug", slug), zap.Error(err))
+		}
+		return nil, errors.New("sayfa bulunamadı")
+	}
+	return page, nil
+}
+
+func (s *PageService) CreatePage(ctx context.Context, page *models.Page) error {
+	if err := s.ensureSlugAvailable(page.Slug, 0); err != nil {
+		return err
+	}
+	return s.repo.CreatePage(ctx, page)
+}
+
+func (s *PageService) UpdatePage(ctx context.Context, id uint, pageData *models.Page, updatedBy uint) error {
+	if _, err := s.repo.GetPageByID(id); err != nil {
+		return errors.New("sayfa bulunamadı")
+	}
+	if err := s.ensureSlugAvailable(pageData.Slug, id); err != nil {
+		return err
+	}
+	updateData := map[string]interface{}{
+		"title":            pageData.Title,
+		"slug":             pageData.Slug,
+		"body":             pageData.Body,
+		"meta_title":       pageData.MetaTitle,
+		"meta_description": pageData.MetaDescription,
+		"is_published":     pageData.IsPublished,
+	}
+	return s.repo.UpdatePage(ctx, id, updateData, updatedBy)
+}
+
+func (s *PageService) DeletePage(ctx context.Context, id uint) error {
+	return s.repo.DeletePage(ctx, id)
+}
+
+func (s *PageService) ensureSlugAvailable(slug string, excludeID uint) error {
+	available, err := s.repo.IsSlugAvailable(slug, excludeID)
+	if err != nil {
+		logconfig.Log.Error("Sayfa adresi kontrol edilemedi", zap.String("slug", slug), zap.Error(err))
+		return errors.New("sayfa adresi kontrol edilirken bir hata oluştu")
+	}
+	if !available {
+		return ErrPageSlugTaken
+	}
+	return nil
+}
+
+var _ IPageService = (*PageService)(nil)
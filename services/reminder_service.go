@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/ics"
+	"davet.link/pkg/reminderoffset"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+type IReminderService interface {
+	QueueDueReminders(ctx context.Context, now time.Time) (int64, error)
+}
+
+type ReminderService struct {
+	repo      repositories.IReminderRepository
+	messaging IMessagingService
+}
+
+func NewReminderService() IReminderService {
+	return &ReminderService{
+		repo:      repositories.NewReminderRepository(),
+		messaging: NewMessagingService(),
+	}
+}
+
+// reminderPart, hatırlatması ayrı gönderilen bir programı temsil eder. Programı olmayan davetiyede
+// davetiyenin kendisi, aksi halde her program bölümü ayrı bir parçadır.
+type reminderPart struct {
+	event    *models.InvitationEvent
+	title    string
+	startsAt time.Time
+	hasTime  bool
+	venue    models.Venue
+}
+
+// reminderParts, davetiyenin hatırlatma gönderilecek parçalarını döner. Mekanı girilmemiş bölümde
+// davetiyenin mekanı kullanılır.
+func reminderParts(invitation *models.Invitation) []reminderPart {
+	title := invitation.Title
+	if title == "" {
+		title = "Davetiye"
+	}
+	if len(invitation.Events) == 0 {
+		startsAt, hasTime := invitation.StartsAt()
+		return []reminderPart{{title: title, startsAt: startsAt, hasTime: hasTime, venue: invitation.Venue}}
+	}
+
+	parts := make([]reminderPart, 0, len(invitation.Events))
+	for i := range invitation.Events {
+		event := &invitation.Events[i]
+		venue := event.Venue
+		if venue.IsZero() {
+			venue = invitation.Venue
+		}
+		parts = append(parts, reminderPart{
+			event:    event,
+			title:    title + " - " + event.Title,
+			startsAt: event.StartsAt,
+			hasTime:  true,
+			venue:    venue,
+		})
+	}
+	return parts
+}
+
+// dedupKey, katılımcıya bu parça için verilen hatırlatma zamanında tek mesaj gönderilmesini sağlar.
+func (p reminderPart) dedupKey(participantID uint, offset time.Duration) string {
+	if p.event == nil {
+		return fmt.Sprintf("reminder:%d:%d", participantID, int64(offset/time.Minute))
+	}
+	return fmt.Sprintf("reminder:%d:%d:%d", participantID, p.event.ID, int64(offset/time.Minute))
+}
+
+// attends, katılımcının bu parçaya katılacağını bildirip bildirmediğini döner. Bölüme ayrıca
+// katılım bildirimi isteniyorsa yalnızca katılacağını bildirenlere hatırlatma gönderilir.
+func (p reminderPart) attends(participant models.InvitationParticipant) bool {
+	if p.event == nil || !p.event.IsRSVP {
+		return true
+	}
+	for _, response := range participant.EventResponses {
+		if response.EventID == p.event.ID {
+			return response.IsAttending
+		}
+	}
+	return false
+}
+
+// QueueDueReminders, zamanı gelmiş hatırlatmaları katılacağını bildiren misafirler için kuyruğa alır.
+// Programı olan davetiyede her bölüm için hatırlatma, o bölümün saati ve mekanıyla ayrı gönderilir.
+// Bir parçada birden fazla hatırlatmanın zamanı geçmişse yalnızca etkinliğe en yakın olanı
+// gönderilir; böylece geç oluşturulan davetiyelerde misafirlere art arda mesaj gitmez.
+// Her katılımcıya her parça ve hatırlatma zamanı için en fazla bir mesaj gönderilir. Mesajlar açık
+// olan ilk kanaldan gönderilir; hiçbir kanal açık değilse hatırlatma kuyruğa alınmaz. Hatırlatmalar
+// davetiye sahibinin günlük mesaj kotasından düşülür; kota yetmezse o davetiyenin hatırlatmaları atlanır.
+func (s *ReminderService) QueueDueReminders(ctx context.Context, now time.Time) (int64, error) {
+	channels := s.messaging.AvailableChannels()
+	if len(channels) == 0 {
+		return 0, nil
+	}
+	channel := channels[0]
+
+	from := now.AddDate(0, 0, -1)
+	to := now.Add(reminderoffset.MaxOffset).AddDate(0, 0, 1)
+	invitations, err := s.repo.GetInvitationsWithReminders(from, to)
+	if err != nil {
+		return 0, fmt.Errorf("hatırlatma yapılacak davetiyeler alınamadı: %w", err)
+	}
+
+	var queued int64
+	for i := range invitations {
+		invitation := &invitations[i]
+		offsets, err := reminderoffset.Parse(invitation.ReminderOffsets)
+		if err != nil {
+			logconfig.Log.Warn("Davetiyenin hatırlatma zamanları okunamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+			continue
+		}
+
+		type duePart struct {
+			reminderPart
+			offset time.Duration
+		}
+		var dueParts []duePart
+		for _, part := range reminderParts(invitation) {
+			if offset, ok := dueReminderOffset(offsets, part.startsAt, now); ok {
+				dueParts = append(dueParts, duePart{reminderPart: part, offset: offset})
+			}
+		}
+		if len(dueParts) == 0 {
+			continue
+		}
+
+		participants, err := s.repo.GetAttendingParticipants(invitation.ID)
+		if err != nil {
+			logconfig.Log.Error("Hatırlatma için katılımcılar alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+			continue
+		}
+		if len(participants) == 0 {
+			continue
+		}
+
+		invitationID := invitation.ID
+		userID := invitation.UserID
+		var messages []models.OutboundMessage
+		for _, part := range dueParts {
+			body := buildReminderBody(invitation, part.reminderPart)
+			for _, participant := range participants {
+				if !part.attends(participant) {
+					continue
+				}
+				participantID := participant.ID
+				messages = append(messages, models.OutboundMessage{
+					Channel:       channel,
+					Recipient:     participant.PhoneNumber,
+					Body:          body,
+					Kind:          models.OutboundMessageKindReminder,
+					Status:        models.OutboundMessagePending,
+					UserID:        &userID,
+					DedupKey:      part.dedupKey(participant.ID, part.offset),
+					InvitationID:  &invitationID,
+					ParticipantID: &participantID,
+				})
+			}
+		}
+		if len(messages) == 0 {
+			continue
+		}
+
+		count, err := s.messaging.EnqueueMessages(ctx, invitation.UserID, messages)
+		if err != nil {
+			var quotaErr *repositories.MessageQuotaError
+			if errors.As(err, &quotaErr) {
+				logconfig.Log.Warn("Mesaj kotası yetmediği için hatırlatmalar kuyruğa alınmadı",
+					zap.Uint("invitation_id", invitation.ID),
+					zap.Int64("remaining", quotaErr.Remaining),
+					zap.Int("count", len(messages)),
+				)
+				continue
+			}
+			logconfig.Log.Error("Hatırlatma mesajları kuyruğa alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+			continue
+		}
+		if count > 0 {
+			logconfig.Log.Info("Hatırlatma mesajları kuyruğa alındı",
+				zap.Uint("invitation_id", invitation.ID),
+				zap.Int("parts", len(dueParts)),
+				zap.Int64("count", count),
+			)
+		}
+		queued += count
+	}
+	return queued, nil
+}
+
+// dueReminderOffset, startsAt zamanı için zamanı gelmiş hatırlatmalardan etkinliğe en yakın olanını
+// döner. Etkinlik başlamışsa hatırlatma gönderilmez.
+func dueReminderOffset(offsets []time.Duration, startsAt time.Time, now time.Time) (time.Duration, bool) {
+	if !now.Before(startsAt) {
+		return 0, false
+	}
+
+	due := time.Duration(-1)
+	for _, offset := range offsets {
+		if !now.Before(startsAt.Add(-offset)) {
+			due = offset
+		}
+	}
+	return due, due >= 0
+}
+
+func buildReminderBody(invitation *models.Invitation, part reminderPart) string {
+	when := part.startsAt.Format("02.01.2006")
+	if part.hasTime {
+		when = part.startsAt.Format("02.01.2006 15:04")
+	}
+
+	lines := []string{fmt.Sprintf("Hatırlatma: %s - %s", part.title, when)}
+	venue := strings.Join(nonEmpty(part.venue.Name, part.venue.AddressText()), ", ")
+	if venue != "" {
+		lines = append(lines, "Mekan: "+venue)
+	}
+	if shareURL := part.venue.ShareURL(); shareURL != "" {
+		lines = append(lines, "Konum: "+shareURL)
+	}
+	lines = append(lines, "Takvime ekle: "+InvitationCalendarURL(invitation))
+	return strings.Join(lines, "\n")
+}
+
+// InvitationCalendarURL, davetiyenin takvim (ICS) dosyasının tam adresini döner.
+func InvitationCalendarURL(invitation *models.Invitation) string {
+	return SiteURL() + "/" + invitation.InvitationKey + "/calendar.ics"
+}
+
+// InvitationCalendarEvents, davetiyeyi takvim uygulamalarına eklenebilecek etkinliklere çevirir.
+// Programı olan davetiyede her bölüm ayrı bir etkinliktir; aksi halde davetiyenin tarihi
+// kullanılır. Tarihi de girilmemiş davetiye için boş döner.
+func InvitationCalendarEvents(invitation *models.Invitation) []ics.Event {
+	if len(invitation.Events) == 0 {
+		if invitation.Date.IsZero() {
+			return nil
+		}
+		return []ics.Event{invitationCalendarEvent(invitation)}
+	}
+
+	events := make([]ics.Event, 0, len(invitation.Events))
+	for _, event := range invitation.Events {
+		summary := event.Title
+		if invitation.Title != "" {
+			summary = invitation.Title + " - " + event.Title
+		}
+		description := ""
+		if shareURL := event.Venue.ShareURL(); shareURL != "" {
+			description = "Konum: " + shareURL
+		}
+		calendarEvent := ics.Event{
+			UID:         fmt.Sprintf("%s-%d@davet.link", invitation.InvitationKey, event.ID),
+			Start:       event.StartsAt,
+			Summary:     summary,
+			Description: description,
+			Location:    event.Venue.Label(),
+			Latitude:    event.Venue.Latitude,
+			Longitude:   event.Venue.Longitude,
+			URL:         SiteURL() + "/" + invitation.InvitationKey,
+		}
+		if event.EndsAt != nil {
+			calendarEvent.End = *event.EndsAt
+		}
+		events = append(events, calendarEvent)
+	}
+	return events
+}
+
+func invitationCalendarEvent(invitation *models.Invitation) ics.Event {
+	startsAt, hasTime := invitation.StartsAt()
+	description := invitation.Description
+	if shareURL := invitation.Venue.ShareURL(); shareURL != "" {
+		description = strings.TrimSpace(description + "\n\nKonum: " + shareURL)
+	}
+	return ics.Event{
+		UID:         invitation.InvitationKey + "@davet.link",
+		Start:       startsAt,
+		AllDay:      !hasTime,
+		Summary:     invitation.Title,
+		Description: description,
+		Location:    invitation.Venue.Label(),
+		Latitude:    invitation.Venue.Latitude,
+		Longitude:   invitation.Venue.Longitude,
+		URL:         SiteURL() + "/" + invitation.InvitationKey,
+	}
+}
+
+func nonEmpty(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, strings.TrimSpace(value))
+		}
+	}
+	return result
+}
+
+var _ IReminderService = (*ReminderService)(nil)
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/phonenumber"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRSVPClosed       = errors.New("bu davetiye için katılım bildirimi kapalı")
+	ErrRSVPInvalidPhone = errors.New("telefon numarası geçerli bir Türkiye numarası değil")
+	ErrRSVPGuestCount   = errors.New("katılacak kişi sayısı en az 1 olmalıdır")
+	ErrRSVPDeadline     = errors.New("bu davetiye için katılım bildirimi süresi doldu")
+	ErrRSVPPhoneTaken   = errors.New("bu telefon numarasıyla daha önce katılım bildirilmiş; yanıtınızı değiştirmek için kişiye özel bağlantınızı ya da bildirimi yaptığınız tarayıcıyı kullanın veya davet sahibiyle iletişime geçin")
+)
+
+// RSVPResult, kaydedilen katılım bildiriminin sonucunu taşır.
+type RSVPResult struct {
+	// Waitlisted, kapasite dolduğu için bildirimin yedek listeye alındığını belirtir.
+	Waitlisted bool
+	// Promoted, bu bildirimle boşalan yerlere yedek listeden alınan katılımcılardır.
+	Promoted []models.InvitationParticipant
+	// TableName, katılımcı oturma planında bir masaya yerleştirilmişse masanın adıdır.
+	TableName string
+	// EditToken, genel bağlantıdan yanıt verenin yanıtını sonradan değiştirebilmesi için oturumunda
+	// saklanacak anahtardır; kişiye özel bağlantıdan gelen yanıtlarda boştur.
+	EditToken string
+}
+
+type IRSVPService interface {
+	SubmitRSVP(ctx context.Context, invitation *models.Invitation, req requests.RSVPRequest, editToken string) (*RSVPResult, error)
+	GetEditableParticipant(invitationID uint, editToken string) (*models.InvitationParticipant, error)
+	SubmitGuestRSVP(ctx context.Context, guest *models.InvitationGuest, req requests.RSVPRequest) (*RSVPResult, error)
+}
+
+type RSVPService struct {
+	repo                repositories.IRSVPRepository
+	questionRepo        repositories.IInvitationQuestionRepository
+	eventRepo           repositories.IInvitationEventRepository
+	tableRepo           repositories.IInvitationTableRepository
+	notificationService INotificationService
+}
+
+func NewRSVPService() IRSVPService {
+	return &RSVPService{
+		repo:                repositories.NewRSVPRepository(),
+		questionRepo:        repositories.NewInvitationQuestionRepository(),
+		eventRepo:           repositories.NewInvitationEventRepository(),
+		tableRepo:           repositories.NewInvitationTableRepository(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+// SubmitRSVP, davetiyenin genel bağlantısından gelen katılım bildirimini kaydeder. editToken,
+// misafirin daha önceki bildiriminden oturumunda kalan anahtardır; eşleşen kayıt güncellenir, yoksa
+// yeni kayıt oluşturulur ve yeni anahtar sonuçla döner. Telefon numarası başka bir katılımcıya
+// aitse bildirim reddedilir ve mevcut yanıt değiştirilmez.
+func (s *RSVPService) SubmitRSVP(ctx context.Context, invitation *models.Invitation, req requests.RSVPRequest, editToken string) (*RSVPResult, error) {
+	return s.submit(ctx, invitation, req, nil, editToken)
+}
+
+// GetEditableParticipant, genel bağlantıdan yanıt veren misafirin kaydını oturumundaki anahtarla döner.
+func (s *RSVPService) GetEditableParticipant(invitationID uint, editToken string) (*models.InvitationParticipant, error) {
+	if editToken == "" {
+		return nil, repositories.ErrNotFound
+	}
+	return s.repo.GetParticipantByEditToken(invitationID, editToken)
+}
+
+// SubmitGuestRSVP, kişiye özel bağlantıdan gelen yanıtı misafir kaydına işler ve
+// kişi sayısını misafire ayrılan koltuk sayısıyla sınırlar.
+func (s *RSVPService) SubmitGuestRSVP(ctx context.Context, guest *models.InvitationGuest, req requests.RSVPRequest) (*RSVPResult, error) {
+	if req.Status != string(models.RSVPNotAttending) && req.GuestCount > guest.SeatLimit {
+		return nil, fmt.Errorf("bu davet için en fazla %d kişilik yer ayrılmıştır", guest.SeatLimit)
+	}
+	return s.submit(ctx, &guest.Invitation, req, guest, "")
+}
+
+func (s *RSVPService) submit(ctx context.Context, invitation *models.Invitation, req requests.RSVPRequest, guest *models.InvitationGuest, editToken string) (*RSVPResult, error) {
+	if !invitation.IsParticipant {
+		return nil, ErrRSVPClosed
+	}
+	if invitation.IsRSVPClosed() {
+		return nil, ErrRSVPDeadline
+	}
+	participant, err := buildRSVPParticipant(invitation.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.MaxGuestsPerParticipant > 0 && participant.GuestCount > invitation.MaxGuestsPerParticipant {
+		return nil, fmt.Errorf("bir katılım bildiriminde en fazla %d kişi bildirilebilir", invitation.MaxGuestsPerParticipant)
+	}
+
+	questions, err := s.questionRepo.GetQuestionsByInvitationID(invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Katılım formu soruları alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("katılım bildiriminiz kaydedilirken bir hata oluştu")
+	}
+	answers, err := buildRSVPAnswers(questions, req.Answers, participant.Status)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.eventRepo.GetEventsByInvitationID(invitation.ID)
+	if err != nil {
+		logconfig.Log.Error("Davetiye programı alınamadı", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("katılım bildiriminiz kaydedilirken bir hata oluştu")
+	}
+	responses := buildRSVPEventResponses(events, req.Events, participant.Status)
+
+	if guest == nil {
+		// Mevcut kayıt güncellenirse kaydın anahtarı korunur; bu değer yalnızca yeni kayıtta kullanılır.
+		token := generateToken()
+		participant.EditToken = &token
+	}
+	outcome, err := s.repo.SaveRSVP(ctx, participant, answers, responses, guest, editToken)
+	if err != nil {
+		var seatsErr *repositories.NotEnoughSeatsError
+		switch {
+		case errors.Is(err, repositories.ErrRSVPDeadlinePassed):
+			return nil, ErrRSVPDeadline
+		case errors.Is(err, repositories.ErrRSVPPhoneRegistered):
+			return nil, ErrRSVPPhoneTaken
+		case errors.As(err, &seatsErr):
+			return nil, fmt.Errorf("kapasite dolmak üzere; katılım bildiriminizi en fazla %d kişi olarak güncelleyebilirsiniz", seatsErr.Available)
+		}
+		logconfig.Log.Error("Katılım bildirimi kaydedilemedi", zap.Uint("invitation_id", invitation.ID), zap.Error(err))
+		return nil, errors.New("katılım bildiriminiz kaydedilirken bir hata oluştu")
+	}
+	if len(outcome.Promoted) > 0 {
+		logconfig.Log.Info("Yedek listeden katılımcı alındı",
+			zap.Uint("invitation_id", invitation.ID),
+			zap.Int("promoted", len(outcome.Promoted)),
+		)
+	}
+	s.notificationService.NotifyParticipantEvents(ctx, rsvpEvents(invitation, participant, outcome)...)
+
+	result := &RSVPResult{Waitlisted: outcome.Waitlisted, Promoted: outcome.Promoted}
+	if participant.EditToken != nil {
+		result.EditToken = *participant.EditToken
+	}
+	if participant.Status == models.RSVPAttending {
+		if table, err := s.tableRepo.GetParticipantTable(participant.ID); err == nil {
+			result.TableName = table.Name
+		}
+	}
+	return result, nil
+}
+
+// rsvpEvents, kaydedilen bildirimin davetiye sahibine iletilecek olaylarını belirler.
+func rsvpEvents(invitation *models.Invitation, participant *models.InvitationParticipant, outcome *repositories.RSVPOutcome) []ParticipantEvent {
+	eventType := models.NotificationRSVPUpdated
+	switch {
+	case outcome.Previous == nil:
+		eventType = models.NotificationRSVPCreated
+	case participant.Status == models.RSVPNotAttending && outcome.Previous.Status != models.RSVPNotAttending:
+		eventType = models.NotificationRSVPCancelled
+	}
+	events := []ParticipantEvent{{Type: eventType, Invitation: invitation, Participant: *participant}}
+	for _, promoted := range outcome.Promoted {
+		events = append(events, ParticipantEvent{Type: models.NotificationRSVPPromoted, Invitation: invitation, Participant: promoted})
+	}
+	return events
+}
+
+func buildRSVPParticipant(invitationID uint, req requests.RSVPRequest) (*models.InvitationParticipant, error) {
+	phone, err := phonenumber.NormalizeTR(req.PhoneNumber)
+	if err != nil {
+		return nil, ErrRSVPInvalidPhone
+	}
+	participant := &models.InvitationParticipant{
+		InvitationID: invitationID,
+		Title:        req.Title,
+		PhoneNumber:  phone,
+		GuestCount:   req.GuestCount,
+		Status:       models.RSVPStatus(req.Status),
+		Message:      req.Message,
+	}
+	if participant.Status == models.RSVPNotAttending {
+		participant.GuestCount = 0
+	} else if participant.GuestCount < 1 {
+		return nil, ErrRSVPGuestCount
+	}
+	return participant, nil
+}
+
+// buildRSVPAnswers, ham form yanıtlarını soru tiplerine göre doğrular ve tipli yanıt kayıtlarına çevirir.
+// Katılmayacağını bildiren misafir için zorunlu sorular aranmaz.
+func buildRSVPAnswers(questions []models.InvitationQuestion, raw map[uint]string, status models.RSVPStatus) ([]models.InvitationAnswer, error) {
+	answers := make([]models.InvitationAnswer, 0, len(questions))
+	for _, question := range questions {
+		value := strings.TrimSpace(raw[question.ID])
+		if value == "" {
+			if question.IsRequired && status != models.RSVPNotAttending {
+				return nil, fmt.Errorf("\"%s\" sorusunu yanıtlamanız gerekiyor", question.Label)
+			}
+			continue
+		}
+
+		answer := models.InvitationAnswer{QuestionID: question.ID}
+		switch question.Type {
+		case models.QuestionTypeChoice:
+			if !containsString(question.OptionList(), value) {
+				return nil, fmt.Errorf("\"%s\" sorusu için geçersiz seçim", question.Label)
+			}
+			answer.ChoiceValue = value
+		case models.QuestionTypeBoolean:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("\"%s\" sorusu için Evet veya Hayır seçin", question.Label)
+			}
+			answer.BoolValue = &b
+		case models.QuestionTypeNumber:
+			n, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+			if err != nil {
+				return nil, fmt.Errorf("\"%s\" sorusunun yanıtı sayı olmalıdır", question.Label)
+			}
+			answer.NumberValue = &n
+		default:
+			if len([]rune(value)) > 1000 {
+				return nil, fmt.Errorf("\"%s\" sorusunun yanıtı en fazla 1000 karakter olabilir", question.Label)
+			}
+			answer.TextValue = value
+		}
+		answers = append(answers, answer)
+	}
+	return answers, nil
+}
+
+// buildRSVPEventResponses, katılım bildirimi açık her program bölümü için yanıt oluşturur.
+// İşaretlenmeyen bölümler ve katılmayacağını bildiren misafirin tüm bölümleri "katılmayacak"
+// olarak kaydedilir.
+func buildRSVPEventResponses(events []models.InvitationEvent, selected map[uint]bool, status models.RSVPStatus) []models.InvitationEventResponse {
+	var responses []models.InvitationEventResponse
+	for _, event := range events {
+		if !event.IsRSVP {
+			continue
+		}
+		responses = append(responses, models.InvitationEventResponse{
+			EventID:     event.ID,
+			IsAttending: selected[event.ID] && status != models.RSVPNotAttending,
+		})
+	}
+	return responses
+}
+
+var _ IRSVPService = (*RSVPService)(nil)
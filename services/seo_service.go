@@ -0,0 +1,341 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"davet.link/configs/fileconfig"
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/markdown"
+	"davet.link/pkg/ogimage"
+	"davet.link/pkg/seo"
+	"davet.link/pkg/socialprofile"
+	"davet.link/repositories"
+
+	"go.uber.org/zap"
+)
+
+type ISEOService interface {
+	SitemapURLs() ([]seo.URL, error)
+}
+
+type SEOService struct {
+	repo repositories.ISitemapRepository
+}
+
+func NewSEOService() ISEOService {
+	return &SEOService{repo: repositories.NewSitemapRepository()}
+}
+
+// SitemapURLs, ana sayfa ile yayındaki içerik sayfalarını, aktif kartvizitleri ve sahibinin
+// herkese açık işaretlediği davetiyeleri site haritası adresleri olarak döner.
+func (s *SEOService) SitemapURLs() ([]seo.URL, error) {
+	pages, err := s.repo.GetPublishedPageSlugs()
+	if err != nil {
+		logconfig.Log.Error("Site haritası: sayfalar alınamadı", zap.Error(err))
+		return nil, errors.New("site haritası oluşturulamadı")
+	}
+	cards, err := s.repo.GetActiveCardSlugs()
+	if err != nil {
+		logconfig.Log.Error("Site haritası: kartlar alınamadı", zap.Error(err))
+		return nil, errors.New("site haritası oluşturulamadı")
+	}
+	invitations, err := s.repo.GetPublicInvitationKeys()
+	if err != nil {
+		logconfig.Log.Error("Site haritası: davetiyeler alınamadı", zap.Error(err))
+		return nil, errors.New("site haritası oluşturulamadı")
+	}
+
+	base := SiteURL()
+	urls := []seo.URL{{Loc: base + "/", Priority: 1}}
+	for _, name := range []string{"dijital_davetiye", "dijital_dugun_davetiyesi", "dijital_egitim_davetiyesi"} {
+		urls = append(urls, seo.URL{Loc: base + "/" + name, Priority: 0.8})
+	}
+	for _, page := range pages {
+		urls = append(urls, seo.URL{Loc: base + "/sayfa/" + page.Key, LastMod: page.UpdatedAt, Priority: 0.3})
+	}
+	for _, card := range cards {
+		urls = append(urls, seo.URL{Loc: base + "/@" + card.Key, LastMod: card.UpdatedAt, Priority: 0.6})
+	}
+	for _, invitation := range invitations {
+		urls = append(urls, seo.URL{Loc: base + "/" + invitation.Key, LastMod: invitation.UpdatedAt, Priority: 0.5})
+	}
+	return urls, nil
+}
+
+// SiteURL, mutlak bağlantılar için sonunda "/" olmayan site adresini döner.
+func SiteURL() string {
+	return strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+}
+
+// PageMeta, içerik sayfasının başlık ve açıklama etiketlerini hazırlar.
+func PageMeta(page *models.Page) seo.Meta {
+	title := page.MetaTitle
+	if title == "" {
+		title = page.Title
+	}
+	description := page.MetaDescription
+	if description == "" {
+		description = seo.Description(markdown.PlainText(page.Body))
+	}
+	return seo.Meta{
+		Title:       title + " | davet.link",
+		Description: description,
+		URL:         SiteURL() + "/sayfa/" + page.Slug,
+		Type:        "article",
+	}
+}
+
+// CardMeta, kartvizit için Open Graph etiketlerini ve schema.org Person verisini hazırlar.
+func CardMeta(card *models.Card) seo.Meta {
+	url := SiteURL() + "/@" + card.Slug
+	description := seo.Description(strings.Join(nonEmpty(card.Title, organizationName(card), card.Venue.Locality()), " · "))
+	if description == "" {
+		description = card.Name + " dijital kartviziti"
+	}
+
+	person := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "Person",
+		"name":     card.Name,
+		"url":      url,
+	}
+	if card.Title != "" {
+		person["jobTitle"] = card.Title
+	}
+	if card.Photo != "" {
+		person["image"] = SiteURL() + "/uploads/cards/" + card.Photo
+	}
+	if card.Email != "" {
+		person["email"] = card.Email
+	}
+	if card.Telephone != "" {
+		person["telephone"] = card.Telephone
+	}
+	if name := organizationName(card); name != "" {
+		worksFor := map[string]any{"@type": "Organization", "name": name}
+		if card.Organization.WebsiteUrl != "" {
+			worksFor["url"] = card.Organization.WebsiteUrl
+		}
+		person["worksFor"] = worksFor
+	}
+	var sameAs []string
+	for _, item := range card.CardSocialMedia {
+		if link, err := socialprofile.Normalize(item.URL, item.SocialMedia.BaseURL, item.SocialMedia.HandlePattern); err == nil {
+			sameAs = append(sameAs, link)
+		}
+	}
+	if card.WebsiteUrl != "" {
+		sameAs = append(sameAs, card.WebsiteUrl)
+	}
+	if len(sameAs) > 0 {
+		person["sameAs"] = sameAs
+	}
+
+	return seo.Meta{
+		Title:       card.Name + " | davet.link",
+		Description: description,
+		URL:         url,
+		Image:       url + "/preview.png?v=" + version(card.UpdatedAt),
+		Type:        "profile",
+		JSONLD:      seo.JSONLD(person),
+	}
+}
+
+// InvitationMeta, davetiye için Open Graph etiketlerini ve schema.org Event verisini hazırlar.
+// Herkese açık işaretlenmemiş davetiyeler arama motorlarına kapatılır.
+func InvitationMeta(invitation *models.Invitation) seo.Meta {
+	url := SiteURL() + "/" + invitation.InvitationKey
+	title := InvitationHeadline(invitation)
+	description := invitation.Description
+	if description == "" {
+		description = invitationSummary(invitation)
+	}
+
+	event := map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "Event",
+		"name":        title,
+		"url":         url,
+		"eventStatus": "https://schema.org/EventScheduled",
+	}
+	if !invitation.Date.IsZero() {
+		if startsAt, hasTime := invitation.StartsAt(); hasTime {
+			event["startDate"] = startsAt.Format(time.RFC3339)
+		} else {
+			event["startDate"] = startsAt.Format("2006-01-02")
+		}
+	}
+	if invitation.Description != "" {
+		event["description"] = seo.Description(invitation.Description)
+	}
+	if invitation.Image != "" {
+		event["image"] = SiteURL() + "/uploads/invitations/" + invitation.Image
+	}
+	if venue := invitation.Venue; venue.Name != "" || venue.AddressText() != "" {
+		event["location"] = venuePlace(venue)
+		event["eventAttendanceMode"] = "https://schema.org/OfflineEventAttendanceMode"
+	} else if invitation.Link != "" {
+		event["location"] = map[string]any{"@type": "VirtualLocation", "url": invitation.Link}
+		event["eventAttendanceMode"] = "https://schema.org/OnlineEventAttendanceMode"
+	}
+
+	return seo.Meta{
+		Title:       title + " | davet.link",
+		Description: seo.Description(description),
+		URL:         url,
+		Image:       url + "/preview.png?v=" + version(invitation.UpdatedAt),
+		Type:        "article",
+		NoIndex:     !invitation.IsPublic,
+		JSONLD:      seo.JSONLD(event),
+	}
+}
+
+// InvitationHeadline, davetiyenin başlığını döner; başlık girilmemişse gelin ve damat ya da
+// kişi adından üretilir.
+func InvitationHeadline(invitation *models.Invitation) string {
+	headline, _ := invitationHeadline(invitation)
+	return headline
+}
+
+// invitationHeadline, başlığı ve başlığın üretildiği detay alanlarının anahtarlarını döner;
+// bu alanlar davetiye sayfasında ayrıca listelenmez.
+func invitationHeadline(invitation *models.Invitation) (string, []string) {
+	if invitation.Title != "" {
+		return invitation.Title, nil
+	}
+	values := invitation.DetailFields().Flatten()
+	if values["bride_name"] != "" && values["groom_name"] != "" {
+		return values["bride_name"] + " & " + values["groom_name"], []string{"bride", "groom"}
+	}
+	if values["person"] != "" {
+		return values["person"], []string{"person"}
+	}
+	if values["title"] != "" {
+		return values["title"], []string{"title"}
+	}
+	return "Davetiye", nil
+}
+
+// CardPreview, kartvizitin paylaşım önizleme görselini tanımlar.
+func CardPreview(card *models.Card) ogimage.Preview {
+	preview := ogimage.Preview{
+		Title:    card.Name,
+		Subtitle: strings.Join(nonEmpty(card.Title, organizationName(card)), " · "),
+		Footer:   previewHost() + "/@" + card.Slug,
+		Photo:    uploadPath("cards", card.Photo),
+	}
+	if card.Organization != nil {
+		if accent, ok := parseHexColor(card.Organization.BrandColor); ok {
+			preview.Accent = accent
+		}
+	}
+	return preview
+}
+
+// InvitationPreview, davetiyenin paylaşım önizleme görselini tanımlar.
+func InvitationPreview(invitation *models.Invitation) ogimage.Preview {
+	return ogimage.Preview{
+		Title:    InvitationHeadline(invitation),
+		Subtitle: invitationSummary(invitation),
+		Footer:   previewHost() + "/" + invitation.InvitationKey,
+		Photo:    uploadPath("invitations", invitation.Image),
+	}
+}
+
+var turkishMonths = [...]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"}
+
+var turkishWeekdays = [...]string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"}
+
+// invitationSummary, tarih, saat ve mekânı "14 Haziran 2026 Cumartesi · 19:00 · Mekan" biçiminde
+// birleştirir.
+func invitationSummary(invitation *models.Invitation) string {
+	var parts []string
+	if !invitation.Date.IsZero() {
+		startsAt, hasTime := invitation.StartsAt()
+		parts = append(parts, fmt.Sprintf("%d %s %d %s", startsAt.Day(), turkishMonths[startsAt.Month()-1], startsAt.Year(), turkishWeekdays[startsAt.Weekday()]))
+		if hasTime {
+			parts = append(parts, startsAt.Format("15:04"))
+		}
+	}
+	parts = append(parts, nonEmpty(invitation.Venue.Name, invitation.Venue.Locality())...)
+	return strings.Join(parts, " · ")
+}
+
+// venuePlace, mekânı schema.org Place verisine çevirir; il ve ilçe PostalAddress alanlarına,
+// koordinatlar GeoCoordinates'e yazılır.
+func venuePlace(venue models.Venue) map[string]any {
+	place := map[string]any{"@type": "Place", "name": venue.Name}
+	if venue.Name == "" {
+		place["name"] = venue.AddressText()
+	}
+	address := map[string]any{"@type": "PostalAddress", "addressCountry": "TR"}
+	if street := strings.Join(nonEmpty(venue.AddressLine1, venue.AddressLine2), ", "); street != "" {
+		address["streetAddress"] = street
+	}
+	if venue.District != "" {
+		address["addressLocality"] = venue.District
+	}
+	if venue.Province != "" {
+		address["addressRegion"] = venue.Province
+	}
+	place["address"] = address
+	if coordinates := venue.Coordinates(); coordinates != nil {
+		place["geo"] = map[string]any{
+			"@type":     "GeoCoordinates",
+			"latitude":  coordinates.Latitude,
+			"longitude": coordinates.Longitude,
+		}
+	}
+	return place
+}
+
+func organizationName(card *models.Card) string {
+	if card.Organization == nil {
+		return ""
+	}
+	return card.Organization.Name
+}
+
+// previewHost, görselin altına yazılan şemasız site adresidir (ör: davet.link).
+func previewHost() string {
+	host := SiteURL()
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	return host
+}
+
+func uploadPath(contentType, name string) string {
+	if name == "" || fileconfig.Config == nil {
+		return ""
+	}
+	return filepath.Join(fileconfig.Config.GetPath(contentType), filepath.Base(name))
+}
+
+// version, önizleme adresine eklenen sürüm değeridir; kayıt güncellendiğinde sosyal ağların
+// önbelleğindeki eski görsel yerine yenisi istenir.
+func version(updatedAt time.Time) string {
+	return strconv.FormatInt(updatedAt.Unix(), 36)
+}
+
+func parseHexColor(hex string) (color.RGBA, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, false
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, true
+}
+
+var _ ISEOService = (*SEOService)(nil)
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"davet.link/configs/logconfig"
 	"davet.link/models"
 	"davet.link/pkg/queryparams"
+	"davet.link/pkg/socialprofile"
 	"davet.link/repositories"
+	"davet.link/requests"
 
 	"go.uber.org/zap"
 )
@@ -19,6 +22,7 @@ type ISocialMediaService interface {
 	UpdateSocialMedia(ctx context.Context, id uint, socialMediaData *models.SocialMedia, updatedBy uint) error
 	DeleteSocialMedia(ctx context.Context, id uint) error
 	GetSocialMediaCount() (int64, error)
+	NormalizeProfileURLs(items []requests.CardSocialMediaRequest) error
 }
 
 type SocialMediaService struct {
@@ -66,9 +70,11 @@ func (s *SocialMediaService) UpdateSocialMedia(ctx context.Context, id uint, soc
 		return errors.New("sosyal medya kaydı bulunamadı")
 	}
 	updateData := map[string]interface{}{
-		"name":      socialMediaData.Name,
-		"icon":      socialMediaData.Icon,
-		"is_active": socialMediaData.IsActive,
+		"name":           socialMediaData.Name,
+		"icon":           socialMediaData.Icon,
+		"is_active":      socialMediaData.IsActive,
+		"base_url":       socialMediaData.BaseURL,
+		"handle_pattern": socialMediaData.HandlePattern,
 	}
 	return s.repo.UpdateSocialMedia(ctx, id, updateData, updatedBy)
 }
@@ -81,4 +87,35 @@ func (s *SocialMediaService) GetSocialMediaCount() (int64, error) {
 	return s.repo.GetSocialMediaCount()
 }
 
+// NormalizeProfileURLs, sosyal medya satırlarındaki kullanıcı adı veya bağlantıları seçilen
+// platformun kanonik profil adresine çevirir; başka bir platformun bağlantısı reddedilir.
+func (s *SocialMediaService) NormalizeProfileURLs(items []requests.CardSocialMediaRequest) error {
+	return normalizeProfileURLs(s.repo, items)
+}
+
+func normalizeProfileURLs(repo repositories.ISocialMediaRepository, items []requests.CardSocialMediaRequest) error {
+	platforms := make(map[uint]*models.SocialMedia)
+	for i := range items {
+		platform, ok := platforms[items[i].SocialMediaID]
+		if !ok {
+			var err error
+			platform, err = repo.GetSocialMediaByID(items[i].SocialMediaID)
+			if err != nil {
+				if !errors.Is(err, repositories.ErrNotFound) {
+					logconfig.Log.Error("Sosyal medya platformu alınamadı", zap.Uint("social_media_id", items[i].SocialMediaID), zap.Error(err))
+				}
+				return errors.New("sosyal medya platformu bulunamadı")
+			}
+			platforms[platform.ID] = platform
+		}
+
+		profileURL, err := socialprofile.Normalize(items[i].URL, platform.BaseURL, platform.HandlePattern)
+		if err != nil {
+			return fmt.Errorf("%s: %s (%s)", platform.Name, err.Error(), items[i].URL)
+		}
+		items[i].URL = profileURL
+	}
+	return nil
+}
+
 var _ ISocialMediaService = (*SocialMediaService)(nil)
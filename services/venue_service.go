@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"davet.link/configs/logconfig"
+	"davet.link/models"
+	"davet.link/pkg/maplink"
+	"davet.link/repositories"
+	"davet.link/requests"
+
+	"go.uber.org/zap"
+)
+
+// shortLinkTimeout, kısa harita bağlantılarının yönlendirmelerini izlerken beklenecek en uzun süredir.
+const shortLinkTimeout = 5 * time.Second
+
+type IVenueService interface {
+	GetProvinces() ([]models.Province, error)
+	GetDistricts(provinceID uint) ([]models.District, error)
+	ResolveVenue(ctx context.Context, req requests.VenueRequest) (models.Venue, error)
+}
+
+type VenueService struct {
+	repo repositories.IProvinceRepository
+}
+
+func NewVenueService() IVenueService {
+	return &VenueService{repo: repositories.NewProvinceRepository()}
+}
+
+func (s *VenueService) GetProvinces() ([]models.Province, error) {
+	provinces, err := s.repo.GetProvinces()
+	if err != nil {
+		logconfig.Log.Error("İller alınamadı", zap.Error(err))
+		return nil, errors.New("iller getirilirken bir hata oluştu")
+	}
+	return provinces, nil
+}
+
+func (s *VenueService) GetDistricts(provinceID uint) ([]models.District, error) {
+	districts, err := s.repo.GetDistrictsByProvinceID(provinceID)
+	if err != nil {
+		logconfig.Log.Error("İlçeler alınamadı", zap.Uint("province_id", provinceID), zap.Error(err))
+		return nil, errors.New("ilçeler getirilirken bir hata oluştu")
+	}
+	return districts, nil
+}
+
+// ResolveVenue, formdaki mekân alanlarını kaydedilecek mekâna çevirir: ilçenin seçilen ile ait
+// olduğunu doğrular, il ve ilçe adlarını doldurur ve harita bağlantısından koordinat çıkarır.
+// Kısa paylaşım bağlantıları yönlendirmeleri izlenerek açılır; koordinat bulunamazsa bağlantı
+// koordinatsız saklanır.
+func (s *VenueService) ResolveVenue(ctx context.Context, req requests.VenueRequest) (models.Venue, error) {
+	venue := models.Venue{
+		Name:         req.Name,
+		AddressLine1: req.AddressLine1,
+		AddressLine2: req.AddressLine2,
+		MapURL:       req.MapURL,
+	}
+
+	if req.DistrictID != 0 {
+		district, err := s.repo.GetDistrictByID(req.DistrictID)
+		if err != nil {
+			return venue, errors.New("seçilen ilçe bulunamadı")
+		}
+		if req.ProvinceID != 0 && district.ProvinceID != req.ProvinceID {
+			return venue, errors.New("seçilen ilçe seçilen ile ait değil")
+		}
+		req.ProvinceID = district.ProvinceID
+		venue.DistrictID = &district.ID
+		venue.District = district.Name
+	}
+	if req.ProvinceID != 0 {
+		province, err := s.repo.GetProvinceByID(req.ProvinceID)
+		if err != nil {
+			return venue, errors.New("seçilen il bulunamadı")
+		}
+		venue.ProvinceID = &province.ID
+		venue.Province = province.Name
+	}
+
+	if venue.MapURL == "" {
+		return venue, nil
+	}
+	coordinates, ok := maplink.Parse(venue.MapURL)
+	if !ok && maplink.IsShortLink(venue.MapURL) {
+		expandCtx, cancel := context.WithTimeout(ctx, shortLinkTimeout)
+		defer cancel()
+		expanded, err := maplink.Expand(expandCtx, venue.MapURL)
+		if err != nil {
+			logconfig.Log.Warn("Kısa harita bağlantısından koordinat alınamadı", zap.String("url", venue.MapURL), zap.Error(err))
+		}
+		coordinates, ok = expanded, err == nil
+	}
+	if ok {
+		venue.Latitude = &coordinates.Latitude
+		venue.Longitude = &coordinates.Longitude
+	}
+	return venue, nil
+}
+
+// venueUpdateData, mekânı venue_ önekli sütunlara göre güncelleme verisine çevirir.
+func venueUpdateData(venue models.Venue) map[string]interface{} {
+	return map[string]interface{}{
+		"venue_name":          venue.Name,
+		"venue_address_line1": venue.AddressLine1,
+		"venue_address_line2": venue.AddressLine2,
+		"venue_province_id":   venue.ProvinceID,
+		"venue_district_id":   venue.DistrictID,
+		"venue_province":      venue.Province,
+		"venue_district":      venue.District,
+		"venue_latitude":      venue.Latitude,
+		"venue_longitude":     venue.Longitude,
+		"venue_map_url":       venue.MapURL,
+	}
+}
+
+var _ IVenueService = (*VenueService)(nil)